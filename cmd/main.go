@@ -1,16 +1,30 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/VladimirStepanov/todo-app/docs"
 	"github.com/VladimirStepanov/todo-app/internal/config"
 	"github.com/VladimirStepanov/todo-app/internal/handler"
+	"github.com/VladimirStepanov/todo-app/internal/metrics"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/repository/blobstore"
 	"github.com/VladimirStepanov/todo-app/internal/repository/postgres"
 	"github.com/VladimirStepanov/todo-app/internal/repository/redisrepo"
 	"github.com/VladimirStepanov/todo-app/internal/server"
 	"github.com/VladimirStepanov/todo-app/internal/service"
 	"github.com/VladimirStepanov/todo-app/pkg/logging"
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
+	"github.com/sirupsen/logrus"
 )
 
 // @title Todo App API
@@ -24,15 +38,27 @@ import (
 // @name Authorization
 
 func main() {
-	cfg, err := config.New(".env")
+	cfg, err := config.New(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		log.Fatal(err)
+	}
 
-	if err != nil {
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := cfg.Validate(); err != nil {
 		log.Fatal(err)
 	}
 
 	db, err := postgres.NewDB(
 		cfg.PostgresHost, cfg.PostgresPort, cfg.PostgresUser,
-		cfg.PostgresPass, cfg.PostgresDB, "disable",
+		cfg.PostgresPass, cfg.PostgresDB, "disable", cfg.PostgresQueryTimeout,
 	)
 
 	if err != nil {
@@ -46,29 +72,255 @@ func main() {
 		return
 	}
 
+	blobStore, err := newBlobStore(cfg)
+	if err != nil {
+		log.Println("Can't create blob store", err)
+		return
+	}
+
 	listRepo := postgres.NewPostgresListRepository(db)
-	tokenRepo := redisrepo.NewRedisRepository(redisClient)
+	tokenRepo := redisrepo.NewRedisRepository(redisClient, cfg.RedisTimeout)
 	userRepo := postgres.NewPostgresUserRepository(db)
 	itemRepo := postgres.NewPostgresItemRepository(db)
+	commentRepo := postgres.NewPostgresCommentRepository(db)
+	attachmentRepo := postgres.NewPostgresAttachmentRepository(db)
+	searchRepo := postgres.NewPostgresSearchRepository(db)
+	activityRepo := postgres.NewPostgresActivityRepository(db)
+	transactor := postgres.NewPostgresTransactor(db)
+	webhookRepo := postgres.NewPostgresWebhookRepository(db)
+	syncRepo := postgres.NewPostgresSyncRepository(db)
+	eventBus := redisrepo.NewRedisEventBus(redisClient, cfg.RedisTimeout)
+	idempotencyRepo := redisrepo.NewRedisIdempotencyRepository(redisClient, cfg.RedisTimeout)
+	rateLimitRepo := redisrepo.NewRedisRateLimitRepository(redisClient, cfg.RedisTimeout)
 	userService := service.NewUserService(userRepo)
-	mailService := service.NewMailService(cfg.Email, cfg.EmailPassword, cfg.Domain)
-	listService := service.NewListService(listRepo)
-	itemService := service.NewItemService(itemRepo)
+	mailService := service.NewMailService(cfg.Email, cfg.EmailPassword, cfg.BaseURL())
+	listService := service.NewListService(listRepo, activityRepo, transactor, eventBus)
+	itemService := service.NewItemService(itemRepo, listRepo, activityRepo, transactor, eventBus, mailService)
+	commentService := service.NewCommentService(commentRepo, itemRepo, listRepo, mailService)
+	attachmentService := service.NewAttachmentService(
+		attachmentRepo, itemRepo, blobStore,
+		cfg.AttachmentMaxSize, cfg.AttachmentTypes,
+	)
+	searchService := service.NewSearchService(searchRepo, cfg.SearchLanguage)
+	trashService := service.NewTrashService(listRepo, itemRepo, activityRepo, transactor, eventBus, cfg.TrashRetention)
+	eventService := service.NewEventService(eventBus)
+	webhookService := service.NewWebhookService(
+		webhookRepo, service.NewWebhookClient(cfg.WebhookTimeout),
+		cfg.WebhookMaxAttempts, cfg.WebhookBackoff, cfg.WebhookMaxFailures,
+	)
+	syncService := service.NewSyncService(syncRepo, transactor, listService, itemService)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyTTL)
+	rateLimitService := service.NewRateLimitService(rateLimitRepo, cfg.RateLimits())
+	healthService := service.NewHealthService(map[string]models.HealthChecker{
+		"postgres": postgres.NewPostgresHealthChecker(db),
+		"redis":    redisrepo.NewRedisHealthChecker(redisClient, cfg.RedisTimeout),
+	}, cfg.HealthCheckTimeout)
 	tokenService := service.NewTokenService(
 		cfg.AccessKey, cfg.RefreshKey,
+		cfg.AccessTTL, cfg.RefreshTTL,
 		cfg.MaxLoggedIn, tokenRepo,
 	)
 
-	logger, err := logging.GetLogger("trace", "log.txt")
+	logger, err := logging.GetLogger(cfg.LoggingOptions())
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	handler := handler.New(userService, mailService, tokenService, listService, itemService, logger)
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.TracingOptions())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	h := handler.New(
+		userService, mailService, tokenService,
+		listService, itemService, commentService,
+		attachmentService, searchService, trashService, eventService,
+		webhookService, syncService, idempotencyService, rateLimitService,
+		healthService, logger,
+	)
+	h.RequestTimeout = cfg.RequestTimeout
+	h.MetricsToken = cfg.MetricsToken
+	h.HSTSMaxAge = cfg.HSTSMaxAge
+	h.AttachmentMaxSize = cfg.AttachmentMaxSize
+	h.TrustedProxies = cfg.TrustedProxyNets()
+	h.CORS = handler.CORSOptions{
+		AllowOrigins:     cfg.CORSAllowOrigins,
+		AllowMethods:     cfg.CORSAllowMethods,
+		AllowHeaders:     cfg.CORSAllowHeaders,
+		ExposeHeaders:    cfg.CORSExposeHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	}
+
+	metrics.RegisterDB(db.DB)
+	metrics.RegisterRedis(redisClient)
+	metrics.RegisterSessions(tokenService.ActiveSessions, cfg.RedisTimeout)
 
 	docs.SwaggerInfo.Host = cfg.Domain
-	srv := server.New(cfg.GetServerAddr(), handler.InitRoutes(cfg.Mode))
-	if err := srv.Run(); err != nil {
-		log.Fatal(err)
+	if cfg.TLSEnabled() {
+		docs.SwaggerInfo.Schemes = []string{"https"}
+	}
+
+	serverOpts := server.Options{
+		CertFile:       cfg.TLSCertFile,
+		KeyFile:        cfg.TLSKeyFile,
+		ReloadInterval: cfg.TLSReloadInterval,
+		OnReload: func(err error) {
+			if err != nil {
+				logger.Error("certificate reload: ", err)
+				return
+			}
+			logger.Info("certificate is reloaded")
+		},
+		RedirectAddr: cfg.TLSRedirectAddr,
+		H2C:          cfg.H2C,
+		WriteTimeout: cfg.WriteTimeout,
+		LongLived:    handler.IsEventStream,
+	}
+	srv, err := server.New(cfg.GetServerAddr(), h.InitRoutes(cfg.Mode), serverOpts)
+	if err != nil {
+		logger.Fatal("Can't create server: ", err)
+	}
+	srv.RegisterOnShutdown(h.Close)
+
+	var workers sync.WaitGroup
+	workers.Add(3)
+	go runAttachmentCleanup(ctx, &workers, attachmentService, cfg.AttachmentCleanupInterval, logger)
+	go runTrashPurge(ctx, &workers, trashService, cfg.TrashPurgeInterval, logger)
+	go runWebhookDelivery(ctx, &workers, webhookService, cfg.WebhookDeliveryInterval, logger)
+
+	go reloadOnHangup(ctx, logger, srv)
+
+	go func() {
+		if err := srv.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	logger.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// pools are closed after in-flight requests and background workers are done
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("server shutdown: ", err)
+	}
+	workers.Wait()
+
+	if err := db.Close(); err != nil {
+		logger.Error("postgres close: ", err)
+	}
+	if err := redisClient.Close(); err != nil {
+		logger.Error("redis close: ", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("tracing shutdown: ", err)
+	}
+}
+
+func newBlobStore(cfg *config.Config) (models.BlobStore, error) {
+	if cfg.BlobStore == "s3" {
+		client, err := blobstore.NewS3Client(
+			cfg.S3Endpoint, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3UseSSL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewS3Store(client, cfg.S3Bucket)
+	}
+
+	return blobstore.NewLocalStore(cfg.BlobLocalPath)
+}
+
+// runAttachmentCleanup periodically removes blobs of deleted attachments until ctx is done
+func runAttachmentCleanup(ctx context.Context, wg *sync.WaitGroup, as models.AttachmentService, interval time.Duration, logger *logrus.Logger) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := as.Cleanup(ctx); err != nil && ctx.Err() == nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+// runTrashPurge periodically removes lists and items from trash after retention period
+func runTrashPurge(ctx context.Context, wg *sync.WaitGroup, ts models.TrashService, interval time.Duration, logger *logrus.Logger) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ts.Purge(ctx); err != nil && ctx.Err() == nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+// runWebhookDelivery periodically sends pending webhook deliveries
+func runWebhookDelivery(ctx context.Context, wg *sync.WaitGroup, ws models.WebhookService, interval time.Duration, logger *logrus.Logger) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ws.Deliver(ctx); err != nil && ctx.Err() == nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+// reloadOnHangup sets LOG_LEVEL from reloaded config and reloads TLS certificate
+// on SIGHUP until ctx is done
+func reloadOnHangup(ctx context.Context, logger *logrus.Logger, srv *server.Server) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if err := srv.ReloadCertificates(); err != nil {
+				logger.Error("certificate reload: ", err)
+			}
+
+			cfg, err := config.New(os.Args[1:])
+			if err == nil {
+				err = logging.SetLevel(logger, cfg.LogLevel)
+			}
+			if err != nil {
+				logger.Error("log level reload: ", err)
+				continue
+			}
+			logger.Info("log level is set to ", cfg.LogLevel)
+		}
 	}
 }
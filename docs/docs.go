@@ -38,6 +38,48 @@ var doc = `{
                 ],
                 "summary": "Get all user lists",
                 "operationId": "get-lists",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "page size, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return archived lists instead of active ones",
+                        "name": "archived",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "lists",
@@ -45,6 +87,12 @@ var doc = `{
                             "$ref": "#/definitions/handler.UserListsResponse"
                         }
                     },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "user is not authorized",
                         "schema": {
@@ -149,6 +197,12 @@ var doc = `{
                         "name": "list_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -158,6 +212,12 @@ var doc = `{
                             "$ref": "#/definitions/models.List"
                         }
                     },
+                    "304": {
+                        "description": "list is not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "400": {
                         "description": "bad input, auth header errors",
                         "schema": {
@@ -190,6 +250,7 @@ var doc = `{
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "List is moved to trash and can be restored until it is purged.\nArchived list is read-only, it is unarchived before deletion",
                 "produces": [
                     "application/json"
                 ],
@@ -205,6 +266,12 @@ var doc = `{
                         "name": "list_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of list version to delete",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -238,6 +305,18 @@ var doc = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "list is archived",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "list version does not match If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "internal error",
                         "schema": {
@@ -276,6 +355,12 @@ var doc = `{
                         "schema": {
                             "$ref": "#/definitions/models.UpdateListReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of list version to update",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -309,6 +394,18 @@ var doc = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "list is archived",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "list version does not match If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "internal error",
                         "schema": {
@@ -318,24 +415,22 @@ var doc = `{
                 }
             }
         },
-        "/api/lists/{list_id}/edit-role": {
-            "patch": {
+        "/api/lists/{list_id}/activity": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "History of list and item mutations with changed fields",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "lists"
                 ],
-                "summary": "Edit user role for list",
-                "operationId": "edit-role",
+                "summary": "Get list activity",
+                "operationId": "get-list-activity",
                 "parameters": [
                     {
                         "type": "integer",
@@ -345,20 +440,35 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "description": "edit-role input",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.editRoleReq"
-                        }
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "page size, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort direction",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "list activity",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ListActivityResponse"
                         }
                     },
                     "400": {
@@ -380,7 +490,7 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "list not found, user not found",
+                        "description": "list not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -394,24 +504,22 @@ var doc = `{
                 }
             }
         },
-        "/api/lists/{list_id}/items": {
-            "get": {
+        "/api/lists/{list_id}/archive": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Archived list is read-only for all members and hidden from lists by default",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "lists"
                 ],
-                "summary": "Get items",
-                "operationId": "get-items",
+                "summary": "Archive list",
+                "operationId": "archive-list",
                 "parameters": [
                     {
                         "type": "integer",
@@ -423,9 +531,9 @@ var doc = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "all user items",
+                        "description": "success",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserItemsResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -440,6 +548,12 @@ var doc = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "list not found",
                         "schema": {
@@ -453,8 +567,10 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/lists/{list_id}/edit-role": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -467,10 +583,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "lists"
                 ],
-                "summary": "Create item",
-                "operationId": "create-item",
+                "summary": "Edit user role for list",
+                "operationId": "edit-role",
                 "parameters": [
                     {
                         "type": "integer",
@@ -480,20 +596,20 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "description": "item input",
+                        "description": "edit-role input",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.itemCreateReq"
+                            "$ref": "#/definitions/handler.editRoleReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success item creation",
+                        "description": "success",
                         "schema": {
-                            "$ref": "#/definitions/handler.ItemCreateResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -508,8 +624,20 @@ var doc = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "list not found",
+                        "description": "list not found, user not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "list is archived",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -523,24 +651,22 @@ var doc = `{
                 }
             }
         },
-        "/api/lists/{list_id}/items/{item_id}": {
+        "/api/lists/{list_id}/events": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Server-Sent Events with list activity, event name is activity action and id is activity id.\nEventSource can't set headers, so access token may be passed in access_token query parameter.\nSuch stream is closed when the token expires, client reconnects with new token",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "items"
+                    "lists"
                 ],
-                "summary": "Get item",
-                "operationId": "get-item",
+                "summary": "Stream list events",
+                "operationId": "list-events",
                 "parameters": [
                     {
                         "type": "integer",
@@ -550,18 +676,17 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "item_id",
-                        "name": "item_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "access token if Authorization header can't be set",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "item",
+                        "description": "stream of events",
                         "schema": {
-                            "$ref": "#/definitions/models.Item"
+                            "$ref": "#/definitions/models.Activity"
                         }
                     },
                     "400": {
@@ -577,7 +702,7 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "list not found, item not found",
+                        "description": "list not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -589,8 +714,10 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/lists/{list_id}/items": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -605,8 +732,8 @@ var doc = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Delete item",
-                "operationId": "delete-item",
+                "summary": "Get items",
+                "operationId": "get-items",
                 "parameters": [
                     {
                         "type": "integer",
@@ -616,18 +743,53 @@ var doc = `{
                         "required": true
                     },
                     {
+                        "maximum": 100,
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "item_id",
-                        "name": "item_id",
-                        "in": "path",
-                        "required": true
+                        "description": "page size, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "title",
+                            "due_at",
+                            "priority"
+                        ],
+                        "type": "string",
+                        "description": "sort field, items without due date are the last",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "filter by done status",
+                        "name": "done",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "list items",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.UserItemsResponse"
                         }
                     },
                     "400": {
@@ -643,7 +805,7 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "list not found, item not found",
+                        "description": "list not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -656,7 +818,7 @@ var doc = `{
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -671,8 +833,8 @@ var doc = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Update item",
-                "operationId": "update-item",
+                "summary": "Create item",
+                "operationId": "create-item",
                 "parameters": [
                     {
                         "type": "integer",
@@ -682,31 +844,24 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "item_id",
-                        "name": "item_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "input",
+                        "description": "item input",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateItemReq"
+                            "$ref": "#/definitions/models.CreateItemReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "success item creation",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ItemCreateResponse"
                         }
                     },
                     "400": {
-                        "description": "bad input, auth header errors",
+                        "description": "bad input, auth header errors, assignee is not a list member",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -718,7 +873,7 @@ var doc = `{
                         }
                     },
                     "404": {
-                        "description": "list not found, item not found",
+                        "description": "list not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -732,8 +887,8 @@ var doc = `{
                 }
             }
         },
-        "/api/lists/{list_id}/items/{item_id}/done": {
-            "patch": {
+        "/api/lists/{list_id}/items/{item_id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -748,8 +903,8 @@ var doc = `{
                 "tags": [
                     "items"
                 ],
-                "summary": "Done item",
-                "operationId": "done-item",
+                "summary": "Get item",
+                "operationId": "get-item",
                 "parameters": [
                     {
                         "type": "integer",
@@ -764,11 +919,23 @@ var doc = `{
                         "name": "item_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "item",
+                        "schema": {
+                            "$ref": "#/definitions/models.Item"
+                        }
+                    },
+                    "304": {
+                        "description": "item is not modified",
                         "schema": {
                             "type": "string"
                         }
@@ -798,10 +965,14 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/confirm/{link}": {
-            "get": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Item is moved to trash and can be restored until it is purged",
                 "consumes": [
                     "application/json"
                 ],
@@ -809,17 +980,30 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "items"
                 ],
-                "summary": "Confirm email",
-                "operationId": "confirm",
+                "summary": "Delete item",
+                "operationId": "delete-item",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "link confirmation",
-                        "name": "link",
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of item version",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -829,8 +1013,26 @@ var doc = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "page not found",
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "item version does not match If-Match",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -842,10 +1044,8 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/logout": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -858,10 +1058,41 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "items"
+                ],
+                "summary": "Update item",
+                "operationId": "update-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateItemReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of item version to update",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Log out",
-                "operationId": "logout",
                 "responses": {
                     "200": {
                         "description": "success",
@@ -870,13 +1101,25 @@ var doc = `{
                         }
                     },
                     "400": {
-                        "description": "auth header errors",
+                        "description": "bad input, auth header errors, assignee is not a list member",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "user unauthorized",
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "item version does not match If-Match",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -890,45 +1133,58 @@ var doc = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
-                "consumes": [
-                    "application/json"
+        "/api/lists/{list_id}/items/{item_id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "attachments"
                 ],
-                "summary": "Refresh token",
-                "operationId": "refresh-token",
+                "summary": "Get item attachments",
+                "operationId": "get-attachments",
                 "parameters": [
                     {
-                        "description": "refresh token",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.refreshReq"
-                        }
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "tokens",
+                        "description": "item attachments",
                         "schema": {
-                            "$ref": "#/definitions/handler.TokensResponse"
+                            "$ref": "#/definitions/handler.ItemAttachmentsResponse"
                         }
                     },
                     "400": {
-                        "description": "bad token",
+                        "description": "bad input, auth header errors",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "user unauthorized",
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -940,59 +1196,81 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/sign-in": {
+            },
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Size and file type limits are set in config",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "attachments"
                 ],
-                "summary": "Sign in",
-                "operationId": "login",
+                "summary": "Upload attachment",
+                "operationId": "create-attachment",
                 "parameters": [
                     {
-                        "description": "credentials",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.signupReq"
-                        }
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "attachment file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "tokens",
+                        "description": "success upload",
                         "schema": {
-                            "$ref": "#/definitions/handler.TokensResponse"
+                            "$ref": "#/definitions/handler.AttachmentCreateResponse"
                         }
                     },
                     "400": {
-                        "description": "bad input",
+                        "description": "bad input, auth header errors",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "user not activated",
+                        "description": "user is not authorized",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "user not found",
+                        "description": "list not found, item not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "max logged in users in one account",
+                    "413": {
+                        "description": "file is too large",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "file type is not allowed",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1006,45 +1284,65 @@ var doc = `{
                 }
             }
         },
-        "/auth/sign-up": {
-            "post": {
-                "consumes": [
-                    "application/json"
+        "/api/lists/{list_id}/items/{item_id}/attachments/{attachment_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "auth"
+                    "attachments"
                 ],
-                "summary": "Sign up",
-                "operationId": "register",
+                "summary": "Download attachment",
+                "operationId": "download-attachment",
                 "parameters": [
                     {
-                        "description": "register",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.signupReq"
-                        }
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "attachment_id",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "attachment content",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "bad input",
+                        "description": "bad input, auth header errors",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "user already exists",
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found, attachment not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1056,206 +1354,2638 @@ var doc = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "handler.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.ItemCreateResponse": {
-            "type": "object",
-            "properties": {
-                "item_id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.ListCreateResponse": {
-            "type": "object",
-            "properties": {
-                "list_id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.TokensResponse": {
-            "type": "object",
-            "properties": {
-                "access_token": {
-                    "type": "string"
-                },
-                "refresh_token": {
-                    "type": "string"
-                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The uploader or a list admin can delete an attachment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Delete attachment",
+                "operationId": "delete-attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "attachment_id",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not the uploader or admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found, attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/items/{item_id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get item comments",
+                "operationId": "get-comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "item comments",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ItemCommentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mentioned list members (@email) are notified by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Create comment",
+                "operationId": "create-comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "comment input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.commentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success comment creation",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommentCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/items/{item_id}/comments/{comment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The author or a list admin can delete a comment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete comment",
+                "operationId": "delete-comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "comment_id",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not the author or admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found, comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Only the author can edit a comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Update comment",
+                "operationId": "update-comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "comment_id",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "comment input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.commentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not the author",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found, comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/items/{item_id}/done": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Done item",
+                "operationId": "done-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of item version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "item version does not match If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/items/{item_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore item from trash",
+                "operationId": "restore-item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "item_id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found, item not found in trash",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/members/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Items assigned to removed user become unassigned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Remove user from list",
+                "operationId": "remove-member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user_id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors, user is not a member of the list",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "list is archived",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Only list admin can restore list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore list from trash",
+                "operationId": "restore-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found in trash",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lists"
+                ],
+                "summary": "Unarchive list",
+                "operationId": "unarchive-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get list webhooks",
+                "operationId": "get-webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListWebhooksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Activity of the list is sent to url as POST with X-Todo-Signature header,\nit contains \"sha256=\" and hex HMAC-SHA256 of request body with webhook secret.\nEmpty events means all actions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create list webhook",
+                "operationId": "create-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateWebhookReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "list is archived",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/webhooks/{webhook_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete list webhook",
+                "operationId": "delete-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook_id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list or webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "list is archived",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Setting active to true enables webhook disabled after repeated failures",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update list webhook",
+                "operationId": "update-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook_id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateWebhookReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list or webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "list is archived",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/lists/{list_id}/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Pending deliveries have next_attempt_at, failed ones ran out of attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook delivery log",
+                "operationId": "get-webhook-deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "list_id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook_id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "page size, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort direction",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "current user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "list or webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/items": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Get items from all user lists",
+                "operationId": "get-my-items",
+                "parameters": [
+                    {
+                        "enum": [
+                            "me"
+                        ],
+                        "type": "string",
+                        "description": "only items assigned to current user",
+                        "name": "assigned",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "user items",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserItemsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists are returned if current user is admin, items are returned from not deleted lists",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Get deleted lists and items",
+                "operationId": "get-trash",
+                "responses": {
+                    "200": {
+                        "description": "deleted lists and items",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Full-text search in titles and descriptions of lists and items available to user.\nMatched words in snippets are highlighted with \u003cb\u003e\u003c/b\u003e tags, other text of snippets is HTML-escaped",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search lists and items",
+                "operationId": "search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search query, websearch syntax",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "english",
+                            "ru",
+                            "russian"
+                        ],
+                        "type": "string",
+                        "description": "query language, default is set in config",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "results ordered by rank",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sync": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists, items and members changed after since token, including deleted ones.\nWithout token all lists and items of user are returned. Returned token is passed as since on the next sync\nChanges made concurrently with sync may be returned again by the next sync, so clients apply them idempotently",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Get changes for offline client",
+                "operationId": "get-sync-changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token from previous sync",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SyncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mutations are applied in order, each one gets applied, conflict or rejected status.\nConflict contains current server version of list or item. Retried mutation with the same client_id is not applied twice",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Apply mutations of offline client",
+                "operationId": "apply-sync-mutations",
+                "parameters": [
+                    {
+                        "description": "mutations",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SyncApplyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input, auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user is not authorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/confirm/{link}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Confirm email",
+                "operationId": "confirm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "link confirmation",
+                        "name": "link",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "page not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out",
+                "operationId": "logout",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "auth header errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh token",
+                "operationId": "refresh-token",
+                "parameters": [
+                    {
+                        "description": "refresh token",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.refreshReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "tokens",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TokensResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sign-in": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Sign in",
+                "operationId": "login",
+                "parameters": [
+                    {
+                        "description": "credentials",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.signupReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "tokens",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TokensResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "user not activated",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "max logged in users in one account",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sign-up": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Sign up",
+                "operationId": "register",
+                "parameters": [
+                    {
+                        "description": "register",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.signupReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "bad input",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "user already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Returns 200 while process is able to serve requests, dependencies are not checked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "operationId": "healthz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Pings postgres and redis, returns 503 if any of them is down",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "operationId": "readyz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    },
+                    "503": {
+                        "description": "some dependency is down",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.AttachmentCreateResponse": {
+            "type": "object",
+            "properties": {
+                "attachment_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CommentCreateResponse": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "stable error code",
+                    "type": "string"
+                },
+                "detail": {
+                    "description": "message in language from Accept-Language header",
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "invalid_params": {
+                    "description": "fields which failed validation",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.invalidArgument"
+                    }
+                },
+                "request_id": {
+                    "description": "X-Request-ID of request, it is logged with request errors",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "uri of problem type, ends with code",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ItemAttachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Attachment"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ItemCommentsResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Comment"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ItemCreateResponse": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListActivityResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "empty if there is no more activity",
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Activity"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListCreateResponse": {
+            "type": "object",
+            "properties": {
+                "list_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListWebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Webhook"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchResult"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SyncApplyResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncResult"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SyncResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "$ref": "#/definitions/models.SyncChanges"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TokensResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TrashResponse": {
+            "type": "object",
+            "properties": {
+                "result": {
+                    "$ref": "#/definitions/models.Trash"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UserItemsResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "empty if there are no more items",
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Item"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UserListsResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "empty if there are no more lists",
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.List"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.WebhookCreateResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.WebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "empty if there are no more deliveries",
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookDelivery"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.commentReq": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.editRoleReq": {
+            "type": "object",
+            "required": [
+                "is_admin",
+                "user_id"
+            ],
+            "properties": {
+                "is_admin": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.invalidArgument": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "param": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.listCreateReq": {
+            "type": "object",
+            "required": [
+                "description",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.refreshReq": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.signupReq": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Activity": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changes": {
+                    "$ref": "#/definitions/models.Changes"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "member_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Attachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Changes": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/models.FieldChange"
+            }
+        },
+        "models.Comment": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateItemReq": {
+            "type": "object",
+            "required": [
+                "description",
+                "title"
+            ],
+            "properties": {
+                "assignee_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "priority": {
+                    "description": "0 - none, 1 - low, 2 - medium, 3 - high",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateWebhookReq": {
+            "type": "object",
+            "required": [
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "description": "activity actions to deliver, empty means all actions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                }
+            }
+        },
+        "models.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "latency_ms": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.HealthCheck"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Item": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "integer"
+                },
+                "comments_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
             }
         },
-        "handler.UserItemsResponse": {
+        "models.List": {
             "type": "object",
             "properties": {
-                "result": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Item"
-                    }
+                "archived_at": {
+                    "type": "string"
                 },
-                "status": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.UserListsResponse": {
+        "models.SearchResult": {
             "type": "object",
             "properties": {
-                "result": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.List"
-                    }
+                "item_id": {
+                    "type": "integer"
                 },
-                "status": {
+                "list_id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "handler.editRoleReq": {
+        "models.SyncChanges": {
             "type": "object",
-            "required": [
-                "is_admin",
-                "user_id"
-            ],
             "properties": {
-                "is_admin": {
-                    "type": "boolean"
+                "deleted_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "user_id": {
-                    "type": "integer"
+                "deleted_lists": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Item"
+                    }
+                },
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.List"
+                    }
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UsersList"
+                    }
+                },
+                "removed_members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UsersList"
+                    }
+                },
+                "token": {
+                    "description": "pass as since parameter to get the following changes",
+                    "type": "string"
                 }
             }
         },
-        "handler.itemCreateReq": {
+        "models.SyncMutation": {
             "type": "object",
             "required": [
-                "description",
-                "title"
+                "client_id",
+                "op"
             ],
             "properties": {
+                "assignee_id": {
+                    "type": "integer"
+                },
+                "client_id": {
+                    "description": "client generated id, mutation with the same id is applied once",
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
+                "due_at": {
+                    "type": "string"
+                },
+                "item_client_id": {
+                    "description": "client id of item.create mutation, used instead of item_id for items created offline",
+                    "type": "string"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "list_client_id": {
+                    "description": "client id of list.create mutation, used instead of list_id for lists created offline",
+                    "type": "string"
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
                 "title": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "version which client changed, mutation conflicts if server version differs",
+                    "type": "integer"
                 }
             }
         },
-        "handler.listCreateReq": {
+        "models.SyncReq": {
             "type": "object",
             "required": [
-                "description",
-                "title"
+                "mutations"
             ],
             "properties": {
-                "description": {
+                "mutations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncMutation"
+                    }
+                }
+            }
+        },
+        "models.SyncResult": {
+            "type": "object",
+            "properties": {
+                "client_id": {
                     "type": "string"
                 },
-                "title": {
+                "error": {
+                    "type": "string"
+                },
+                "item": {
+                    "$ref": "#/definitions/models.Item"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "list": {
+                    "$ref": "#/definitions/models.List"
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "handler.refreshReq": {
+        "models.Trash": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Item"
+                    }
+                },
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.List"
+                    }
                 }
             }
         },
-        "handler.signupReq": {
+        "models.UpdateItemReq": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "assignee_id": {
+                    "description": "0 removes current assignee",
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
-                "password": {
+                "due_at": {
+                    "description": "zero time removes current due date",
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "models.Item": {
+        "models.UpdateListReq": {
             "type": "object",
             "properties": {
                 "description": {
                     "type": "string"
                 },
-                "done": {
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdateWebhookReq": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "true enables disabled webhook and resets its failures",
                     "type": "boolean"
                 },
-                "id": {
-                    "type": "integer"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "list_id": {
-                    "type": "integer"
+                "secret": {
+                    "type": "string"
                 },
-                "title": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "models.List": {
+        "models.UsersList": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "is_admin": {
+                    "type": "boolean"
                 },
                 "list_id": {
                     "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.UpdateItemReq": {
+        "models.Webhook": {
             "type": "object",
             "properties": {
-                "description": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "title": {
+                "created_by": {
+                    "type": "integer"
+                },
+                "events": {
+                    "description": "activity actions to deliver, empty means all actions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "models.UpdateListReq": {
+        "models.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "description": {
+                "activity_id": {
+                    "type": "integer"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "title": {
+                "error": {
                     "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "response_code": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         }
@@ -2,49 +2,196 @@ package config
 
 import (
 	"fmt"
-	"strconv"
+	"net"
+	"strings"
+	"time"
 
-	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
 )
 
 type Config struct {
 	AppAddr string `env:"APP_ADDR" env-default:"0.0.0.0"`
 	AppPort string `env:"APP_PORT" env-default:"8080"`
 
+	TLSCertFile       string        `env:"TLS_CERT_FILE"`
+	TLSKeyFile        string        `env:"TLS_KEY_FILE"`
+	TLSReloadInterval time.Duration `env:"TLS_RELOAD_INTERVAL" env-default:"1m"`
+	TLSRedirectAddr   string        `env:"TLS_REDIRECT_ADDR"`
+	HSTSMaxAge        time.Duration `env:"HSTS_MAX_AGE" env-default:"8760h"`
+	H2C               bool          `env:"H2C" env-default:"false"`
+	PublicHTTPS       bool          `env:"PUBLIC_HTTPS" env-default:"false"`
+	TrustedProxies    []string      `env:"TRUSTED_PROXIES"`
+
+	CORSAllowOrigins     []string      `env:"CORS_ALLOW_ORIGINS"`
+	CORSAllowMethods     []string      `env:"CORS_ALLOW_METHODS" env-default:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`
+	CORSAllowHeaders     []string      `env:"CORS_ALLOW_HEADERS" env-default:"Content-Type,Authorization,Accept,Cache-Control,X-Requested-With,If-Match,If-None-Match,Idempotency-Key,X-Request-ID,traceparent,tracestate"`
+	CORSExposeHeaders    []string      `env:"CORS_EXPOSE_HEADERS" env-default:"ETag,Idempotent-Replayed,X-Request-ID,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,RateLimit-Policy,Retry-After"`
+	CORSAllowCredentials bool          `env:"CORS_ALLOW_CREDENTIALS" env-default:"false"`
+	CORSMaxAge           time.Duration `env:"CORS_MAX_AGE" env-default:"12h"`
+
 	PostgresHost string `env:"POSTGRES_HOST" env-default:"127.0.0.1"`
 	PostgresPort string `env:"POSTGRES_PORT" env-default:"5432"`
 	PostgresUser string `env:"POSTGRES_USER" env-default:"postgres"`
-	PostgresPass string `env:"POSTGRES_PASSWORD" env-default:"postgres"`
+	PostgresPass string `env:"POSTGRES_PASSWORD" env-default:"postgres" secret:"true"`
 	PostgresDB   string `env:"POSTGRES_DB" env-default:"todo"`
 
+	PostgresQueryTimeout time.Duration `env:"POSTGRES_QUERY_TIMEOUT" env-default:"5s"`
+
 	Mode string `env:"APP_MODE" env-default:"debug"`
 
+	LogLevel      string `env:"LOG_LEVEL" env-default:"info"`
+	LogFormat     string `env:"LOG_FORMAT" env-default:"json"`
+	LogOutput     string `env:"LOG_OUTPUT" env-default:"stdout"`
+	LogFile       string `env:"LOG_FILE" env-default:"log.txt"`
+	LogMaxSize    int    `env:"LOG_MAX_SIZE" env-default:"100"`
+	LogMaxAge     int    `env:"LOG_MAX_AGE" env-default:"7"`
+	LogMaxBackups int    `env:"LOG_MAX_BACKUPS" env-default:"5"`
+	LogCompress   bool   `env:"LOG_COMPRESS" env-default:"false"`
+
+	RequestTimeout     time.Duration `env:"REQUEST_TIMEOUT" env-default:"30s"`
+	WriteTimeout       time.Duration `env:"WRITE_TIMEOUT" env-default:"60s"`
+	ShutdownTimeout    time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"30s"`
+	HealthCheckTimeout time.Duration `env:"HEALTH_CHECK_TIMEOUT" env-default:"2s"`
+
+	MetricsToken string `env:"METRICS_TOKEN" secret:"true"`
+
+	TraceExporter     string  `env:"TRACE_EXPORTER" env-default:"none"`
+	TraceOTLPEndpoint string  `env:"TRACE_OTLP_ENDPOINT" env-default:"127.0.0.1:4318"`
+	TraceOTLPInsecure bool    `env:"TRACE_OTLP_INSECURE" env-default:"true"`
+	TraceSampleRatio  float64 `env:"TRACE_SAMPLE_RATIO" env-default:"1"`
+
 	Email         string `env:"EMAIL"`
-	EmailPassword string `env:"EMAIL_PASSWORD"`
+	EmailPassword string `env:"EMAIL_PASSWORD" secret:"true"`
 
 	Domain string `env:"DOMAIN"`
 
-	RedisHost      string `env:"REDIS_HOST" env-default:"127.0.0.1"`
-	RedisPort      string `env:"REDIS_PORT" env-default:"6379"`
-	MaxLoggedInStr string `env:"MAX_LOGGED_IN" env-default:"6"`
-	MaxLoggedIn    int
+	RedisHost    string        `env:"REDIS_HOST" env-default:"127.0.0.1"`
+	RedisPort    string        `env:"REDIS_PORT" env-default:"6379"`
+	RedisTimeout time.Duration `env:"REDIS_TIMEOUT" env-default:"3s"`
+	MaxLoggedIn  int           `env:"MAX_LOGGED_IN" env-default:"6"`
+
+	AccessKey  string        `env:"JWT_ACCESS_KEY" env-default:"access_key" secret:"true"`
+	RefreshKey string        `env:"JWT_REFRESH_KEY" env-default:"refresh_key" secret:"true"`
+	AccessTTL  time.Duration `env:"JWT_ACCESS_TTL" env-default:"15m"`
+	RefreshTTL time.Duration `env:"JWT_REFRESH_TTL" env-default:"168h"`
+
+	BlobStore     string `env:"BLOB_STORE" env-default:"local"`
+	BlobLocalPath string `env:"BLOB_LOCAL_PATH" env-default:"uploads"`
+
+	S3Endpoint  string `env:"S3_ENDPOINT" env-default:"127.0.0.1:9000"`
+	S3AccessKey string `env:"S3_ACCESS_KEY"`
+	S3SecretKey string `env:"S3_SECRET_KEY" secret:"true"`
+	S3Bucket    string `env:"S3_BUCKET" env-default:"todo"`
+	S3UseSSL    bool   `env:"S3_USE_SSL" env-default:"false"`
+
+	AttachmentMaxSize         int64         `env:"ATTACHMENT_MAX_SIZE" env-default:"10485760"`
+	AttachmentTypes           []string      `env:"ATTACHMENT_TYPES" env-default:"image/png,image/jpeg,image/gif,image/webp,application/pdf"`
+	AttachmentCleanupInterval time.Duration `env:"ATTACHMENT_CLEANUP_INTERVAL" env-default:"1m"`
+
+	SearchLanguage string `env:"SEARCH_LANGUAGE" env-default:"english"`
+
+	TrashRetention     time.Duration `env:"TRASH_RETENTION" env-default:"720h"`
+	TrashPurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" env-default:"1h"`
+
+	WebhookTimeout          time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+	WebhookMaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"5"`
+	WebhookBackoff          time.Duration `env:"WEBHOOK_BACKOFF" env-default:"30s"`
+	WebhookMaxFailures      int           `env:"WEBHOOK_MAX_FAILURES" env-default:"20"`
+	WebhookDeliveryInterval time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" env-default:"5s"`
+
+	IdempotencyTTL time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+
+	RateLimitAuthRequests int           `env:"RATE_LIMIT_AUTH_REQUESTS" env-default:"20"`
+	RateLimitAuthWindow   time.Duration `env:"RATE_LIMIT_AUTH_WINDOW" env-default:"1m"`
+	RateLimitAPIRequests  int           `env:"RATE_LIMIT_API_REQUESTS" env-default:"600"`
+	RateLimitAPIWindow    time.Duration `env:"RATE_LIMIT_API_WINDOW" env-default:"1m"`
 
-	AccessKey  string `env:"JWT_ACCESS_KEY" env-default:"access_key"`
-	RefreshKey string `env:"JWT_REFRESH_KEY" env-default:"refresh_key"`
+	// ConfigFile, EnvFile and PrintConfig are set by flags only
+	ConfigFile  string
+	EnvFile     string
+	PrintConfig bool
 }
 
-func New(configName string) (*Config, error) {
-	cfg := &Config{}
-	err := cleanenv.ReadConfig(configName, cfg)
-	if err != nil {
-		return nil, err
+// RateLimits returns limits of route groups
+func (c *Config) RateLimits() map[string]models.RateLimit {
+	return map[string]models.RateLimit{
+		models.RateLimitAuth: {Requests: c.RateLimitAuthRequests, Window: c.RateLimitAuthWindow},
+		models.RateLimitAPI:  {Requests: c.RateLimitAPIRequests, Window: c.RateLimitAPIWindow},
 	}
+}
+
+// LoggingOptions returns options of logger
+func (c *Config) LoggingOptions() logging.Options {
+	return logging.Options{
+		Level:      c.LogLevel,
+		Format:     c.LogFormat,
+		Output:     c.LogOutput,
+		File:       c.LogFile,
+		MaxSizeMB:  c.LogMaxSize,
+		MaxAgeDays: c.LogMaxAge,
+		MaxBackups: c.LogMaxBackups,
+		Compress:   c.LogCompress,
+	}
+}
+
+// TracingOptions returns options of span exporter
+func (c *Config) TracingOptions() tracing.Options {
+	return tracing.Options{
+		Exporter:    c.TraceExporter,
+		Endpoint:    c.TraceOTLPEndpoint,
+		Insecure:    c.TraceOTLPInsecure,
+		SampleRatio: c.TraceSampleRatio,
+		Service:     "todo-app",
+	}
+}
+
+// TrustedProxyNets returns ranges of trusted proxies, they are checked by Validate
+func (c *Config) TrustedProxyNets() []*net.IPNet {
+	nets, _ := parseTrustedProxies(c.TrustedProxies)
+	return nets
+}
+
+// parseTrustedProxies parses ip addresses and CIDR ranges of trusted proxies
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("bad proxy address %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("bad proxy range %q", proxy)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// TLSEnabled returns true if server serves HTTPS
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != ""
+}
 
-	cfg.MaxLoggedIn, err = strconv.Atoi(cfg.MaxLoggedInStr)
-	if err != nil {
-		return nil, err
+// BaseURL returns url of app for links in emails, https is used if TLS is
+// enabled or terminated by proxy
+func (c *Config) BaseURL() string {
+	scheme := "http"
+	if c.TLSEnabled() || c.PublicHTTPS {
+		scheme = "https"
 	}
-	return cfg, err
+	return fmt.Sprintf("%s://%s", scheme, c.Domain)
 }
 
 // Return addr:port for server
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+	return path
+}
+
+// setenv sets environment variable for duration of test
+func setenv(t *testing.T, key, value string) {
+	prev, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestNewPrecedence(t *testing.T) {
+	yamlFile := writeFile(t, "config.yaml", strings.Join([]string{
+		"app_port: 8081",
+		"log_level: debug",
+		"log_format: text",
+		"trace_otlp_insecure: false",
+		"jwt_access_ttl: 5m",
+		"attachment_types: [image/png, application/pdf]",
+	}, "\n"))
+	envFile := writeFile(t, ".env", "APP_PORT=8082\nLOG_LEVEL=error\n")
+	setenv(t, "LOG_LEVEL", "warn")
+
+	cfg, err := New([]string{"--config", yamlFile, "--env-file", envFile, "--app-port=8083"})
+	require.NoError(t, err)
+
+	require.Equal(t, "8083", cfg.AppPort)
+	require.Equal(t, "warn", cfg.LogLevel)
+	require.Equal(t, "text", cfg.LogFormat)
+	require.Equal(t, false, cfg.TraceOTLPInsecure)
+	require.Equal(t, 5*time.Minute, cfg.AccessTTL)
+	require.Equal(t, 168*time.Hour, cfg.RefreshTTL)
+	require.Equal(t, []string{"image/png", "application/pdf"}, cfg.AttachmentTypes)
+	require.Equal(t, "6379", cfg.RedisPort)
+	require.Equal(t, 6, cfg.MaxLoggedIn)
+}
+
+func TestNewSecretFile(t *testing.T) {
+	keyFile := writeFile(t, "access_key", "secret-from-file\n")
+	setenv(t, "JWT_ACCESS_KEY_FILE", keyFile)
+
+	cfg, err := New([]string{"--env-file", writeFile(t, ".env", "")})
+	require.NoError(t, err)
+	require.Equal(t, "secret-from-file", cfg.AccessKey)
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   func(t *testing.T) []string
+		errMsg string
+	}{
+		{
+			name: "Missing explicit env file",
+			args: func(t *testing.T) []string {
+				return []string{"--env-file", filepath.Join(t.TempDir(), ".env")}
+			},
+			errMsg: "no such file",
+		},
+		{
+			name: "Unknown yaml key",
+			args: func(t *testing.T) []string {
+				return []string{"--config", writeFile(t, "config.yaml", "app_prot: 8080")}
+			},
+			errMsg: `unknown key "app_prot"`,
+		},
+		{
+			name: "Bad duration",
+			args: func(t *testing.T) []string {
+				return []string{"--jwt-access-ttl", "15"}
+			},
+			errMsg: "bad value of JWT_ACCESS_TTL",
+		},
+		{
+			name: "Secret and secret file",
+			args: func(t *testing.T) []string {
+				return []string{"--env-file", writeFile(t, ".env", "JWT_REFRESH_KEY=a\nJWT_REFRESH_KEY_FILE=/run/secrets/key\n")}
+			},
+			errMsg: "both JWT_REFRESH_KEY and JWT_REFRESH_KEY_FILE are set",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.args(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	key := strings.Repeat("a", minKeyLen)
+
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{
+			name: "Debug mode allows defaults",
+			args: []string{},
+		},
+		{
+			name:   "Release mode refuses defaults",
+			args:   []string{"--app-mode=release", "--domain=todo.example.com"},
+			errMsg: "JWT_ACCESS_KEY must be at least 32 characters in release mode; JWT_REFRESH_KEY must be at least 32 characters in release mode; POSTGRES_PASSWORD must not be default in release mode",
+		},
+		{
+			name:   "Release mode refuses same keys",
+			args:   []string{"--app-mode=release", "--domain=todo.example.com", "--postgres-password=p", "--jwt-access-key=" + key, "--jwt-refresh-key=" + key},
+			errMsg: "JWT_ACCESS_KEY and JWT_REFRESH_KEY must differ",
+		},
+		{
+			name:   "Bad values",
+			args:   []string{"--app-port=http", "--jwt-refresh-ttl=1m", "--log-output=syslog"},
+			errMsg: "APP_PORT must be port number; LOG_OUTPUT must be one of stdout, file, both; JWT_REFRESH_TTL must be greater than JWT_ACCESS_TTL",
+		},
+		{
+			name:   "Bad cors origins",
+			args:   []string{"--cors-allow-origins=https://app.example.com/path,https://app*.example.com,*"},
+			errMsg: `CORS_ALLOW_ORIGINS has bad origin "https://app.example.com/path", * must be the only origin or start host, e.g. https://*.example.com; CORS_ALLOW_ORIGINS has bad origin "https://app*.example.com", * must be the only origin or start host, e.g. https://*.example.com; CORS_ALLOW_ORIGINS has bad origin "*", * must be the only origin or start host, e.g. https://*.example.com`,
+		},
+		{
+			name:   "Cors credentials with any origin",
+			args:   []string{"--cors-allow-origins=*", "--cors-allow-credentials=true"},
+			errMsg: "CORS_ALLOW_CREDENTIALS must not be set with * origin",
+		},
+		{
+			name:   "Bad trusted proxies",
+			args:   []string{"--trusted-proxies=10.0.0.0/8,proxy"},
+			errMsg: `TRUSTED_PROXIES has bad proxy address "proxy"`,
+		},
+		{
+			name:   "Write timeout before request timeout",
+			args:   []string{"--write-timeout=10s"},
+			errMsg: "WRITE_TIMEOUT must be greater than REQUEST_TIMEOUT, timeout response is written after it",
+		},
+		{
+			name: "Disabled write timeout",
+			args: []string{"--write-timeout=0"},
+		},
+		{
+			name: "Valid cors origins",
+			args: []string{"--cors-allow-origins=https://app.example.com,https://*.example.org,http://localhost:3000"},
+		},
+		{
+			name: "Valid release",
+			args: []string{"--app-mode=release", "--domain=todo.example.com", "--postgres-password=p", "--jwt-access-key=" + key, "--jwt-refresh-key=b" + key},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := New(append(tc.args, "--env-file", writeFile(t, ".env", "")))
+			require.NoError(t, err)
+
+			err = cfg.Validate()
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, "invalid config: "+tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestTrustedProxyNets(t *testing.T) {
+	cfg := &Config{TrustedProxies: []string{"10.0.0.1", "172.16.0.0/12", "::1"}}
+	nets := cfg.TrustedProxyNets()
+	require.Len(t, nets, 3)
+	require.Equal(t, "10.0.0.1/32", nets[0].String())
+	require.Equal(t, "172.16.0.0/12", nets[1].String())
+	require.Equal(t, "::1/128", nets[2].String())
+
+	_, err := parseTrustedProxies([]string{"10.0.0.0/33"})
+	require.EqualError(t, err, `bad proxy range "10.0.0.0/33"`)
+}
+
+func TestPrint(t *testing.T) {
+	envFile := writeFile(t, ".env", "")
+	cfg, err := New([]string{"--env-file", envFile, "--metrics-token=token", "--log-level=debug"})
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, cfg.Print(buf))
+	require.Contains(t, buf.String(), "metrics_token: '[REDACTED]'")
+	require.Contains(t, buf.String(), "jwt_access_key: '[REDACTED]'")
+	require.Contains(t, buf.String(), "email_password:\n")
+	require.NotContains(t, buf.String(), "access_key\n")
+
+	// printed config is valid config file
+	printed, err := New([]string{"--env-file", envFile, "--config", writeFile(t, "config.yaml", buf.String())})
+	require.NoError(t, err)
+	require.Equal(t, "debug", printed.LogLevel)
+	require.Equal(t, cfg.AttachmentTypes, printed.AttachmentTypes)
+	require.Equal(t, cfg.TrashRetention, printed.TrashRetention)
+}
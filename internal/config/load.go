@@ -0,0 +1,294 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces values of secrets in printed config
+const redacted = "[REDACTED]"
+
+// fileSuffix is suffix of variable with path of file which contains secret, e.g. JWT_ACCESS_KEY_FILE
+const fileSuffix = "_FILE"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// field is config value which is set by variable name
+type field struct {
+	name   string
+	value  reflect.Value
+	def    *string
+	secret bool
+}
+
+// yamlKey returns key of field in yaml file, e.g. app_port
+func (f *field) yamlKey() string {
+	return strings.ToLower(f.name)
+}
+
+// flagName returns name of command-line flag of field, e.g. app-port
+func (f *field) flagName() string {
+	return strings.ReplaceAll(f.yamlKey(), "_", "-")
+}
+
+// fields returns fields of cfg with env tag in order of declaration
+func fields(cfg *Config) []*field {
+	v := reflect.ValueOf(cfg).Elem()
+	res := []*field{}
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		name, ok := sf.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+
+		f := &field{name: name, value: v.Field(i), secret: sf.Tag.Get("secret") == "true"}
+		if def, ok := sf.Tag.Lookup("env-default"); ok {
+			f.def = &def
+		}
+		res = append(res, f)
+	}
+
+	return res
+}
+
+// New returns config from args without program name. Later sources override earlier ones:
+// defaults, yaml file (--config or CONFIG_FILE), .env file (--env-file), environment
+// and flags, e.g. --app-port=8081 for APP_PORT. Secrets may be read from file
+// which is set by variable with _FILE suffix
+func New(args []string) (*Config, error) {
+	cfg := &Config{}
+	cfgFields := fields(cfg)
+
+	fs := flag.NewFlagSet("todo-app", flag.ContinueOnError)
+	fs.StringVar(&cfg.ConfigFile, "config", os.Getenv("CONFIG_FILE"), "path of yaml config file")
+	fs.StringVar(&cfg.EnvFile, "env-file", ".env", "path of .env file, may be missing unless set explicitly")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "print config with redacted secrets and exit")
+
+	flagValues := map[string]*string{}
+	for _, f := range cfgFields {
+		flagValues[f.name] = fs.String(f.flagName(), "", "overrides "+f.name)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	isSet := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { isSet[f.Name] = true })
+
+	for _, f := range cfgFields {
+		if f.def == nil {
+			continue
+		}
+		if err := setValue(f, *f.def); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ConfigFile != "" {
+		if err := cfg.readYAML(cfgFields); err != nil {
+			return nil, err
+		}
+	}
+
+	env, err := readEnv(cfg.EnvFile, isSet["env-file"])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range cfgFields {
+		value, ok, err := lookup(env, f)
+		if err != nil {
+			return nil, err
+		}
+
+		if isSet[f.flagName()] {
+			value, ok = *flagValues[f.name], true
+		}
+
+		if !ok {
+			continue
+		}
+		if err := setValue(f, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// readYAML sets fields from flat yaml file with lower case variable names as keys
+func (c *Config) readYAML(cfgFields []*field) error {
+	data, err := ioutil.ReadFile(c.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("parse %s: %w", c.ConfigFile, err)
+	}
+
+	byKey := map[string]*field{}
+	for _, f := range cfgFields {
+		byKey[f.yamlKey()] = f
+	}
+
+	for key, v := range values {
+		f, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("unknown key %q in %s", key, c.ConfigFile)
+		}
+
+		if err := setValue(f, yamlString(v)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// yamlString returns yaml value as it would be written in environment variable
+func yamlString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, p := range v {
+			parts[i] = fmt.Sprint(p)
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+// readEnv returns variables of .env file overridden by environment
+func readEnv(envFile string, required bool) (map[string]string, error) {
+	env, err := godotenv.Read(envFile)
+	if os.IsNotExist(err) && !required {
+		env = map[string]string{}
+	} else if err != nil {
+		return nil, fmt.Errorf("read %s: %w", envFile, err)
+	}
+
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		env[parts[0]] = parts[1]
+	}
+
+	return env, nil
+}
+
+// lookup returns value of field from env, secret is read from file set by variable with _FILE suffix
+func lookup(env map[string]string, f *field) (string, bool, error) {
+	value, ok := env[f.name]
+	if !f.secret {
+		return value, ok, nil
+	}
+
+	path, fromFile := env[f.name+fileSuffix]
+	if !fromFile {
+		return value, ok, nil
+	}
+	if ok {
+		return "", false, fmt.Errorf("both %s and %s%s are set", f.name, f.name, fileSuffix)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("read %s%s: %w", f.name, fileSuffix, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// setValue parses value into field
+func setValue(f *field, value string) error {
+	v := f.value
+	var err error
+
+	switch {
+	case v.Type() == durationType:
+		var d time.Duration
+		d, err = time.ParseDuration(value)
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(value)
+	case v.Kind() == reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(value)
+		v.SetBool(b)
+	case v.Kind() == reflect.Int || v.Kind() == reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(value, 10, 64)
+		v.SetInt(i)
+	case v.Kind() == reflect.Float64:
+		var fl float64
+		fl, err = strconv.ParseFloat(value, 64)
+		v.SetFloat(fl)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		list := []string{}
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+		v.Set(reflect.ValueOf(list))
+	default:
+		err = fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	if err != nil {
+		return fmt.Errorf("bad value of %s: %w", f.name, err)
+	}
+	return nil
+}
+
+// formatValue returns value of field as it is written in config
+func formatValue(f *field) string {
+	v := f.value
+
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+	case v.Kind() == reflect.Slice:
+		return strings.Join(v.Interface().([]string), ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Print writes config in yaml file format, values of secrets are redacted
+func (c *Config) Print(w io.Writer) error {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, f := range fields(c) {
+		value := formatValue(f)
+		if f.secret && value != "" {
+			value = redacted
+		}
+
+		doc.Content = append(doc.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: f.yamlKey()},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+		)
+	}
+
+	enc := yaml.NewEncoder(w)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
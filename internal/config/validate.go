@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
+	"github.com/sirupsen/logrus"
+)
+
+// app modes, they are modes of gin
+const (
+	ModeDebug   = "debug"
+	ModeRelease = "release"
+	ModeTest    = "test"
+)
+
+// minKeyLen is min length of jwt keys in release mode
+const minKeyLen = 32
+
+// validator collects all problems of config, so they can be fixed at once
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, format string, args ...interface{}) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) oneOf(name, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, "%s must be one of %s", name, strings.Join(allowed, ", "))
+}
+
+func (v *validator) port(name, value string) {
+	p, err := strconv.Atoi(value)
+	v.check(err == nil && p > 0 && p < 65536, "%s must be port number", name)
+}
+
+func (v *validator) positive(name string, d time.Duration) {
+	v.check(d > 0, "%s must be positive", name)
+}
+
+// Validate returns error with all problems of config. Insecure defaults
+// of secrets are refused in release mode
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.oneOf("APP_MODE", c.Mode, ModeDebug, ModeRelease, ModeTest)
+	v.port("APP_PORT", c.AppPort)
+	v.port("POSTGRES_PORT", c.PostgresPort)
+	v.port("REDIS_PORT", c.RedisPort)
+
+	_, err := logrus.ParseLevel(c.LogLevel)
+	v.check(err == nil, "LOG_LEVEL must be logrus level")
+	v.oneOf("LOG_FORMAT", c.LogFormat, logging.FormatJSON, logging.FormatText)
+	v.oneOf("LOG_OUTPUT", c.LogOutput, logging.OutputStdout, logging.OutputFile, logging.OutputBoth)
+
+	v.oneOf("TRACE_EXPORTER", c.TraceExporter, tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP)
+	v.check(c.TraceSampleRatio >= 0 && c.TraceSampleRatio <= 1, "TRACE_SAMPLE_RATIO must be between 0 and 1")
+
+	v.oneOf("BLOB_STORE", c.BlobStore, "local", "s3")
+
+	v.check((c.TLSCertFile == "") == (c.TLSKeyFile == ""), "TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	if c.TLSEnabled() {
+		v.positive("TLS_RELOAD_INTERVAL", c.TLSReloadInterval)
+		v.check(!c.H2C, "H2C must not be set with TLS, HTTP/2 is negotiated over TLS")
+	} else {
+		v.check(c.TLSRedirectAddr == "", "TLS_REDIRECT_ADDR requires TLS_CERT_FILE")
+	}
+	v.check(c.HSTSMaxAge >= 0, "HSTS_MAX_AGE must not be negative")
+
+	if _, err := parseTrustedProxies(c.TrustedProxies); err != nil {
+		v.check(false, "TRUSTED_PROXIES has %s", err)
+	}
+
+	for _, origin := range c.CORSAllowOrigins {
+		v.check(validOrigin(origin, len(c.CORSAllowOrigins)),
+			"CORS_ALLOW_ORIGINS has bad origin %q, * must be the only origin or start host, e.g. https://*.example.com", origin)
+	}
+	v.check(!(c.CORSAllowCredentials && contains(c.CORSAllowOrigins, "*")),
+		"CORS_ALLOW_CREDENTIALS must not be set with * origin")
+	v.check(c.CORSMaxAge >= 0, "CORS_MAX_AGE must not be negative")
+
+	v.check(c.AccessKey != "" && c.RefreshKey != "", "JWT_ACCESS_KEY and JWT_REFRESH_KEY must be set")
+	v.positive("JWT_ACCESS_TTL", c.AccessTTL)
+	v.check(c.RefreshTTL > c.AccessTTL, "JWT_REFRESH_TTL must be greater than JWT_ACCESS_TTL")
+	v.check(c.MaxLoggedIn > 0, "MAX_LOGGED_IN must be positive")
+
+	// zero disables these timeouts
+	v.check(c.PostgresQueryTimeout >= 0, "POSTGRES_QUERY_TIMEOUT must not be negative")
+	v.check(c.RequestTimeout >= 0, "REQUEST_TIMEOUT must not be negative")
+	v.check(c.WriteTimeout >= 0, "WRITE_TIMEOUT must not be negative")
+	v.check(c.WriteTimeout == 0 || c.WriteTimeout > c.RequestTimeout,
+		"WRITE_TIMEOUT must be greater than REQUEST_TIMEOUT, timeout response is written after it")
+
+	v.positive("REDIS_TIMEOUT", c.RedisTimeout)
+	v.positive("SHUTDOWN_TIMEOUT", c.ShutdownTimeout)
+	v.positive("HEALTH_CHECK_TIMEOUT", c.HealthCheckTimeout)
+	v.positive("ATTACHMENT_CLEANUP_INTERVAL", c.AttachmentCleanupInterval)
+	v.positive("TRASH_RETENTION", c.TrashRetention)
+	v.positive("TRASH_PURGE_INTERVAL", c.TrashPurgeInterval)
+	v.positive("WEBHOOK_TIMEOUT", c.WebhookTimeout)
+	v.positive("WEBHOOK_DELIVERY_INTERVAL", c.WebhookDeliveryInterval)
+	v.positive("IDEMPOTENCY_TTL", c.IdempotencyTTL)
+	v.positive("RATE_LIMIT_AUTH_WINDOW", c.RateLimitAuthWindow)
+	v.positive("RATE_LIMIT_API_WINDOW", c.RateLimitAPIWindow)
+
+	if c.Mode == ModeRelease {
+		v.check(c.AccessKey != "access_key" && len(c.AccessKey) >= minKeyLen,
+			"JWT_ACCESS_KEY must be at least %d characters in release mode", minKeyLen)
+		v.check(c.RefreshKey != "refresh_key" && len(c.RefreshKey) >= minKeyLen,
+			"JWT_REFRESH_KEY must be at least %d characters in release mode", minKeyLen)
+		v.check(c.AccessKey != c.RefreshKey, "JWT_ACCESS_KEY and JWT_REFRESH_KEY must differ")
+		v.check(c.PostgresPass != "postgres", "POSTGRES_PASSWORD must not be default in release mode")
+		v.check(c.Domain != "", "DOMAIN must be set in release mode")
+	}
+
+	if len(v.problems) != 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(v.problems, "; "))
+	}
+	return nil
+}
+
+// validOrigin returns true for origin without path, * is allowed as the only
+// origin or as first label of host
+func validOrigin(origin string, count int) bool {
+	if origin == "*" {
+		return count == 1
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") ||
+		u.Path != "" || u.RawQuery != "" || u.User != nil {
+		return false
+	}
+
+	host := strings.TrimPrefix(u.Host, "*.")
+	return host != "" && !strings.Contains(host, "*")
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
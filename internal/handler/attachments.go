@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is room for multipart headers and boundaries of upload body
+const multipartOverhead = 64 << 10
+
+// countingReader counts bytes read from reader
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// CreateAttachment godoc
+// @Summary Upload attachment
+// @Description Size and file type limits are set in config
+// @Tags attachments
+// @Accept  multipart/form-data
+// @Produce  json
+// @ID create-attachment
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Param file formData file true "attachment file"
+// @Success 200 {object} AttachmentCreateResponse "success upload"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 404 {object} ErrorResponse "list not found, item not found"
+// @Failure 413 {object} ErrorResponse "file is too large"
+// @Failure 415 {object} ErrorResponse "file type is not allowed"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/attachments [post]
+func (h *Handler) attachmentCreate(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	// body is limited before parsing, otherwise whole body is spooled to disk.
+	// One byte over limit is read to tell too large body from malformed one
+	limit := h.AttachmentMaxSize + multipartOverhead
+	body := &countingReader{Reader: c.Request.Body}
+	if h.AttachmentMaxSize > 0 {
+		body.Reader = io.LimitReader(c.Request.Body, limit+1)
+	}
+	c.Request.Body = readCloser{body, c.Request.Body}
+
+	fileHeader, err := c.FormFile("file")
+	if h.AttachmentMaxSize > 0 && body.n > limit {
+		h.Error(c, models.ErrFileTooLarge)
+		return
+	}
+	if err != nil {
+		h.Error(c, models.ErrNoFile)
+		return
+	}
+
+	userID, err := h.GetUserId(c)
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+	defer file.Close()
+
+	attachmentID, err := h.AttachmentService.Create(c.Request.Context(),
+		listID, itemID, userID, fileHeader.Filename, fileHeader.Size, file,
+	)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &AttachmentCreateResponse{"success", attachmentID})
+}
+
+// GetAttachments godoc
+// @Summary Get item attachments
+// @Tags attachments
+// @Produce  json
+// @ID get-attachments
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Success 200 {object} ItemAttachmentsResponse "item attachments"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 404 {object} ErrorResponse "list not found, item not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/attachments [get]
+func (h *Handler) getAttachments(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	result, err := h.AttachmentService.GetAttachments(c.Request.Context(), listID, itemID)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ItemAttachmentsResponse{"success", result})
+}
+
+// DownloadAttachment godoc
+// @Summary Download attachment
+// @Tags attachments
+// @Produce  octet-stream
+// @ID download-attachment
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Param attachment_id path int true "attachment_id"
+// @Success 200 {file} file "attachment content"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 404 {object} ErrorResponse "list not found, item not found, attachment not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/attachments/{attachment_id} [get]
+func (h *Handler) downloadAttachment(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	attachmentID, err := strconv.ParseInt(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	attachment, r, err := h.AttachmentService.Download(c.Request.Context(), listID, itemID, attachmentID)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+	defer r.Close()
+
+	disposition := mime.FormatMediaType(
+		"attachment", map[string]string{"filename": attachment.FileName},
+	)
+
+	c.DataFromReader(
+		http.StatusOK, attachment.Size, attachment.ContentType, r,
+		map[string]string{"Content-Disposition": disposition},
+	)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete attachment
+// @Description The uploader or a list admin can delete an attachment
+// @Tags attachments
+// @Produce  json
+// @ID delete-attachment
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Param attachment_id path int true "attachment_id"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not the uploader or admin"
+// @Failure 404 {object} ErrorResponse "list not found, item not found, attachment not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/attachments/{attachment_id} [delete]
+func (h *Handler) deleteAttachment(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	attachmentID, err := strconv.ParseInt(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	userID, err := h.GetUserId(c)
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	err = h.AttachmentService.Delete(c.Request.Context(), listID, itemID, attachmentID, userID, c.GetBool(CtxIsAdmin))
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+	})
+}
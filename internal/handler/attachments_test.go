@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAttachment = &models.Attachment{
+		ID:          1,
+		ItemID:      testItem.ID,
+		UserID:      1,
+		FileName:    "report.pdf",
+		ContentType: "application/pdf",
+		Size:        5,
+	}
+)
+
+func multipartBody(t *testing.T, field string, data []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, testAttachment.FileName)
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return body, writer.FormDataContentType()
+}
+
+func TestAttachmentCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		itemID string
+		field  string
+		data   []byte
+		retID  int64
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Bad itemID",
+			itemID: "bad",
+			field:  "file",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:   "No file",
+			itemID: "1",
+			field:  "other",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrNoFile.Error(),
+		},
+		{
+			name:   "Item not found",
+			itemID: "1",
+			field:  "file",
+			retErr: models.ErrNoItem,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoItem.Error(),
+		},
+		{
+			name:   "File too large",
+			itemID: "1",
+			field:  "file",
+			retErr: models.ErrFileTooLarge,
+			code:   http.StatusRequestEntityTooLarge,
+			errMsg: models.ErrFileTooLarge.Error(),
+		},
+		{
+			name:   "Body exceeds limit",
+			itemID: "1",
+			field:  "file",
+			data:   bytes.Repeat([]byte("a"), 10+multipartOverhead),
+			code:   http.StatusRequestEntityTooLarge,
+			errMsg: models.ErrFileTooLarge.Error(),
+		},
+		{
+			name:   "File type not allowed",
+			itemID: "1",
+			field:  "file",
+			retErr: models.ErrFileTypeNotAllowed,
+			code:   http.StatusUnsupportedMediaType,
+			errMsg: models.ErrFileTypeNotAllowed.Error(),
+		},
+		{
+			name:   "Create return unknown error",
+			itemID: "1",
+			field:  "file",
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name:   "Success upload",
+			itemID: "1",
+			field:  "file",
+			retID:  777,
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			as := new(mocks.AttachmentService)
+			as.On(
+				"Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+				testAttachment.FileName, int64(5), mock.Anything,
+			).Return(tc.retID, tc.retErr)
+
+			data := tc.data
+			if data == nil {
+				data = []byte("%PDF-")
+			}
+			body, contentType := multipartBody(t, tc.field, data)
+			headers := map[string]string{
+				"Authorization": "Bearer token",
+				"Content-Type":  contentType,
+			}
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, as, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			handler.AttachmentMaxSize = 10
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPost,
+				fmt.Sprintf("/api/lists/1/items/%s/attachments", tc.itemID),
+				body,
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				crResp := &AttachmentCreateResponse{}
+				err := json.Unmarshal(data, crResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.retID, crResp.AttachmentID)
+			}
+		})
+	}
+}
+
+func TestGetAttachments(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name   string
+		retRes []*models.Attachment
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Item not found",
+			retErr: models.ErrNoItem,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoItem.Error(),
+		},
+		{
+			name:   "Success get",
+			retRes: []*models.Attachment{testAttachment},
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			as := new(mocks.AttachmentService)
+			as.On("GetAttachments", mock.Anything, mock.Anything, mock.Anything).Return(tc.retRes, tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, as, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/lists/1/items/1/attachments",
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := &ItemAttachmentsResponse{}
+				err := json.Unmarshal(data, resp)
+				require.NoError(t, err)
+				require.Equal(t, tc.retRes, resp.Result)
+			}
+		})
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name         string
+		attachmentID string
+		isAdminErr   error
+		retErr       error
+		code         int
+		errMsg       string
+	}{
+		{
+			name:         "Bad attachmentID",
+			attachmentID: "bad",
+			code:         http.StatusBadRequest,
+			errMsg:       models.ErrBadParam.Error(),
+		},
+		{
+			name:         "List not found",
+			attachmentID: "1",
+			isAdminErr:   models.ErrNoList,
+			code:         http.StatusNotFound,
+			errMsg:       models.ErrNoList.Error(),
+		},
+		{
+			name:         "Attachment not found",
+			attachmentID: "1",
+			retErr:       models.ErrNoAttachment,
+			code:         http.StatusNotFound,
+			errMsg:       models.ErrNoAttachment.Error(),
+		},
+		{
+			name:         "Download return unknown error",
+			attachmentID: "1",
+			retErr:       ErrUnknown,
+			code:         http.StatusInternalServerError,
+			errMsg:       "Internal server error",
+		},
+		{
+			name:         "Success download by list member",
+			attachmentID: "1",
+			isAdminErr:   models.ErrNoListAccess,
+			code:         http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(tc.isAdminErr)
+
+			as := new(mocks.AttachmentService)
+			as.On("Download", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+				testAttachment, ioutil.NopCloser(bytes.NewReader([]byte("%PDF-"))), tc.retErr,
+			)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, as, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				fmt.Sprintf("/api/lists/1/items/1/attachments/%s", tc.attachmentID),
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				require.Equal(t, []byte("%PDF-"), data)
+			}
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name   string
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Attachment not found",
+			retErr: models.ErrNoAttachment,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoAttachment.Error(),
+		},
+		{
+			name:   "No access",
+			retErr: models.ErrNoAttachmentAccess,
+			code:   http.StatusForbidden,
+			errMsg: models.ErrNoAttachmentAccess.Error(),
+		},
+		{
+			name: "Success delete",
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			as := new(mocks.AttachmentService)
+			as.On(
+				"Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, true,
+			).Return(tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, as, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodDelete,
+				"/api/lists/1/items/1/attachments/1",
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			}
+		})
+	}
+}
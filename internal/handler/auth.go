@@ -3,7 +3,6 @@ package handler
 import (
 	"net/http"
 
-	"github.com/VladimirStepanov/todo-app/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
@@ -32,37 +31,18 @@ func (h *Handler) signIn(c *gin.Context) {
 		return
 	}
 
-	user, err := h.UserService.SignIn(req.Email, req.Password)
+	user, err := h.UserService.SignIn(c.Request.Context(), req.Email, req.Password)
 
 	if err != nil {
-		switch err {
-		case models.ErrBadUser:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		case models.ErrUserNotActivated:
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		recordSignIn(err)
+		h.Error(c, err)
 		return
 	}
 
-	td, err := h.TokenService.NewTokenPair(user.ID)
+	td, err := h.TokenService.NewTokenPair(c.Request.Context(), user.ID)
+	recordSignIn(err)
 	if err != nil {
-		switch err {
-		case models.ErrMaxLoggedIn:
-			c.JSON(http.StatusUnprocessableEntity, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
 		return
 	}
 
@@ -90,21 +70,14 @@ func (h *Handler) signUp(c *gin.Context) {
 		return
 	}
 
-	user, err := h.UserService.Create(req.Email, req.Password)
+	user, err := h.UserService.Create(c.Request.Context(), req.Email, req.Password)
 
 	if err != nil {
-		if err == models.ErrUserAlreadyExists {
-			c.JSON(http.StatusConflict, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		} else {
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
 		return
 	}
 
-	err = h.MailService.SendConfirmationsEmail(user)
+	err = h.MailService.SendConfirmationsEmail(c.Request.Context(), user)
 
 	if err != nil {
 		h.InternalError(c, err)
@@ -140,7 +113,7 @@ func (h *Handler) logout(c *gin.Context) {
 		return
 	}
 
-	err = h.TokenService.Logout(userID, userUUID)
+	err = h.TokenService.Logout(c.Request.Context(), userID, userUUID)
 	if err != nil {
 		h.InternalError(c, err)
 		return
@@ -164,14 +137,10 @@ func (h *Handler) logout(c *gin.Context) {
 func (h *Handler) confirm(c *gin.Context) {
 	link := c.Param("link")
 
-	err := h.UserService.ConfirmEmail(link)
+	err := h.UserService.ConfirmEmail(c.Request.Context(), link)
 
 	if err != nil {
-		if err == models.ErrConfirmLinkNotExists {
-			h.PageNotFound(c)
-		} else {
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
 		return
 	}
 
@@ -203,23 +172,10 @@ func (h *Handler) refreshToken(c *gin.Context) {
 		return
 	}
 
-	td, err := h.TokenService.Refresh(req.Token)
+	td, err := h.TokenService.Refresh(c.Request.Context(), req.Token)
 
 	if err != nil {
-		switch err {
-		case models.ErrBadToken:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		case models.ErrUserUnauthorized, models.ErrTokenExpired:
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
 		return
 	}
 
@@ -3,7 +3,9 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/VladimirStepanov/todo-app/internal/models"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
@@ -19,17 +21,15 @@ type invalidArgument struct {
 // bindData is helper function, returns false if data is not bound
 func bindData(c *gin.Context, req interface{}) bool {
 	if c.ContentType() != "application/json" {
-		msg := fmt.Sprintf("%s only accepts Content-Type application/json", c.FullPath())
-
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": msg,
-		})
+		problem(
+			c, http.StatusBadRequest, codeBadContentType,
+			"only Content-Type application/json is accepted", nil,
+		)
 		return false
 	}
 	// Bind incoming json to struct and check for validation errors
 	if err := c.ShouldBindJSON(req); err != nil {
 		if errs, ok := err.(validator.ValidationErrors); ok {
-			// could probably extract this, it is also in middleware_auth_user
 			var invalidArgs []invalidArgument
 
 			for _, err := range errs {
@@ -41,18 +41,46 @@ func bindData(c *gin.Context, req interface{}) bool {
 				})
 			}
 
-			msg := "Invalid request parameters. See invalidArgs"
-
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":       msg,
-				"invalidArgs": invalidArgs,
-			})
+			problem(
+				c, http.StatusBadRequest, codeValidation,
+				"Invalid request parameters. See invalid_params", invalidArgs,
+			)
 			return false
 		}
 
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Can't parse JSON request"})
+		problem(c, http.StatusBadRequest, codeBadJSON, "Can't parse JSON request", nil)
 		return false
 	}
 
 	return true
 }
+
+// bindPage reads limit, cursor, sort and order query params, returns false if params are invalid
+func bindPage(c *gin.Context) (*models.PageReq, bool) {
+	page := &models.PageReq{
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+	}
+
+	badParam := func() (*models.PageReq, bool) {
+		errorResponse(c, models.ErrBadParam)
+		return nil, false
+	}
+
+	if limit, ok := c.GetQuery("limit"); ok {
+		var err error
+		if page.Limit, err = strconv.Atoi(limit); err != nil {
+			return badParam()
+		}
+	}
+
+	switch c.Query("order") {
+	case "", "asc":
+	case "desc":
+		page.Desc = true
+	default:
+		return badParam()
+	}
+
+	return page, true
+}
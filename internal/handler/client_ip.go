@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) isTrustedProxy(ip net.IP) bool {
+	for _, ipNet := range h.TrustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns ip of connection peer. X-Forwarded-For is used only if peer is
+// trusted proxy, client is the first address from the right which is not trusted
+func (h *Handler) clientIP(c *gin.Context) string {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(c.Request.RemoteAddr))
+	if err != nil {
+		return ""
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !h.isTrustedProxy(ip) {
+		return host
+	}
+
+	hops := strings.Split(c.GetHeader("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		ip = hop
+		if !h.isTrustedProxy(hop) {
+			break
+		}
+	}
+	return ip.String()
+}
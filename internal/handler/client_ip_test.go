@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	_, trusted, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		trusted    []string
+		remoteAddr string
+		forwarded  string
+		exp        string
+	}{
+		{
+			name:       "Header is ignored without trusted proxies",
+			remoteAddr: "203.0.113.7:5000",
+			forwarded:  "198.51.100.1",
+			exp:        "203.0.113.7",
+		},
+		{
+			name:       "Header of untrusted peer is ignored",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "203.0.113.7:5000",
+			forwarded:  "198.51.100.1",
+			exp:        "203.0.113.7",
+		},
+		{
+			name:       "Spoofed hops before trusted proxies are skipped",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:5000",
+			forwarded:  "1.1.1.1, 198.51.100.1, 10.0.0.3",
+			exp:        "198.51.100.1",
+		},
+		{
+			name:       "Trusted peer without header",
+			trusted:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:5000",
+			exp:        "10.0.0.2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Handler{}
+			if tc.trusted != nil {
+				h.TrustedProxies = []*net.IPNet{trusted}
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Request.RemoteAddr = tc.remoteAddr
+			if tc.forwarded != "" {
+				c.Request.Header.Set("X-Forwarded-For", tc.forwarded)
+			}
+
+			require.Equal(t, tc.exp, h.clientIP(c))
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+type commentReq struct {
+	Body string `json:"body" binding:"required,gte=1"`
+}
+
+// CreateComment godoc
+// @Summary Create comment
+// @Description Mentioned list members (@email) are notified by email
+// @Tags comments
+// @Accept  json
+// @Produce  json
+// @ID create-comment
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Param input body commentReq true "comment input"
+// @Success 200 {object} CommentCreateResponse "success comment creation"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 404 {object} ErrorResponse "list not found, item not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/comments [post]
+func (h *Handler) commentCreate(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	var req commentReq
+	if !bindData(c, &req) {
+		return
+	}
+
+	userID, err := h.GetUserId(c)
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	commentID, err := h.CommentService.Create(c.Request.Context(), listID, itemID, userID, req.Body)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &CommentCreateResponse{"success", commentID})
+}
+
+// GetComments godoc
+// @Summary Get item comments
+// @Tags comments
+// @Produce  json
+// @ID get-comments
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Success 200 {object} ItemCommentsResponse "item comments"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 404 {object} ErrorResponse "list not found, item not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/comments [get]
+func (h *Handler) getComments(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	result, err := h.CommentService.GetComments(c.Request.Context(), listID, itemID)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ItemCommentsResponse{"success", result})
+}
+
+// UpdateComment godoc
+// @Summary Update comment
+// @Description Only the author can edit a comment
+// @Tags comments
+// @Accept  json
+// @Produce  json
+// @ID update-comment
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Param comment_id path int true "comment_id"
+// @Param input body commentReq true "comment input"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not the author"
+// @Failure 404 {object} ErrorResponse "list not found, item not found, comment not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/comments/{comment_id} [patch]
+func (h *Handler) updateComment(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	commentID, err := strconv.ParseInt(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	var req commentReq
+	if !bindData(c, &req) {
+		return
+	}
+
+	userID, err := h.GetUserId(c)
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	err = h.CommentService.Update(c.Request.Context(), listID, itemID, commentID, userID, req.Body)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+	})
+}
+
+// DeleteComment godoc
+// @Summary Delete comment
+// @Description The author or a list admin can delete a comment
+// @Tags comments
+// @Produce  json
+// @ID delete-comment
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Param comment_id path int true "comment_id"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not the author or admin"
+// @Failure 404 {object} ErrorResponse "list not found, item not found, comment not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/comments/{comment_id} [delete]
+func (h *Handler) deleteComment(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	commentID, err := strconv.ParseInt(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	userID, err := h.GetUserId(c)
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	err = h.CommentService.Delete(c.Request.Context(), listID, itemID, commentID, userID, c.GetBool(CtxIsAdmin))
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+	})
+}
@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testComment = &models.Comment{
+		ID:     1,
+		ItemID: testItem.ID,
+		UserID: 1,
+		Author: "author@mail.ru",
+		Body:   "comment body",
+	}
+)
+
+func TestCommentCreate(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name   string
+		itemID string
+		input  string
+		retID  int64
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Bad itemID",
+			itemID: "bad",
+			input:  `{"body": "comment"}`,
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:   "Item not found",
+			itemID: "1",
+			input:  `{"body": "comment"}`,
+			retErr: models.ErrNoItem,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoItem.Error(),
+		},
+		{
+			name:   "Create return unknown error",
+			itemID: "1",
+			input:  `{"body": "comment"}`,
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name:   "Success create",
+			itemID: "1",
+			input:  `{"body": "comment"}`,
+			retID:  777,
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			cs := new(mocks.CommentService)
+			cs.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+				tc.retID, tc.retErr,
+			)
+
+			handler := New(nil, nil, tsObj, ls, nil, cs, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPost,
+				fmt.Sprintf("/api/lists/1/items/%s/comments", tc.itemID),
+				bytes.NewBuffer([]byte(tc.input)),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				crResp := &CommentCreateResponse{}
+				err := json.Unmarshal(data, crResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.retID, crResp.CommentID)
+			}
+		})
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name   string
+		itemID string
+		retRes []*models.Comment
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Bad itemID",
+			itemID: "bad",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:   "Item not found",
+			itemID: "1",
+			retErr: models.ErrNoItem,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoItem.Error(),
+		},
+		{
+			name:   "GetComments return unknown error",
+			itemID: "1",
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name:   "Success get",
+			itemID: "1",
+			retRes: []*models.Comment{testComment},
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			cs := new(mocks.CommentService)
+			cs.On("GetComments", mock.Anything, mock.Anything, mock.Anything).Return(
+				tc.retRes, tc.retErr,
+			)
+
+			handler := New(nil, nil, tsObj, ls, nil, cs, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				fmt.Sprintf("/api/lists/1/items/%s/comments", tc.itemID),
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := &ItemCommentsResponse{}
+				err := json.Unmarshal(data, resp)
+				require.NoError(t, err)
+				require.Equal(t, tc.retRes, resp.Result)
+			}
+		})
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name      string
+		commentID string
+		input     string
+		retErr    error
+		code      int
+		errMsg    string
+	}{
+		{
+			name:      "Bad commentID",
+			commentID: "bad",
+			input:     `{"body": "comment"}`,
+			code:      http.StatusBadRequest,
+			errMsg:    models.ErrBadParam.Error(),
+		},
+		{
+			name:      "Comment not found",
+			commentID: "1",
+			input:     `{"body": "comment"}`,
+			retErr:    models.ErrNoComment,
+			code:      http.StatusNotFound,
+			errMsg:    models.ErrNoComment.Error(),
+		},
+		{
+			name:      "Not author",
+			commentID: "1",
+			input:     `{"body": "comment"}`,
+			retErr:    models.ErrNoCommentAccess,
+			code:      http.StatusForbidden,
+			errMsg:    models.ErrNoCommentAccess.Error(),
+		},
+		{
+			name:      "Update return unknown error",
+			commentID: "1",
+			input:     `{"body": "comment"}`,
+			retErr:    ErrUnknown,
+			code:      http.StatusInternalServerError,
+			errMsg:    "Internal server error",
+		},
+		{
+			name:      "Success update",
+			commentID: "1",
+			input:     `{"body": "comment"}`,
+			code:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			cs := new(mocks.CommentService)
+			cs.On(
+				"Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			).Return(tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, cs, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPatch,
+				fmt.Sprintf("/api/lists/1/items/1/comments/%s", tc.commentID),
+				bytes.NewBuffer([]byte(tc.input)),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			}
+		})
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name       string
+		commentID  string
+		isAdminErr error
+		expIsAdmin bool
+		retErr     error
+		code       int
+		errMsg     string
+	}{
+		{
+			name:      "Bad commentID",
+			commentID: "bad",
+			code:      http.StatusBadRequest,
+			errMsg:    models.ErrBadParam.Error(),
+		},
+		{
+			name:       "Comment not found",
+			commentID:  "1",
+			expIsAdmin: true,
+			retErr:     models.ErrNoComment,
+			code:       http.StatusNotFound,
+			errMsg:     models.ErrNoComment.Error(),
+		},
+		{
+			name:       "Not author and not admin",
+			commentID:  "1",
+			isAdminErr: models.ErrNoListAccess,
+			expIsAdmin: false,
+			retErr:     models.ErrNoCommentAccess,
+			code:       http.StatusForbidden,
+			errMsg:     models.ErrNoCommentAccess.Error(),
+		},
+		{
+			name:       "Delete return unknown error",
+			commentID:  "1",
+			expIsAdmin: true,
+			retErr:     ErrUnknown,
+			code:       http.StatusInternalServerError,
+			errMsg:     "Internal server error",
+		},
+		{
+			name:       "Success delete by admin",
+			commentID:  "1",
+			expIsAdmin: true,
+			code:       http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(tc.isAdminErr)
+
+			cs := new(mocks.CommentService)
+			cs.On(
+				"Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, tc.expIsAdmin,
+			).Return(tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, cs, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodDelete,
+				fmt.Sprintf("/api/lists/1/items/1/comments/%s", tc.commentID),
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			}
+		})
+	}
+}
@@ -29,8 +29,8 @@ func TestConfirmHandler(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			usObj := new(mocks.UserService)
-			usObj.On("ConfirmEmail", mock.Anything).Return(tc.mockErr)
-			handler := New(usObj, nil, nil, nil, nil, getTestLogger())
+			usObj.On("ConfirmEmail", mock.Anything, mock.Anything).Return(tc.mockErr)
+			handler := New(usObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 
 			r := handler.InitRoutes(gin.TestMode)
 
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORSOptions is cross-origin policy of browser clients
+type CORSOptions struct {
+	// AllowOrigins are allowed origins, e.g. https://app.example.com,
+	// https://*.example.com allows subdomains, * allows any origin
+	AllowOrigins []string
+	AllowMethods []string
+	AllowHeaders []string
+	// ExposeHeaders are response headers which are readable by scripts
+	ExposeHeaders    []string
+	AllowCredentials bool
+	// MaxAge is time of caching preflight response
+	MaxAge time.Duration
+}
+
+// corsMiddleware answers preflight requests and sets CORS headers of allowed origins,
+// requests from other origins are rejected with 403
+func corsMiddleware(opts CORSOptions) gin.HandlerFunc {
+	return cors.New(cors.Config{
+		AllowOrigins:     opts.AllowOrigins,
+		AllowWildcard:    true,
+		AllowMethods:     opts.AllowMethods,
+		AllowHeaders:     opts.AllowHeaders,
+		ExposeHeaders:    opts.ExposeHeaders,
+		AllowCredentials: opts.AllowCredentials,
+		MaxAge:           opts.MaxAge,
+	})
+}
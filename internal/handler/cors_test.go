@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	policy := CORSOptions{
+		AllowOrigins:     []string{"https://app.example.com", "https://*.example.org"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", "Idempotency-Key"},
+		ExposeHeaders:    []string{"ETag", "X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	}
+
+	tests := []struct {
+		name    string
+		policy  CORSOptions
+		method  string
+		origin  string
+		code    int
+		headers map[string]string
+	}{
+		{
+			name:    "Policy without origins",
+			method:  http.MethodGet,
+			origin:  "https://app.example.com",
+			code:    http.StatusOK,
+			headers: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "Allowed origin",
+			policy: policy,
+			method: http.MethodGet,
+			origin: "https://app.example.com",
+			code:   http.StatusOK,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "Etag,X-Request-Id",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			name:    "Allowed subdomain",
+			policy:  policy,
+			method:  http.MethodGet,
+			origin:  "https://team.example.org",
+			code:    http.StatusOK,
+			headers: map[string]string{"Access-Control-Allow-Origin": "https://team.example.org"},
+		},
+		{
+			name:    "Unknown origin",
+			policy:  policy,
+			method:  http.MethodGet,
+			origin:  "https://evil.com",
+			code:    http.StatusForbidden,
+			headers: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "Preflight",
+			policy: policy,
+			method: http.MethodOptions,
+			origin: "https://app.example.com",
+			code:   http.StatusNoContent,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":  "https://app.example.com",
+				"Access-Control-Allow-Methods": "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+				"Access-Control-Allow-Headers": "Authorization,Content-Type,Idempotency-Key",
+				"Access-Control-Max-Age":       "3600",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			handler.CORS = tc.policy
+			r := handler.InitRoutes(gin.TestMode)
+
+			req := httptest.NewRequest(tc.method, "/healthz", nil)
+			req.Header.Set("Origin", tc.origin)
+			if tc.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.code, w.Code)
+			for k, v := range tc.headers {
+				require.Equal(t, v, w.Header().Get(k), k)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// problemTypeBase is prefix of problem type uri, the rest is error code
+const problemTypeBase = "urn:todo-app:problem:"
+
+// codes of errors which have no sentinel error in models
+const (
+	codeInternal       = "internal_error"
+	codeNotFound       = "not_found"
+	codeBadContentType = "unsupported_content_type"
+	codeBadJSON        = "malformed_json"
+	codeValidation     = "validation_failed"
+)
+
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorMappings maps sentinel errors of models to http status and error code.
+// Codes are part of API, clients rely on them, so they must never change
+var errorMappings = []errorMapping{
+	{models.ErrUserAlreadyExists, http.StatusConflict, "user_already_exists"},
+	{models.ErrConfirmLinkNotExists, http.StatusNotFound, "confirm_link_not_found"},
+	{models.ErrBadUser, http.StatusNotFound, "bad_credentials"},
+	{models.ErrUserNotActivated, http.StatusUnauthorized, "user_not_activated"},
+	{models.ErrMaxLoggedIn, http.StatusUnprocessableEntity, "max_logged_in"},
+	{models.ErrBadToken, http.StatusBadRequest, "bad_token"},
+	{models.ErrUserUnauthorized, http.StatusUnauthorized, "user_unauthorized"},
+	{models.ErrTokenExpired, http.StatusUnauthorized, "token_expired"},
+	{models.ErrNoAuthHeader, http.StatusBadRequest, "no_auth_header"},
+	{models.ErrInvalidAuthHeader, http.StatusBadRequest, "bad_auth_header"},
+	{models.ErrNoList, http.StatusNotFound, "list_not_found"},
+	{models.ErrNoItem, http.StatusNotFound, "item_not_found"},
+	{models.ErrBadParam, http.StatusBadRequest, "bad_param"},
+	{models.ErrNoListAccess, http.StatusForbidden, "no_list_access"},
+	{models.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{models.ErrUpdateEmptyArgs, http.StatusBadRequest, "empty_update"},
+	{models.ErrTitleTooShort, http.StatusBadRequest, "title_too_short"},
+	{models.ErrNoComment, http.StatusNotFound, "comment_not_found"},
+	{models.ErrNoCommentAccess, http.StatusForbidden, "no_comment_access"},
+	{models.ErrNoAttachment, http.StatusNotFound, "attachment_not_found"},
+	{models.ErrNoAttachmentAccess, http.StatusForbidden, "no_attachment_access"},
+	{models.ErrFileTooLarge, http.StatusRequestEntityTooLarge, "file_too_large"},
+	{models.ErrFileTypeNotAllowed, http.StatusUnsupportedMediaType, "file_type_not_allowed"},
+	{models.ErrNoBlob, http.StatusNotFound, "blob_not_found"},
+	{models.ErrNoFile, http.StatusBadRequest, "no_file"},
+	{models.ErrNotListMember, http.StatusBadRequest, "not_list_member"},
+	{models.ErrEmptySearchQuery, http.StatusBadRequest, "empty_search_query"},
+	{models.ErrBadSearchLanguage, http.StatusBadRequest, "bad_search_language"},
+	{models.ErrBadCursor, http.StatusBadRequest, "bad_cursor"},
+	{models.ErrBadSort, http.StatusBadRequest, "bad_sort"},
+	{models.ErrBadPageLimit, http.StatusBadRequest, "bad_page_limit"},
+	{models.ErrBadPriority, http.StatusBadRequest, "bad_priority"},
+	{models.ErrVersionMismatch, http.StatusPreconditionFailed, "version_mismatch"},
+	{models.ErrListArchived, http.StatusConflict, "list_archived"},
+	{models.ErrNoWebhook, http.StatusNotFound, "webhook_not_found"},
+	{models.ErrBadWebhookURL, http.StatusBadRequest, "bad_webhook_url"},
+	{models.ErrBadWebhookEvent, http.StatusBadRequest, "bad_webhook_event"},
+	{models.ErrBadSyncToken, http.StatusBadRequest, "bad_sync_token"},
+	{models.ErrBadSyncOp, http.StatusBadRequest, "bad_sync_op"},
+	{models.ErrNoSyncTitle, http.StatusBadRequest, "no_sync_title"},
+	{models.ErrNoSyncMutation, http.StatusNotFound, "sync_mutation_not_found"},
+	{models.ErrSyncMutationApplied, http.StatusConflict, "sync_mutation_applied"},
+	{models.ErrBadIdempotencyKey, http.StatusBadRequest, "bad_idempotency_key"},
+	{models.ErrIdempotencyKeyReused, http.StatusConflict, "idempotency_key_reused"},
+	{models.ErrIdempotencyInFlight, http.StatusConflict, "idempotency_in_flight"},
+	{models.ErrRateLimited, http.StatusTooManyRequests, "rate_limited"},
+	{models.ErrRequestTimeout, http.StatusServiceUnavailable, "request_timeout"},
+}
+
+// lookupError returns mapping of err, wrapped errors are matched with errors.Is.
+// Expired deadline of request context is reported as request timeout
+func lookupError(err error) (errorMapping, bool) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = models.ErrRequestTimeout
+	}
+
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			return m, true
+		}
+	}
+	return errorMapping{}, false
+}
+
+// problem writes RFC 7807 problem details, detail is english message localized by code
+func problem(c *gin.Context, status int, code, detail string, invalidParams []invalidArgument) {
+	lang := language(c)
+
+	c.Header("Content-Type", "application/problem+json")
+	c.Header("Content-Language", lang)
+	c.JSON(status, &ErrorResponse{
+		Type:          problemTypeBase + code,
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        localize(lang, code, detail),
+		Instance:      c.Request.URL.Path,
+		Code:          code,
+		InvalidParams: invalidParams,
+		RequestID:     logging.RequestID(c.Request.Context()),
+	})
+}
+
+// errorResponse writes problem of err, errors without mapping are internal errors
+func errorResponse(c *gin.Context, err error) {
+	m, ok := lookupError(err)
+	if !ok {
+		problem(c, http.StatusInternalServerError, codeInternal, "Internal server error", nil)
+		return
+	}
+
+	// message of sentinel error, wrapping context is not shown to clients
+	problem(c, m.status, m.code, m.err.Error(), nil)
+}
+
+// Error writes problem of err, errors without mapping are logged
+func (h *Handler) Error(c *gin.Context, err error) {
+	if _, ok := lookupError(err); !ok {
+		h.log(c).Error(err)
+		tracing.RecordError(trace.SpanFromContext(c.Request.Context()), err)
+	}
+	errorResponse(c, err)
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorMappingsCoverSentinels(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../models/errors.go", nil, 0)
+	require.NoError(t, err)
+
+	sentinels := 0
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.ValueSpec); ok {
+			sentinels += len(spec.Names)
+		}
+		return true
+	})
+	require.Equal(t, sentinels, len(errorMappings))
+
+	errs, codes := map[error]bool{}, map[string]bool{}
+	for _, m := range errorMappings {
+		require.False(t, errs[m.err], m.err)
+		require.False(t, codes[m.code], m.code)
+		errs[m.err], codes[m.code] = true, true
+
+		for lang, messages := range translations {
+			require.NotEmpty(t, messages[m.code], "%s has no %s translation", m.code, lang)
+		}
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		lang     string
+		code     int
+		errCode  string
+		detail   string
+		language string
+	}{
+		{
+			name:     "Sentinel error",
+			err:      models.ErrNoList,
+			code:     http.StatusNotFound,
+			errCode:  "list_not_found",
+			detail:   models.ErrNoList.Error(),
+			language: "en",
+		},
+		{
+			name:     "Wrapped sentinel error",
+			err:      fmt.Errorf("get list 5: %w", models.ErrNoListAccess),
+			code:     http.StatusForbidden,
+			errCode:  "no_list_access",
+			detail:   models.ErrNoListAccess.Error(),
+			language: "en",
+		},
+		{
+			name:     "Unknown error",
+			err:      ErrUnknown,
+			code:     http.StatusInternalServerError,
+			errCode:  codeInternal,
+			detail:   "Internal server error",
+			language: "en",
+		},
+		{
+			name:     "Localized message",
+			err:      models.ErrNoList,
+			lang:     "ru-RU,ru;q=0.9,en;q=0.8",
+			code:     http.StatusNotFound,
+			errCode:  "list_not_found",
+			detail:   "список не найден",
+			language: "ru",
+		},
+		{
+			name:     "Preferred language by quality",
+			err:      models.ErrNoList,
+			lang:     "ru;q=0.5, en",
+			code:     http.StatusNotFound,
+			errCode:  "list_not_found",
+			detail:   models.ErrNoList.Error(),
+			language: "en",
+		},
+		{
+			name:     "Unsupported language",
+			err:      models.ErrNoList,
+			lang:     "de-DE, fr;q=0.8",
+			code:     http.StatusNotFound,
+			errCode:  "list_not_found",
+			detail:   models.ErrNoList.Error(),
+			language: "en",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/api/lists/5", func(c *gin.Context) {
+				handler.Error(c, tc.err)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/lists/5", nil)
+			req.Header.Set("Accept-Language", tc.lang)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.code, w.Code)
+			require.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+			require.Equal(t, tc.language, w.Header().Get("Content-Language"))
+
+			resp := &ErrorResponse{}
+			err := json.Unmarshal(w.Body.Bytes(), resp)
+			require.NoError(t, err)
+			require.Equal(t, &ErrorResponse{
+				Type:     problemTypeBase + tc.errCode,
+				Title:    http.StatusText(tc.code),
+				Status:   tc.code,
+				Detail:   tc.detail,
+				Instance: "/api/lists/5",
+				Code:     tc.errCode,
+			}, resp)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// etag returns strong entity tag of resource version. Derived state which doesn't
+// change version, e.g. count of comments, is added after version
+func etag(version int64, derived ...int64) string {
+	parts := []string{strconv.FormatInt(version, 10)}
+	for _, d := range derived {
+		parts = append(parts, strconv.FormatInt(d, 10))
+	}
+	return `"` + strings.Join(parts, "-") + `"`
+}
+
+// tagVersion returns version of strong tag made by etag
+func tagVersion(tag string) (int64, bool) {
+	if len(tag) < 3 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	value := tag[1 : len(tag)-1]
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		value = value[:i]
+	}
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	return version, err == nil
+}
+
+// notModified sets ETag header and responds with 304 if If-None-Match header matches it
+func notModified(c *gin.Context, tag string) bool {
+	c.Header("ETag", tag)
+
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	// If-None-Match uses weak comparison
+	for _, value := range strings.Split(header, ",") {
+		value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+		if value == tag || value == "*" {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion returns version from comma-separated tags of If-Match header, nil if
+// header is absent or has "*". If tags have several versions, current version is used
+// when it is listed. Header that can't match current version is answered with 412,
+// then ok is false
+func (h *Handler) ifMatchVersion(c *gin.Context, current func(ctx context.Context) (int64, error)) (version *int64, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return nil, true
+	}
+
+	// If-Match uses strong comparison, weak tags never match
+	versions := map[int64]struct{}{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if v, ok := tagVersion(tag); ok {
+			versions[v] = struct{}{}
+		}
+	}
+
+	switch len(versions) {
+	case 0:
+		errorResponse(c, models.ErrVersionMismatch)
+		return nil, false
+	case 1:
+		for v := range versions {
+			return &v, true
+		}
+	}
+
+	// listed version is checked again by update, so concurrent change is not lost
+	v, err := current(c.Request.Context())
+	if err != nil {
+		h.Error(c, err)
+		return nil, false
+	}
+	if _, ok := versions[v]; !ok {
+		errorResponse(c, models.ErrVersionMismatch)
+		return nil, false
+	}
+	return &v, true
+}
+
+// listVersion returns func which reads current version of list
+func (h *Handler) listVersion(listID, userID int64) func(ctx context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		list, err := h.ListService.GetListByID(ctx, listID, userID)
+		if err != nil {
+			return 0, err
+		}
+		return list.Version, nil
+	}
+}
+
+// itemVersion returns func which reads current version of item
+func (h *Handler) itemVersion(listID, itemID int64) func(ctx context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		item, err := h.ItemService.GetItemByID(ctx, listID, itemID)
+		if err != nil {
+			return 0, err
+		}
+		return item.Version, nil
+	}
+}
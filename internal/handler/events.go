@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// eventsHeartbeat is an interval of comments which keep idle stream open through proxies
+const eventsHeartbeat = 30 * time.Second
+
+// IsEventStream reports whether r is request of event stream, it stays open and
+// is served without write timeout of server
+func IsEventStream(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.HasPrefix(r.URL.Path, "/api/lists/") &&
+		strings.HasSuffix(r.URL.Path, "/events")
+}
+
+// ListEvents godoc
+// @Summary Stream list events
+// @Description Server-Sent Events with list activity, event name is activity action and id is activity id.
+// @Description EventSource can't set headers, so access token may be passed in access_token query parameter.
+// @Description Such stream is closed when the token expires, client reconnects with new token
+// @Tags lists
+// @Produce  text/event-stream
+// @ID list-events
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param access_token query string false "access token if Authorization header can't be set"
+// @Success 200 {object} models.Activity "stream of events"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/events [get]
+func (h *Handler) listEvents(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+
+	events, err := h.EventService.Subscribe(c.Request.Context(), listID)
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	// nil channel never fires for token from header
+	var expired <-chan time.Time
+	if exp, ok := c.Get(expCtx); ok {
+		timer := time.NewTimer(time.Until(exp.(time.Time)))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// channel is closed when client goes away
+	for {
+		select {
+		case activity, ok := <-events:
+			if !ok {
+				return
+			}
+
+			err = sse.Encode(c.Writer, sse.Event{
+				Id:    strconv.FormatInt(activity.ID, 10),
+				Event: activity.Action,
+				Data:  activity,
+			})
+		case <-heartbeat.C:
+			_, err = io.WriteString(c.Writer, ": heartbeat\n\n")
+		case <-expired:
+			return
+		case <-h.done:
+			// server is shutting down, EventSource clients reconnect automatically
+			return
+		}
+
+		if err != nil {
+			return
+		}
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEvents(t *testing.T) {
+	itemID := int64(3)
+	activity := &models.Activity{ID: 5, ListID: 1, ItemID: &itemID, Action: models.ActionItemCreate}
+
+	tests := []struct {
+		name      string
+		query     string
+		headers   map[string]string
+		subErr    error
+		code      int
+		errMsg    string
+		expStream string
+	}{
+		{
+			name:    "No token",
+			headers: map[string]string{},
+			code:    http.StatusBadRequest,
+			errMsg:  models.ErrNoAuthHeader.Error(),
+		},
+		{
+			name:    "Subscribe return unknown error",
+			headers: map[string]string{"Authorization": "Bearer token"},
+			subErr:  ErrUnknown,
+			code:    http.StatusInternalServerError,
+			errMsg:  "Internal server error",
+		},
+		{
+			name:    "Expired query token",
+			query:   "?access_token=expired",
+			headers: map[string]string{},
+			code:    http.StatusUnauthorized,
+			errMsg:  models.ErrTokenExpired.Error(),
+		},
+		{
+			name:    "Success stream with header token",
+			headers: map[string]string{"Authorization": "Bearer token"},
+			code:    http.StatusOK,
+		},
+		{
+			name:    "Success stream with query token",
+			query:   "?access_token=token",
+			headers: map[string]string{},
+			code:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, "token").Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+			tsObj.On("ExpiresAt", "token").Return(time.Now().Add(time.Hour), nil)
+			tsObj.On("ExpiresAt", "expired").Return(time.Time{}, models.ErrTokenExpired)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(models.ErrNoListAccess)
+
+			// stream ends when subscription is closed
+			events := make(chan *models.Activity, 1)
+			events <- activity
+			close(events)
+
+			es := new(mocks.EventService)
+			es.On("Subscribe", mock.Anything, int64(1)).Return(
+				(<-chan *models.Activity)(events), tc.subErr,
+			)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, es, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/lists/1/events"+tc.query,
+				bytes.NewBuffer([]byte{}),
+				tc.headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				payload, err := json.Marshal(activity)
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					"id:5\nevent:item.create\ndata:"+string(payload)+"\n\n",
+					string(data),
+				)
+			}
+		})
+	}
+}
+
+func TestListEventsEndOnClose(t *testing.T) {
+	tsObj := new(mocks.TokenService)
+	tsObj.On("Verify", mock.Anything, "token").Return(
+		int64(1), "aaa-aaa-aaa-aaa", nil,
+	)
+
+	ls := new(mocks.ListService)
+	ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// subscription is never closed, stream ends only on handler close
+	events := make(chan *models.Activity)
+	es := new(mocks.EventService)
+	es.On("Subscribe", mock.Anything, int64(1)).Return((<-chan *models.Activity)(events), nil)
+
+	handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, es, nil, nil, nil, nil, nil, getTestLogger())
+	handler.Close()
+	handler.Close()
+
+	r := handler.InitRoutes(gin.TestMode)
+	code, data := helpers.MakeRequest(
+		r,
+		t,
+		http.MethodGet,
+		"/api/lists/1/events",
+		bytes.NewBuffer([]byte{}),
+		map[string]string{"Authorization": "Bearer token"},
+	)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, data)
+}
+
+func TestIsEventStream(t *testing.T) {
+	tests := []struct {
+		method string
+		target string
+		exp    bool
+	}{
+		{http.MethodGet, "/api/lists/1/events", true},
+		{http.MethodGet, "/api/lists/1/events?access_token=token", true},
+		{http.MethodPost, "/api/lists/1/events", false},
+		{http.MethodGet, "/api/lists/1/activity", false},
+		{http.MethodGet, "/api/sync", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.method+" "+tc.target, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+			require.Equal(t, tc.exp, IsEventStream(req))
+		})
+	}
+}
+
+func TestListEventsEndOnTokenExpiry(t *testing.T) {
+	tsObj := new(mocks.TokenService)
+	tsObj.On("Verify", mock.Anything, "token").Return(
+		int64(1), "aaa-aaa-aaa-aaa", nil,
+	)
+	tsObj.On("ExpiresAt", "token").Return(time.Now().Add(50*time.Millisecond), nil)
+
+	ls := new(mocks.ListService)
+	ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// subscription is never closed, stream ends only on token expiry
+	events := make(chan *models.Activity)
+	es := new(mocks.EventService)
+	es.On("Subscribe", mock.Anything, int64(1)).Return((<-chan *models.Activity)(events), nil)
+
+	handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, es, nil, nil, nil, nil, nil, getTestLogger())
+	r := handler.InitRoutes(gin.TestMode)
+	code, data := helpers.MakeRequest(
+		r,
+		t,
+		http.MethodGet,
+		"/api/lists/1/events?access_token=token",
+		bytes.NewBuffer([]byte{}),
+		map[string]string{},
+	)
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, data)
+}
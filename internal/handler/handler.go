@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"net"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/VladimirStepanov/todo-app/internal/models"
 	"github.com/gin-gonic/gin"
@@ -11,48 +14,95 @@ import (
 )
 
 type Handler struct {
-	UserService  models.UserService
-	MailService  models.MailService
-	TokenService models.TokenService
-	ListService  models.ListService
-	ItemService  models.ItemService
-	logger       *logrus.Logger
+	UserService        models.UserService
+	MailService        models.MailService
+	TokenService       models.TokenService
+	ListService        models.ListService
+	ItemService        models.ItemService
+	CommentService     models.CommentService
+	AttachmentService  models.AttachmentService
+	SearchService      models.SearchService
+	TrashService       models.TrashService
+	EventService       models.EventService
+	WebhookService     models.WebhookService
+	SyncService        models.SyncService
+	IdempotencyService models.IdempotencyService
+	RateLimitService   models.RateLimitService
+	HealthService      models.HealthService
+	// RequestTimeout is deadline of /auth and /api requests, event streams aren't limited
+	RequestTimeout time.Duration
+	// MetricsToken is bearer token of /metrics, endpoint is disabled if it is empty
+	MetricsToken string
+	// HSTSMaxAge is max-age of Strict-Transport-Security header of TLS responses, zero disables it
+	HSTSMaxAge time.Duration
+	// AttachmentMaxSize is max file size of upload, body is read up to it plus multipart
+	// overhead, zero disables the limit
+	AttachmentMaxSize int64
+	// TrustedProxies are proxies which X-Forwarded-For is used from, header of
+	// other peers is ignored
+	TrustedProxies []*net.IPNet
+	// CORS is cross-origin policy, CORS headers aren't sent if no origin is allowed
+	CORS   CORSOptions
+	logger *logrus.Logger
+	// done is closed on shutdown to end long-lived event streams
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-func (h *Handler) AccessLogger(c *gin.Context) {
-	c.Next()
-
-	h.logger.WithFields(logrus.Fields{
-		"path":   c.Request.URL.Path,
-		"method": c.Request.Method,
-		"code":   c.Writer.Status(),
-	}).Info("access")
+// Close ends event streams, server shutdown waits for them otherwise
+func (h *Handler) Close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
 }
 
-func CORSMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PATCH, DELETE")
+func (h *Handler) AccessLogger(c *gin.Context) {
+	start := time.Now()
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
+	c.Next()
+
+	// probes and scrapes are too frequent to log
+	switch c.Request.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return
+	}
 
-		c.Next()
+	size := c.Writer.Size()
+	if size < 0 {
+		size = 0
 	}
+
+	h.log(c).WithFields(logrus.Fields{
+		"path":       c.Request.URL.Path,
+		"method":     c.Request.Method,
+		"code":       c.Writer.Status(),
+		"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+		"client_ip":  h.clientIP(c),
+		"size":       size,
+		"user_agent": c.Request.UserAgent(),
+	}).Info("access")
 }
 
 func (h *Handler) InitRoutes(mode string) http.Handler {
 	gin.SetMode(mode)
 	r := gin.New()
 
-	r.Use(CORSMiddleware())
+	r.Use(h.securityHeadersMiddleware)
+	r.Use(h.requestIDMiddleware)
+	r.Use(tracingMiddleware)
+	r.Use(metricsMiddleware)
+	if len(h.CORS.AllowOrigins) != 0 {
+		r.Use(corsMiddleware(h.CORS))
+	}
 	r.Use(h.AccessLogger)
 
-	auth := r.Group("/auth")
+	r.GET("/healthz", h.healthz)
+	r.GET("/readyz", h.readyz)
+	if h.MetricsToken != "" {
+		r.GET("/metrics", h.metricsAuthMiddleware, metricsHandler)
+	}
+
+	auth := r.Group("/auth", h.timeoutMiddleware, h.rateLimitMiddleware(models.RateLimitAuth))
 	{
 		auth.POST("/sign-in", h.signIn)
 		auth.GET("/confirm/:link", h.confirm)
@@ -61,16 +111,40 @@ func (h *Handler) InitRoutes(mode string) http.Handler {
 		auth.GET("/logout", h.authMiddleware, h.logout)
 	}
 
-	api := r.Group("/api", h.authMiddleware)
+	r.GET(
+		"/api/lists/:list_id/events",
+		h.streamAuthMiddleware, h.rateLimitMiddleware(models.RateLimitAPI),
+		h.checkAccessToListMiddleware, h.listEvents,
+	)
+
+	api := r.Group(
+		"/api",
+		h.timeoutMiddleware, h.authMiddleware,
+		h.rateLimitMiddleware(models.RateLimitAPI), h.idempotencyMiddleware,
+	)
 	{
 		lists := api.Group("/lists")
 		{
 			lists.POST("", h.listCreate)
 			lists.GET("", h.getUserLists)
 			lists.GET("/:list_id", h.getListByID)
-			lists.PATCH("/:list_id", h.onlyAdminAccessMiddleware, h.updateList)
-			lists.PATCH("/:list_id/edit-role", h.onlyAdminAccessMiddleware, h.editRole)
-			lists.DELETE("/:list_id", h.onlyAdminAccessMiddleware, h.deleteList)
+			lists.PATCH("/:list_id", h.onlyAdminAccessMiddleware, h.notArchivedMiddleware, h.updateList)
+			lists.PATCH("/:list_id/edit-role", h.onlyAdminAccessMiddleware, h.notArchivedMiddleware, h.editRole)
+			lists.DELETE("/:list_id", h.onlyAdminAccessMiddleware, h.notArchivedMiddleware, h.deleteList)
+			lists.POST("/:list_id/restore", h.onlyTrashAdminMiddleware, h.restoreList)
+			lists.POST("/:list_id/archive", h.onlyAdminAccessMiddleware, h.archiveList)
+			lists.POST("/:list_id/unarchive", h.onlyAdminAccessMiddleware, h.unarchiveList)
+			lists.DELETE("/:list_id/members/:user_id", h.onlyAdminAccessMiddleware, h.notArchivedMiddleware, h.removeMember)
+			lists.GET("/:list_id/activity", h.onlyAdminAccessMiddleware, h.getListActivity)
+
+			webhooks := lists.Group("/:list_id/webhooks", h.onlyAdminAccessMiddleware, h.notArchivedMiddleware)
+			{
+				webhooks.POST("", h.webhookCreate)
+				webhooks.GET("", h.getWebhooks)
+				webhooks.PATCH("/:webhook_id", h.updateWebhook)
+				webhooks.DELETE("/:webhook_id", h.deleteWebhook)
+				webhooks.GET("/:webhook_id/deliveries", h.getWebhookDeliveries)
+			}
 
 			items := lists.Group("/:list_id/items", h.checkAccessToListMiddleware)
 			{
@@ -80,10 +154,31 @@ func (h *Handler) InitRoutes(mode string) http.Handler {
 				items.PATCH("/:item_id", h.updateItem)
 				items.PATCH("/:item_id/done", h.doneItem)
 				items.DELETE("/:item_id", h.deleteItem)
+				items.POST("/:item_id/restore", h.restoreItem)
+				items.GET("/:item_id/comments", h.getComments)
+				items.POST("/:item_id/comments", h.commentCreate)
+				items.PATCH("/:item_id/comments/:comment_id", h.updateComment)
+				items.DELETE("/:item_id/comments/:comment_id", h.deleteComment)
+				items.GET("/:item_id/attachments", h.getAttachments)
+				items.POST("/:item_id/attachments", h.attachmentCreate)
+				items.GET("/:item_id/attachments/:attachment_id", h.downloadAttachment)
+				items.DELETE("/:item_id/attachments/:attachment_id", h.deleteAttachment)
 			}
 		}
+
+		me := api.Group("/me")
+		{
+			me.GET("/items", h.getMyItems)
+			me.GET("/trash", h.getTrash)
+		}
+
+		api.GET("/search", h.search)
+
+		api.GET("/sync", h.getSyncChanges)
+		api.POST("/sync", h.applySyncMutations)
 	}
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	r.NoRoute(h.PageNotFound)
 	return r
 }
 
@@ -93,13 +188,35 @@ func New(
 	TokenService models.TokenService,
 	ListService models.ListService,
 	ItemService models.ItemService,
+	CommentService models.CommentService,
+	AttachmentService models.AttachmentService,
+	SearchService models.SearchService,
+	TrashService models.TrashService,
+	EventService models.EventService,
+	WebhookService models.WebhookService,
+	SyncService models.SyncService,
+	IdempotencyService models.IdempotencyService,
+	RateLimitService models.RateLimitService,
+	HealthService models.HealthService,
 	logger *logrus.Logger) *Handler {
 
 	return &Handler{
-		UserService:  UserService,
-		MailService:  MailService,
-		TokenService: TokenService,
-		ListService:  ListService,
-		ItemService:  ItemService,
-		logger:       logger}
+		UserService:        UserService,
+		MailService:        MailService,
+		TokenService:       TokenService,
+		ListService:        ListService,
+		ItemService:        ItemService,
+		CommentService:     CommentService,
+		AttachmentService:  AttachmentService,
+		SearchService:      SearchService,
+		TrashService:       TrashService,
+		EventService:       EventService,
+		WebhookService:     WebhookService,
+		SyncService:        SyncService,
+		IdempotencyService: IdempotencyService,
+		RateLimitService:   RateLimitService,
+		HealthService:      HealthService,
+		logger:             logger,
+		done:               make(chan struct{}),
+	}
 }
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Healthz godoc
+// @Summary Liveness probe
+// @Description Returns 200 while process is able to serve requests, dependencies are not checked
+// @Tags health
+// @Produce  json
+// @ID healthz
+// @Success 200 {object} models.HealthReport
+// @Router /healthz [get]
+func (h *Handler) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, &models.HealthReport{
+		Status: models.HealthUp,
+		Checks: map[string]*models.HealthCheck{},
+	})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Pings postgres and redis, returns 503 if any of them is down
+// @Tags health
+// @Produce  json
+// @ID readyz
+// @Success 200 {object} models.HealthReport
+// @Failure 503 {object} models.HealthReport "some dependency is down"
+// @Router /readyz [get]
+func (h *Handler) readyz(c *gin.Context) {
+	report := h.HealthService.Check(c.Request.Context())
+
+	code := http.StatusOK
+	if report.Status != models.HealthUp {
+		code = http.StatusServiceUnavailable
+		for name, check := range report.Checks {
+			if check.Err != nil {
+				h.log(c).WithField("dependency", name).Error(check.Err)
+			}
+		}
+	}
+
+	c.JSON(code, report)
+}
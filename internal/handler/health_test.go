@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthz(t *testing.T) {
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+	r := handler.InitRoutes(gin.TestMode)
+	code, data := helpers.MakeRequest(
+		r,
+		t,
+		http.MethodGet,
+		"/healthz",
+		bytes.NewBuffer([]byte{}),
+		nil,
+	)
+	require.Equal(t, http.StatusOK, code)
+
+	report := &models.HealthReport{}
+	require.NoError(t, json.Unmarshal(data, report))
+	require.Equal(t, models.HealthUp, report.Status)
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *models.HealthReport
+		code   int
+	}{
+		{
+			name: "Dependency down",
+			report: &models.HealthReport{
+				Status: models.HealthDown,
+				Checks: map[string]*models.HealthCheck{
+					"postgres": {Status: models.HealthUp},
+					"redis":    {Status: models.HealthDown, Err: ErrUnknown},
+				},
+			},
+			code: http.StatusServiceUnavailable,
+		},
+		{
+			name: "All up",
+			report: &models.HealthReport{
+				Status: models.HealthUp,
+				Checks: map[string]*models.HealthCheck{
+					"postgres": {Status: models.HealthUp},
+					"redis":    {Status: models.HealthUp},
+				},
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hs := new(mocks.HealthService)
+			hs.On("Check", mock.Anything).Return(tc.report)
+
+			handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, hs, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/readyz",
+				bytes.NewBuffer([]byte{}),
+				nil,
+			)
+			require.Equal(t, tc.code, code)
+
+			report := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(data, &report))
+			require.Equal(t, tc.report.Status, report["status"])
+
+			checks := report["checks"].(map[string]interface{})
+			redis := checks["redis"].(map[string]interface{})
+			require.Equal(t, tc.report.Checks["redis"].Status, redis["status"])
+			require.NotContains(t, redis, "error")
+		})
+	}
+}
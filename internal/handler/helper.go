@@ -5,17 +5,30 @@ import (
 	"net/http"
 
 	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type UserListsResponse struct {
 	Status string         `json:"status"`
 	Result []*models.List `json:"result"`
+	// empty if there are no more lists
+	NextCursor string `json:"next_cursor"`
 }
 
 type UserItemsResponse struct {
 	Status string         `json:"status"`
 	Result []*models.Item `json:"result"`
+	// empty if there are no more items
+	NextCursor string `json:"next_cursor"`
+}
+
+type ListActivityResponse struct {
+	Status string             `json:"status"`
+	Result []*models.Activity `json:"result"`
+	// empty if there is no more activity
+	NextCursor string `json:"next_cursor"`
 }
 
 type ItemCreateResponse struct {
@@ -23,6 +36,63 @@ type ItemCreateResponse struct {
 	ItemID int64  `json:"item_id"`
 }
 
+type CommentCreateResponse struct {
+	Status    string `json:"status"`
+	CommentID int64  `json:"comment_id"`
+}
+
+type ItemCommentsResponse struct {
+	Status string            `json:"status"`
+	Result []*models.Comment `json:"result"`
+}
+
+type AttachmentCreateResponse struct {
+	Status       string `json:"status"`
+	AttachmentID int64  `json:"attachment_id"`
+}
+
+type ItemAttachmentsResponse struct {
+	Status string               `json:"status"`
+	Result []*models.Attachment `json:"result"`
+}
+
+type SearchResponse struct {
+	Status string                 `json:"status"`
+	Result []*models.SearchResult `json:"result"`
+}
+
+type TrashResponse struct {
+	Status string        `json:"status"`
+	Result *models.Trash `json:"result"`
+}
+
+type WebhookCreateResponse struct {
+	Status    string `json:"status"`
+	WebhookID int64  `json:"webhook_id"`
+}
+
+type ListWebhooksResponse struct {
+	Status string            `json:"status"`
+	Result []*models.Webhook `json:"result"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Status string                    `json:"status"`
+	Result []*models.WebhookDelivery `json:"result"`
+	// empty if there are no more deliveries
+	NextCursor string `json:"next_cursor"`
+}
+
+type SyncResponse struct {
+	Status string              `json:"status"`
+	Result *models.SyncChanges `json:"result"`
+}
+
+type SyncApplyResponse struct {
+	Status string               `json:"status"`
+	Result []*models.SyncResult `json:"result"`
+}
+
 type ListCreateResponse struct {
 	Status string `json:"status"`
 	ListID int64  `json:"list_id"`
@@ -33,29 +103,31 @@ type TokensResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// ErrorResponse is RFC 7807 problem details
 type ErrorResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-}
-
-type BindDataError struct {
-	Err         string            `json:"error"`
-	InvalidArgs []invalidArgument `json:"invalidArgs"`
+	// uri of problem type, ends with code
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// message in language from Accept-Language header
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+	// stable error code
+	Code string `json:"code"`
+	// fields which failed validation
+	InvalidParams []invalidArgument `json:"invalid_params,omitempty"`
+	// X-Request-ID of request, it is logged with request errors
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func (h *Handler) PageNotFound(c *gin.Context) {
-	c.JSON(http.StatusNotFound, gin.H{
-		"status":  "error",
-		"message": "Page not found",
-	})
+	problem(c, http.StatusNotFound, codeNotFound, "Page not found", nil)
 }
 
 func (h *Handler) InternalError(c *gin.Context, err error) {
-	h.logger.Error(err)
-	c.JSON(http.StatusInternalServerError, gin.H{
-		"status":  "error",
-		"message": "Internal server error",
-	})
+	h.log(c).Error(err)
+	tracing.RecordError(trace.SpanFromContext(c.Request.Context()), err)
+	problem(c, http.StatusInternalServerError, codeInternal, "Internal server error", nil)
 }
 
 func (h *Handler) GetUserId(c *gin.Context) (int64, error) {
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const idempotencyHeader = "Idempotency-Key"
+
+// fingerprintLimit is max size of body prefix in request fingerprint. JSON bodies
+// are smaller, uploads are identified by prefix and content length
+const fingerprintLimit = 64 << 10
+
+// idempotencyStoreTimeout limits saving of response, it is done with detached
+// context because request context may be already cancelled
+const idempotencyStoreTimeout = 5 * time.Second
+
+// storeContext returns context for saving of response which is not cancelled
+// with request context, request id, logger and span of request are kept
+func storeContext(reqCtx context.Context) (context.Context, context.CancelFunc) {
+	ctx := trace.ContextWithSpan(logging.Detach(reqCtx), trace.SpanFromContext(reqCtx))
+	return context.WithTimeout(ctx, idempotencyStoreTimeout)
+}
+
+// replayedHeaders are response headers stored with idempotent response
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// bodyRecorder keeps copy of response body
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// readCloser reads buffered prefix and rest of request body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// requestFingerprint hashes method, uri, length and bounded prefix of body,
+// read prefix is put back to body
+func requestFingerprint(r *http.Request) (string, error) {
+	prefix, err := ioutil.ReadAll(io.LimitReader(r.Body, fingerprintLimit))
+	if err != nil {
+		return "", err
+	}
+	r.Body = readCloser{io.MultiReader(bytes.NewReader(prefix), r.Body), r.Body}
+
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write([]byte(strconv.FormatInt(r.ContentLength, 10) + "\n"))
+	hash.Write(prefix)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// idempotencyMiddleware replays stored response of POST and PATCH requests
+// with the same Idempotency-Key header. Server errors are not stored
+func (h *Handler) idempotencyMiddleware(c *gin.Context) {
+	if h.IdempotencyService == nil {
+		c.Next()
+		return
+	}
+
+	key := c.GetHeader(idempotencyHeader)
+	method := c.Request.Method
+	if key == "" || (method != http.MethodPost && method != http.MethodPatch) {
+		c.Next()
+		return
+	}
+
+	fingerprint, err := requestFingerprint(c.Request)
+	if err != nil {
+		h.InternalError(c, err)
+		c.Abort()
+		return
+	}
+
+	userID := c.GetInt64(idCtx)
+
+	stored, err := h.IdempotencyService.Begin(c.Request.Context(), userID, key, fingerprint)
+	if err != nil {
+		if errors.Is(err, models.ErrIdempotencyInFlight) {
+			c.Header("Retry-After", "1")
+		}
+		h.Error(c, err)
+		c.Abort()
+		return
+	}
+
+	if stored != nil {
+		for name, value := range stored.Header {
+			c.Header(name, value)
+		}
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(stored.Code, stored.Header["Content-Type"], stored.Body)
+		c.Abort()
+		return
+	}
+
+	recorder := &bodyRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+
+	c.Next()
+
+	ctx, cancel := storeContext(c.Request.Context())
+	defer cancel()
+
+	if recorder.Status() >= http.StatusInternalServerError {
+		if err := h.IdempotencyService.Abort(ctx, userID, key); err != nil {
+			h.log(c).Error(err)
+		}
+		return
+	}
+
+	resp := &models.IdempotentResponse{
+		Fingerprint: fingerprint,
+		Code:        recorder.Status(),
+		Header:      map[string]string{},
+		Body:        recorder.body.Bytes(),
+	}
+	for _, name := range replayedHeaders {
+		if value := recorder.Header().Get(name); value != "" {
+			resp.Header[name] = value
+		}
+	}
+
+	if err := h.IdempotencyService.Complete(ctx, userID, key, resp); err != nil {
+		h.log(c).Error(err)
+	}
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	body := `{"title":"title","description":"description"}`
+	stored := &models.IdempotentResponse{
+		Fingerprint: "fp",
+		Done:        true,
+		Code:        http.StatusOK,
+		Header:      map[string]string{"Content-Type": "application/json; charset=utf-8"},
+		Body:        []byte(`{"status":"success","list_id":7}`),
+	}
+
+	tests := []struct {
+		name        string
+		key         string
+		retStored   *models.IdempotentResponse
+		beginErr    error
+		createErr   error
+		code        int
+		errMsg      string
+		retryAfter  string
+		replayed    string
+		expListID   int64
+		expComplete bool
+		expAbort    bool
+	}{
+		{
+			name:     "Bad key",
+			key:      "key",
+			beginErr: models.ErrBadIdempotencyKey,
+			code:     http.StatusBadRequest,
+			errMsg:   models.ErrBadIdempotencyKey.Error(),
+		},
+		{
+			name:     "Key reused",
+			key:      "key",
+			beginErr: models.ErrIdempotencyKeyReused,
+			code:     http.StatusConflict,
+			errMsg:   models.ErrIdempotencyKeyReused.Error(),
+		},
+		{
+			name:       "Request in flight",
+			key:        "key",
+			beginErr:   models.ErrIdempotencyInFlight,
+			code:       http.StatusConflict,
+			errMsg:     models.ErrIdempotencyInFlight.Error(),
+			retryAfter: "1",
+		},
+		{
+			name:     "Begin return unknown error",
+			key:      "key",
+			beginErr: ErrUnknown,
+			code:     http.StatusInternalServerError,
+			errMsg:   "Internal server error",
+		},
+		{
+			name:      "Replay stored response",
+			key:       "key",
+			retStored: stored,
+			code:      http.StatusOK,
+			replayed:  "true",
+			expListID: 7,
+		},
+		{
+			name:        "Store first response",
+			key:         "key",
+			code:        http.StatusOK,
+			expListID:   5,
+			expComplete: true,
+		},
+		{
+			name:      "Release key on server error",
+			key:       "key",
+			createErr: ErrUnknown,
+			code:      http.StatusInternalServerError,
+			errMsg:    "Internal server error",
+			expAbort:  true,
+		},
+		{
+			name:      "Request without key",
+			code:      http.StatusOK,
+			expListID: 5,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("Create", mock.Anything, "title", "description", int64(1)).Return(int64(5), tc.createErr)
+
+			is := new(mocks.IdempotencyService)
+			is.On("Begin", mock.Anything, int64(1), tc.key, mock.Anything).Return(tc.retStored, tc.beginErr)
+			is.On("Complete", mock.Anything, int64(1), tc.key, mock.MatchedBy(func(r *models.IdempotentResponse) bool {
+				return r.Code == http.StatusOK && r.Header["Content-Type"] != "" && len(r.Body) != 0
+			})).Return(nil)
+			is.On("Abort", mock.Anything, int64(1), tc.key).Return(nil)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, is, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/lists", bytes.NewBufferString(body))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("Content-Type", "application/json")
+			if tc.key != "" {
+				req.Header.Set(idempotencyHeader, tc.key)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.code, w.Code)
+			require.Equal(t, tc.retryAfter, w.Header().Get("Retry-After"))
+			require.Equal(t, tc.replayed, w.Header().Get("Idempotent-Replayed"))
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(w.Body.Bytes(), errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				crResp := &ListCreateResponse{}
+				err := json.Unmarshal(w.Body.Bytes(), crResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.expListID, crResp.ListID)
+			}
+
+			if tc.expComplete {
+				is.AssertCalled(t, "Complete", mock.Anything, int64(1), tc.key, mock.Anything)
+			} else {
+				is.AssertNotCalled(t, "Complete", mock.Anything, int64(1), tc.key, mock.Anything)
+			}
+			if tc.expAbort {
+				is.AssertCalled(t, "Abort", mock.Anything, int64(1), tc.key)
+			} else {
+				is.AssertNotCalled(t, "Abort", mock.Anything, int64(1), tc.key)
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareWithoutService(t *testing.T) {
+	tsObj := new(mocks.TokenService)
+	tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+		int64(1), "aaa-aaa-aaa-aaa", nil,
+	)
+
+	ls := new(mocks.ListService)
+	ls.On("Create", mock.Anything, "title", "description", int64(1)).Return(int64(5), nil)
+
+	handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+	r := handler.InitRoutes(gin.TestMode)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/lists", bytes.NewBufferString(`{"title":"title","description":"description"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotencyHeader, "key")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("Idempotent-Replayed"))
+}
+
+func TestStoreContext(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(logging.WithRequestID(context.Background(), "req-1"))
+	cancel()
+
+	ctx, cancelStore := storeContext(reqCtx)
+	defer cancelStore()
+
+	require.NoError(t, ctx.Err())
+	require.Equal(t, "req-1", logging.RequestID(ctx))
+	_, ok := ctx.Deadline()
+	require.True(t, ok)
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	newRequest := func(body []byte) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/api/lists/1/items/1/attachments", bytes.NewReader(body))
+	}
+
+	large := bytes.Repeat([]byte("a"), 2*fingerprintLimit)
+	req := newRequest(large)
+	fingerprint, err := requestFingerprint(req)
+	require.NoError(t, err)
+
+	// body is not changed by fingerprint
+	body, err := ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, large, body)
+
+	same, err := requestFingerprint(newRequest(large))
+	require.NoError(t, err)
+	require.Equal(t, fingerprint, same)
+
+	other, err := requestFingerprint(newRequest(large[:len(large)-1]))
+	require.NoError(t, err)
+	require.NotEqual(t, fingerprint, other)
+}
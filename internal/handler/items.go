@@ -8,11 +8,6 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-type itemCreateReq struct {
-	Title       string `json:"title" binding:"required,gte=1,lte=255"`
-	Description string `json:"description" binding:"required"`
-}
-
 // CreateItem godoc
 // @Summary Create item
 // @Tags items
@@ -21,25 +16,25 @@ type itemCreateReq struct {
 // @ID create-item
 // @Security ApiKeyAuth
 // @Param list_id path int true "list_id"
-// @Param input body itemCreateReq true "item input"
+// @Param input body models.CreateItemReq true "item input"
 // @Success 200 {object} ItemCreateResponse "success item creation"
-// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors, assignee is not a list member"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 404 {object} ErrorResponse "list not found"
 // @Failure 500 {object} ErrorResponse "internal error"
 // @Router /api/lists/{list_id}/items [post]
 func (h *Handler) itemCreate(c *gin.Context) {
 
-	var req itemCreateReq
-	if !bindData(c, &req) {
+	req := &models.CreateItemReq{}
+	if !bindData(c, req) {
 		return
 	}
 
 	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
-	itemID, err := h.ItemService.Create(req.Title, req.Description, listID)
+	itemID, err := h.ItemService.Create(c.Request.Context(), listID, c.GetInt64(idCtx), req)
 
 	if err != nil {
-		h.InternalError(c, err)
+		h.Error(c, err)
 		return
 	}
 
@@ -57,7 +52,12 @@ func (h *Handler) itemCreate(c *gin.Context) {
 // @ID get-items
 // @Security ApiKeyAuth
 // @Param list_id path int true "list_id"
-// @Success 200 {object} UserItemsResponse "all user items"
+// @Param limit query int false "page size, 20 by default" minimum(1) maximum(100)
+// @Param cursor query string false "next_cursor from previous page"
+// @Param sort query string false "sort field, items without due date are the last" Enums(created_at, title, due_at, priority)
+// @Param order query string false "sort direction" Enums(asc, desc)
+// @Param done query bool false "filter by done status"
+// @Success 200 {object} UserItemsResponse "list items"
 // @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 404 {object} ErrorResponse "list not found"
@@ -66,7 +66,57 @@ func (h *Handler) itemCreate(c *gin.Context) {
 func (h *Handler) getItems(c *gin.Context) {
 	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
 
-	result, err := h.ItemService.GetItems(listID)
+	page, ok := bindPage(c)
+	if !ok {
+		return
+	}
+
+	filter := &models.ItemFilter{}
+	if done, ok := c.GetQuery("done"); ok {
+		value, err := strconv.ParseBool(done)
+		if err != nil {
+			h.Error(c, models.ErrBadParam)
+			return
+		}
+		filter.Done = &value
+	}
+
+	result, next, err := h.ItemService.GetItems(c.Request.Context(), listID, filter, page)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, UserItemsResponse{"success", result, next})
+}
+
+// GetMyItems godoc
+// @Summary Get items from all user lists
+// @Tags items
+// @Produce  json
+// @ID get-my-items
+// @Security ApiKeyAuth
+// @Param assigned query string false "only items assigned to current user" Enums(me)
+// @Success 200 {object} UserItemsResponse "user items"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/me/items [get]
+func (h *Handler) getMyItems(c *gin.Context) {
+	assigned, ok := c.GetQuery("assigned")
+	if ok && assigned != "me" {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	userID, err := h.GetUserId(c)
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	result, err := h.ItemService.GetUserItems(c.Request.Context(), userID, ok)
 
 	if err != nil {
 		h.InternalError(c, err)
@@ -74,7 +124,6 @@ func (h *Handler) getItems(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{"status": "success", "result": result})
-
 }
 
 // GetItem godoc
@@ -86,7 +135,9 @@ func (h *Handler) getItems(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param list_id path int true "list_id"
 // @Param item_id path int true "item_id"
+// @Param If-None-Match header string false "ETag from previous response"
 // @Success 200 {object} models.Item "item"
+// @Success 304 {string} string "item is not modified"
 // @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 404 {object} ErrorResponse "list not found, item not found"
@@ -98,25 +149,18 @@ func (h *Handler) getItemByID(c *gin.Context) {
 	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": models.ErrBadParam.Error(),
-		})
+		h.Error(c, models.ErrBadParam)
 		return
 	}
 
-	item, err := h.ItemService.GetItemByID(listID, itemID)
+	item, err := h.ItemService.GetItemByID(c.Request.Context(), listID, itemID)
 
 	if err != nil {
-		switch err {
-		case models.ErrNoItem:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
+		return
+	}
+
+	if notModified(c, etag(item.Version, item.CommentsCount)) {
 		return
 	}
 
@@ -134,10 +178,12 @@ func (h *Handler) getItemByID(c *gin.Context) {
 // @Param list_id path int true "list_id"
 // @Param item_id path int true "item_id"
 // @Param input body models.UpdateItemReq true "input"
+// @Param If-Match header string false "ETag of item version to update"
 // @Success 200 {string} status	"success"
-// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors, assignee is not a list member"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 404 {object} ErrorResponse "list not found, item not found"
+// @Failure 412 {object} ErrorResponse "item version does not match If-Match"
 // @Failure 500 {object} ErrorResponse "internal error"
 // @Router /api/lists/{list_id}/items/{item_id} [patch]
 func (h *Handler) updateItem(c *gin.Context) {
@@ -145,10 +191,7 @@ func (h *Handler) updateItem(c *gin.Context) {
 	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": models.ErrBadParam.Error(),
-		})
+		h.Error(c, models.ErrBadParam)
 		return
 	}
 
@@ -158,23 +201,16 @@ func (h *Handler) updateItem(c *gin.Context) {
 		return
 	}
 
-	err = h.ItemService.Update(listID, itemID, req)
+	version, ok := h.ifMatchVersion(c, h.itemVersion(listID, itemID))
+	if !ok {
+		return
+	}
+	req.Version = version
+
+	err = h.ItemService.Update(c.Request.Context(), listID, itemID, c.GetInt64(idCtx), req)
 
 	if err != nil {
-		switch err {
-		case models.ErrUpdateEmptyArgs, models.ErrTitleTooShort:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		case models.ErrNoItem:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
 		return
 	}
 
@@ -193,10 +229,12 @@ func (h *Handler) updateItem(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param list_id path int true "list_id"
 // @Param item_id path int true "item_id"
+// @Param If-Match header string false "ETag of item version"
 // @Success 200 {string} status	"success"
 // @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 404 {object} ErrorResponse "list not found, item not found"
+// @Failure 412 {object} ErrorResponse "item version does not match If-Match"
 // @Failure 500 {object} ErrorResponse "internal error"
 // @Router /api/lists/{list_id}/items/{item_id}/done [patch]
 func (h *Handler) doneItem(c *gin.Context) {
@@ -204,30 +242,19 @@ func (h *Handler) doneItem(c *gin.Context) {
 	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": models.ErrBadParam.Error(),
-		})
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c, h.itemVersion(listID, itemID))
+	if !ok {
 		return
 	}
 
-	err = h.ItemService.Done(listID, itemID)
+	err = h.ItemService.Done(c.Request.Context(), listID, itemID, c.GetInt64(idCtx), version)
 
 	if err != nil {
-		switch err {
-		case models.ErrUpdateEmptyArgs, models.ErrTitleTooShort:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		case models.ErrNoItem:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
 		return
 	}
 
@@ -239,6 +266,7 @@ func (h *Handler) doneItem(c *gin.Context) {
 
 // DeleteItem godoc
 // @Summary Delete item
+// @Description Item is moved to trash and can be restored until it is purged
 // @Tags items
 // @Accept  json
 // @Produce  json
@@ -246,10 +274,12 @@ func (h *Handler) doneItem(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param list_id path int true "list_id"
 // @Param item_id path int true "item_id"
+// @Param If-Match header string false "ETag of item version"
 // @Success 200 {string} status	"success"
 // @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 404 {object} ErrorResponse "list not found, item not found"
+// @Failure 412 {object} ErrorResponse "item version does not match If-Match"
 // @Failure 500 {object} ErrorResponse "internal error"
 // @Router /api/lists/{list_id}/items/{item_id} [delete]
 func (h *Handler) deleteItem(c *gin.Context) {
@@ -257,25 +287,19 @@ func (h *Handler) deleteItem(c *gin.Context) {
 	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": models.ErrBadParam.Error(),
-		})
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c, h.itemVersion(listID, itemID))
+	if !ok {
 		return
 	}
 
-	err = h.ItemService.Delete(listID, itemID)
+	err = h.ItemService.Delete(c.Request.Context(), listID, itemID, c.GetInt64(idCtx), version)
 
 	if err != nil {
-		switch err {
-		case models.ErrNoItem:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
 		return
 	}
 
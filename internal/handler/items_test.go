@@ -34,6 +34,7 @@ func TestItemCreate(t *testing.T) {
 		input       string
 		listID      string
 		listServErr error
+		archived    bool
 		crExpRetID  int64
 		crExpRetErr error
 		errMsg      string
@@ -58,6 +59,14 @@ func TestItemCreate(t *testing.T) {
 			crExpRetErr: nil,
 			errMsg:      "Internal server error",
 		},
+		{
+			name:     "List is archived",
+			code:     http.StatusConflict,
+			input:    `{"title": "title", "description": "description"}`,
+			listID:   "1",
+			archived: true,
+			errMsg:   models.ErrListArchived.Error(),
+		},
 		{
 			name:        "Create return unknown error",
 			code:        http.StatusInternalServerError,
@@ -68,6 +77,26 @@ func TestItemCreate(t *testing.T) {
 			crExpRetErr: ErrUnknown,
 			errMsg:      "Internal server error",
 		},
+		{
+			name:        "Assignee is not a list member",
+			code:        http.StatusBadRequest,
+			input:       `{"title": "title", "description": "description", "assignee_id": 5}`,
+			listID:      "1",
+			listServErr: nil,
+			crExpRetID:  0,
+			crExpRetErr: models.ErrNotListMember,
+			errMsg:      models.ErrNotListMember.Error(),
+		},
+		{
+			name:        "Bad priority",
+			code:        http.StatusBadRequest,
+			input:       `{"title": "title", "description": "description", "priority": 7}`,
+			listID:      "1",
+			listServErr: nil,
+			crExpRetID:  0,
+			crExpRetErr: models.ErrBadPriority,
+			errMsg:      models.ErrBadPriority.Error(),
+		},
 		{
 			name:        "Success create",
 			code:        http.StatusOK,
@@ -83,21 +112,22 @@ func TestItemCreate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(tc.archived, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
 				tc.listServErr,
 			)
 
 			is := new(mocks.ItemService)
-			is.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(
+			is.On("Create", mock.Anything, mock.Anything, int64(1), mock.Anything).Return(
 				tc.crExpRetID, tc.crExpRetErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, is, getTestLogger())
+			handler := New(nil, nil, tsObj, ls, is, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -112,8 +142,8 @@ func TestItemCreate(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				require.Equal(t, "success", actResp["status"])
 				require.Equal(t, tc.crExpRetID, int64(actResp["item_id"].(float64)))
@@ -124,16 +154,16 @@ func TestItemCreate(t *testing.T) {
 }
 
 func TestGetItemByID(t *testing.T) {
-	headers := map[string]string{"Authorization": "Bearer token"}
 	tests := []struct {
-		name       string
-		listID     string
-		itemID     string
-		getRetItem *models.Item
-		getRetErr  error
-		code       int
-		expItem    *models.Item
-		errMsg     string
+		name        string
+		listID      string
+		itemID      string
+		ifNoneMatch string
+		getRetItem  *models.Item
+		getRetErr   error
+		code        int
+		expItem     *models.Item
+		errMsg      string
 	}{
 		{
 			name:       "Bad itemID",
@@ -165,6 +195,28 @@ func TestGetItemByID(t *testing.T) {
 			expItem:    nil,
 			errMsg:     "Internal server error",
 		},
+		{
+			name:        "Not modified",
+			listID:      fmt.Sprintf("%d", testList.ID),
+			itemID:      fmt.Sprintf("%d", testItem.ID),
+			ifNoneMatch: fmt.Sprintf(`"100", "%d-%d"`, testItem.Version, testItem.CommentsCount),
+			getRetItem:  testItem,
+			getRetErr:   nil,
+			code:        http.StatusNotModified,
+			expItem:     nil,
+			errMsg:      "",
+		},
+		{
+			name:        "Tag without comments count is modified",
+			listID:      fmt.Sprintf("%d", testList.ID),
+			itemID:      fmt.Sprintf("%d", testItem.ID),
+			ifNoneMatch: fmt.Sprintf(`"%d"`, testItem.Version),
+			getRetItem:  testItem,
+			getRetErr:   nil,
+			code:        http.StatusOK,
+			expItem:     testItem,
+			errMsg:      "",
+		},
 		{
 			name:       "Success",
 			listID:     fmt.Sprintf("%d", testList.ID),
@@ -180,21 +232,27 @@ func TestGetItemByID(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
 				nil,
 			)
 
 			is := new(mocks.ItemService)
-			is.On("GetItemByID", mock.Anything, mock.Anything).Return(
+			is.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(
 				tc.getRetItem, tc.getRetErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, is, getTestLogger())
+			headers := map[string]string{"Authorization": "Bearer token"}
+			if tc.ifNoneMatch != "" {
+				headers["If-None-Match"] = tc.ifNoneMatch
+			}
+
+			handler := New(nil, nil, tsObj, ls, is, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -205,12 +263,14 @@ func TestGetItemByID(t *testing.T) {
 				headers,
 			)
 			require.Equal(t, tc.code, code)
-			if tc.code != 200 {
+			if tc.code == http.StatusNotModified {
+				require.Empty(t, data)
+			} else if tc.code != 200 {
 				errResp := &ErrorResponse{}
 				err := json.Unmarshal(data, errResp)
 				require.NoError(t, err)
-				require.Equal(t, "error", errResp.Status)
-				require.Equal(t, tc.errMsg, errResp.Message)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
 			} else {
 				item := &models.Item{}
 				err := json.Unmarshal(data, item)
@@ -222,14 +282,14 @@ func TestGetItemByID(t *testing.T) {
 }
 
 func TestDeleteItem(t *testing.T) {
-	headers := map[string]string{"Authorization": "Bearer token"}
 	tests := []struct {
-		name   string
-		listID string
-		itemID string
-		retErr error
-		code   int
-		errMsg string
+		name    string
+		listID  string
+		itemID  string
+		ifMatch string
+		retErr  error
+		code    int
+		errMsg  string
 	}{
 		{
 			name:   "Bad itemID",
@@ -255,6 +315,46 @@ func TestDeleteItem(t *testing.T) {
 			code:   http.StatusInternalServerError,
 			errMsg: "Internal server error",
 		},
+		{
+			name:    "Bad If-Match",
+			listID:  "1",
+			itemID:  "2",
+			ifMatch: "abc",
+			retErr:  nil,
+			code:    http.StatusPreconditionFailed,
+			errMsg:  models.ErrVersionMismatch.Error(),
+		},
+		{
+			name:    "Delete return ErrVersionMismatch",
+			listID:  "1",
+			itemID:  "2",
+			ifMatch: `"3"`,
+			retErr:  models.ErrVersionMismatch,
+			code:    http.StatusPreconditionFailed,
+			errMsg:  models.ErrVersionMismatch.Error(),
+		},
+		{
+			name:    "If-Match list with star",
+			listID:  "1",
+			itemID:  "2",
+			ifMatch: `W/"1", *`,
+			code:    http.StatusOK,
+		},
+		{
+			name:    "If-Match list has current version",
+			listID:  "1",
+			itemID:  "2",
+			ifMatch: `"1-4", "7-2"`,
+			code:    http.StatusOK,
+		},
+		{
+			name:    "If-Match list without current version",
+			listID:  "1",
+			itemID:  "2",
+			ifMatch: `"1", "8"`,
+			code:    http.StatusPreconditionFailed,
+			errMsg:  models.ErrVersionMismatch.Error(),
+		},
 		{
 			name:   "Success",
 			listID: fmt.Sprintf("%d", testList.ID),
@@ -268,21 +368,30 @@ func TestDeleteItem(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
 				nil,
 			)
 
 			is := new(mocks.ItemService)
-			is.On("Delete", mock.Anything, mock.Anything).Return(
+			is.On("Delete", mock.Anything, mock.Anything, mock.Anything, int64(1), mock.Anything).Return(
 				tc.retErr,
 			)
+			is.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(
+				&models.Item{Version: 7}, nil,
+			)
 
-			handler := New(nil, nil, tsObj, ls, is, getTestLogger())
+			headers := map[string]string{"Authorization": "Bearer token"}
+			if tc.ifMatch != "" {
+				headers["If-Match"] = tc.ifMatch
+			}
+
+			handler := New(nil, nil, tsObj, ls, is, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -297,8 +406,8 @@ func TestDeleteItem(t *testing.T) {
 				errResp := &ErrorResponse{}
 				err := json.Unmarshal(data, errResp)
 				require.NoError(t, err)
-				require.Equal(t, "error", errResp.Status)
-				require.Equal(t, tc.errMsg, errResp.Message)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
 			}
 		})
 	}
@@ -374,21 +483,22 @@ func TestUpdateItem(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
 				nil,
 			)
 
 			is := new(mocks.ItemService)
-			is.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(
+			is.On("Update", mock.Anything, mock.Anything, mock.Anything, int64(1), mock.Anything).Return(
 				tc.retErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, is, getTestLogger())
+			handler := New(nil, nil, tsObj, ls, is, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -403,8 +513,8 @@ func TestUpdateItem(t *testing.T) {
 				errResp := &ErrorResponse{}
 				err := json.Unmarshal(data, errResp)
 				require.NoError(t, err)
-				require.Equal(t, "error", errResp.Status)
-				require.Equal(t, tc.errMsg, errResp.Message)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
 			}
 		})
 	}
@@ -473,21 +583,22 @@ func TestDoneItem(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
 				nil,
 			)
 
 			is := new(mocks.ItemService)
-			is.On("Done", mock.Anything, mock.Anything).Return(
+			is.On("Done", mock.Anything, mock.Anything, mock.Anything, int64(1), mock.Anything).Return(
 				tc.retErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, is, getTestLogger())
+			handler := New(nil, nil, tsObj, ls, is, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -502,8 +613,8 @@ func TestDoneItem(t *testing.T) {
 				errResp := &ErrorResponse{}
 				err := json.Unmarshal(data, errResp)
 				require.NoError(t, err)
-				require.Equal(t, "error", errResp.Status)
-				require.Equal(t, tc.errMsg, errResp.Message)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
 			}
 		})
 	}
@@ -511,56 +622,162 @@ func TestDoneItem(t *testing.T) {
 
 func TestGetItems(t *testing.T) {
 	headers := map[string]string{"Authorization": "Bearer token"}
+	done := false
 	tests := []struct {
-		name     string
-		listID   string
-		retErr   error
-		retItems []*models.Item
-		code     int
-		expItems []*models.Item
-		errMsg   string
+		name      string
+		listID    string
+		query     string
+		expFilter *models.ItemFilter
+		expPage   *models.PageReq
+		retErr    error
+		retItems  []*models.Item
+		retNext   string
+		code      int
+		expItems  []*models.Item
+		errMsg    string
 	}{
 		{
-			name:   "Unknown error",
+			name:   "Bad done param",
 			listID: "1",
+			query:  "?done=maybe",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:      "Unsupported sort",
+			listID:    "1",
+			query:     "?sort=id",
+			expFilter: &models.ItemFilter{},
+			expPage:   &models.PageReq{Sort: "id"},
+			code:      http.StatusBadRequest,
+			retErr:    models.ErrBadSort,
+			errMsg:    models.ErrBadSort.Error(),
+		},
+		{
+			name:      "Unknown error",
+			listID:    "1",
+			expFilter: &models.ItemFilter{},
+			expPage:   &models.PageReq{},
+			code:      http.StatusInternalServerError,
+			retErr:    ErrUnknown,
+			errMsg:    "Internal server error",
+		},
+		{
+			name:      "Success get items",
+			listID:    "1",
+			query:     "?done=false&sort=due_at&limit=10",
+			expFilter: &models.ItemFilter{Done: &done},
+			expPage:   &models.PageReq{Limit: 10, Sort: "due_at"},
+			code:      http.StatusOK,
+			retItems:  helpers.ExpItems,
+			retNext:   "next",
+			expItems:  helpers.ExpItems,
+			errMsg:    "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
+				nil,
+			)
+
+			is := new(mocks.ItemService)
+			is.On("GetItems", mock.Anything, int64(1), tc.expFilter, tc.expPage).Return(
+				tc.retItems, tc.retNext, tc.retErr,
+			)
+
+			handler := New(nil, nil, tsObj, ls, is, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				fmt.Sprintf("/api/lists/%s/items%s", tc.listID, tc.query),
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := UserItemsResponse{}
+				require.NoError(t, json.Unmarshal(data, &resp))
+				require.Equal(t, "success", resp.Status)
+				require.Equal(t, tc.expItems, resp.Result)
+				require.Equal(t, tc.retNext, resp.NextCursor)
+			}
+		})
+	}
+}
+
+func TestGetMyItems(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+	tests := []struct {
+		name         string
+		query        string
+		onlyAssigned bool
+		retErr       error
+		retItems     []*models.Item
+		code         int
+		errMsg       string
+	}{
+		{
+			name:   "Bad assigned param",
+			query:  "?assigned=other",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:   "Unknown error",
 			code:   http.StatusInternalServerError,
 			retErr: ErrUnknown,
 			errMsg: "Internal server error",
 		},
 		{
-			name:     "Success get items",
-			listID:   "1",
+			name:     "Success get all items",
 			code:     http.StatusOK,
 			retItems: helpers.ExpItems,
-			expItems: helpers.ExpItems,
-			errMsg:   "",
+		},
+		{
+			name:         "Success get assigned items",
+			query:        "?assigned=me",
+			onlyAssigned: true,
+			code:         http.StatusOK,
+			retItems:     helpers.ExpItems,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
-			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
-				nil,
-			)
-
 			is := new(mocks.ItemService)
-			is.On("GetItems", mock.Anything).Return(
+			is.On("GetUserItems", mock.Anything, int64(1), tc.onlyAssigned).Return(
 				tc.retItems, tc.retErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, is, getTestLogger())
+			handler := New(nil, nil, tsObj, nil, is, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
 				t,
 				http.MethodGet,
-				fmt.Sprintf("/api/lists/%s/items", tc.listID),
+				"/api/me/items"+tc.query,
 				bytes.NewBuffer([]byte{}),
 				headers,
 			)
@@ -569,13 +786,13 @@ func TestGetItems(t *testing.T) {
 				errResp := &ErrorResponse{}
 				err := json.Unmarshal(data, errResp)
 				require.NoError(t, err)
-				require.Equal(t, "error", errResp.Status)
-				require.Equal(t, tc.errMsg, errResp.Message)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
 			} else {
 				resp := UserItemsResponse{}
 				require.NoError(t, json.Unmarshal(data, &resp))
 				require.Equal(t, "success", resp.Status)
-				require.Equal(t, tc.expItems, resp.Result)
+				require.Equal(t, tc.retItems, resp.Result)
 			}
 		})
 	}
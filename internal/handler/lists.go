@@ -39,7 +39,7 @@ func (h *Handler) listCreate(c *gin.Context) {
 		return
 	}
 
-	listID, err := h.ListService.Create(req.Title, req.Description, userID)
+	listID, err := h.ListService.Create(c.Request.Context(), req.Title, req.Description, userID)
 
 	if err != nil {
 		h.InternalError(c, err)
@@ -56,7 +56,9 @@ func (h *Handler) listCreate(c *gin.Context) {
 // @ID get-list
 // @Security ApiKeyAuth
 // @Param list_id path int true "list_id"
+// @Param If-None-Match header string false "ETag from previous response"
 // @Success 200 {object} models.List "list"
+// @Success 304 {string} string "list is not modified"
 // @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 404 {object} ErrorResponse "list not found"
@@ -71,24 +73,17 @@ func (h *Handler) getListByID(c *gin.Context) {
 
 	listID, err := strconv.ParseInt(c.Param("list_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": models.ErrBadParam.Error(),
-		})
+		h.Error(c, models.ErrBadParam)
 		return
 	}
 
-	userList, err := h.ListService.GetListByID(listID, userID)
+	userList, err := h.ListService.GetListByID(c.Request.Context(), listID, userID)
 	if err != nil {
-		switch err {
-		case models.ErrNoList:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
+		return
+	}
+
+	if notModified(c, etag(userList.Version)) {
 		return
 	}
 
@@ -114,6 +109,7 @@ type editRoleReq struct {
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 403 {object} ErrorResponse "current user is not admin"
 // @Failure 404 {object} ErrorResponse "list not found, user not found"
+// @Failure 409 {object} ErrorResponse "list is archived"
 // @Failure 500 {object} ErrorResponse "internal error"
 // @Router /api/lists/{list_id}/edit-role [patch]
 func (h *Handler) editRole(c *gin.Context) {
@@ -124,18 +120,46 @@ func (h *Handler) editRole(c *gin.Context) {
 
 	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
 
-	err := h.ListService.EditRole(listID, req.UserID, *(req.IsAdmin))
+	err := h.ListService.EditRole(c.Request.Context(), listID, c.GetInt64(idCtx), req.UserID, *(req.IsAdmin))
 
 	if err != nil {
-		switch err {
-		case models.ErrUserNotFound:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// RemoveMember godoc
+// @Summary Remove user from list
+// @Description Items assigned to removed user become unassigned
+// @Tags lists
+// @Produce  json
+// @ID remove-member
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param user_id path int true "user_id"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors, user is not a member of the list"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 409 {object} ErrorResponse "list is archived"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/members/{user_id} [delete]
+func (h *Handler) removeMember(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	err = h.ListService.RemoveMember(c.Request.Context(), listID, c.GetInt64(idCtx), userID)
+
+	if err != nil {
+		h.Error(c, err)
 		return
 	}
 
@@ -144,33 +168,35 @@ func (h *Handler) editRole(c *gin.Context) {
 
 // DeleteList godoc
 // @Summary Delete list by id
+// @Description List is moved to trash and can be restored until it is purged.
+// @Description Archived list is read-only, it is unarchived before deletion
 // @Tags lists
 // @Produce  json
 // @ID delete-list
 // @Security ApiKeyAuth
 // @Param list_id path int true "list_id"
+// @Param If-Match header string false "ETag of list version to delete"
 // @Success 200 {string} status	"success"
 // @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 403 {object} ErrorResponse "current user is not admin"
 // @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 409 {object} ErrorResponse "list is archived"
+// @Failure 412 {object} ErrorResponse "list version does not match If-Match"
 // @Failure 500 {object} ErrorResponse "internal error"
 // @Router /api/lists/{list_id} [delete]
 func (h *Handler) deleteList(c *gin.Context) {
 	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
 
-	err := h.ListService.Delete(listID)
+	version, ok := h.ifMatchVersion(c, h.listVersion(listID, c.GetInt64(idCtx)))
+	if !ok {
+		return
+	}
+
+	err := h.ListService.Delete(c.Request.Context(), listID, c.GetInt64(idCtx), version)
 
 	if err != nil {
-		switch err {
-		case models.ErrNoList:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
 		return
 	}
 
@@ -185,11 +211,14 @@ func (h *Handler) deleteList(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param list_id path int true "list_id"
 // @Param input body models.UpdateListReq true "input"
+// @Param If-Match header string false "ETag of list version to update"
 // @Success 200 {string} status	"success"
 // @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 403 {object} ErrorResponse "current user is not admin"
 // @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 409 {object} ErrorResponse "list is archived"
+// @Failure 412 {object} ErrorResponse "list version does not match If-Match"
 // @Failure 500 {object} ErrorResponse "internal error"
 // @Router /api/lists/{list_id} [patch]
 func (h *Handler) updateList(c *gin.Context) {
@@ -201,23 +230,66 @@ func (h *Handler) updateList(c *gin.Context) {
 
 	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
 
-	err := h.ListService.Update(listID, &req)
+	version, ok := h.ifMatchVersion(c, h.listVersion(listID, c.GetInt64(idCtx)))
+	if !ok {
+		return
+	}
+	req.Version = version
+
+	err := h.ListService.Update(c.Request.Context(), listID, c.GetInt64(idCtx), &req)
 
 	if err != nil {
-		switch err {
-		case models.ErrUpdateEmptyArgs, models.ErrTitleTooShort:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		case models.ErrNoList:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// ArchiveList godoc
+// @Summary Archive list
+// @Description Archived list is read-only for all members and hidden from lists by default
+// @Tags lists
+// @Produce  json
+// @ID archive-list
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/archive [post]
+func (h *Handler) archiveList(c *gin.Context) {
+	h.setListArchived(c, true)
+}
+
+// UnarchiveList godoc
+// @Summary Unarchive list
+// @Tags lists
+// @Produce  json
+// @ID unarchive-list
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/unarchive [post]
+func (h *Handler) unarchiveList(c *gin.Context) {
+	h.setListArchived(c, false)
+}
+
+func (h *Handler) setListArchived(c *gin.Context, archived bool) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+
+	err := h.ListService.SetArchived(c.Request.Context(), listID, c.GetInt64(idCtx), archived)
+
+	if err != nil {
+		h.Error(c, err)
 		return
 	}
 
@@ -230,18 +302,75 @@ func (h *Handler) updateList(c *gin.Context) {
 // @Produce  json
 // @ID get-lists
 // @Security ApiKeyAuth
+// @Param limit query int false "page size, 20 by default" minimum(1) maximum(100)
+// @Param cursor query string false "next_cursor from previous page"
+// @Param sort query string false "sort field" Enums(created_at, title)
+// @Param order query string false "sort direction" Enums(asc, desc)
+// @Param archived query bool false "return archived lists instead of active ones"
 // @Success 200 {object} UserListsResponse "lists"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
 // @Failure 401 {object} ErrorResponse "user is not authorized"
 // @Failure 404 {object} ErrorResponse "user not found"
 // @Failure 500 {object} ErrorResponse "internal error"
 // @Router /api/lists [get]
 func (h *Handler) getUserLists(c *gin.Context) {
-	result, err := h.ListService.GetUserLists(c.GetInt64(idCtx))
+	page, ok := bindPage(c)
+	if !ok {
+		return
+	}
+
+	filter := &models.ListFilter{}
+	if archived, ok := c.GetQuery("archived"); ok {
+		value, err := strconv.ParseBool(archived)
+		if err != nil {
+			h.Error(c, models.ErrBadParam)
+			return
+		}
+		filter.Archived = value
+	}
+
+	result, next, err := h.ListService.GetUserLists(c.Request.Context(), c.GetInt64(idCtx), filter, page)
 
 	if err != nil {
-		h.InternalError(c, err)
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, UserListsResponse{"success", result, next})
+}
+
+// GetListActivity godoc
+// @Summary Get list activity
+// @Description History of list and item mutations with changed fields
+// @Tags lists
+// @Produce  json
+// @ID get-list-activity
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param limit query int false "page size, 20 by default" minimum(1) maximum(100)
+// @Param cursor query string false "next_cursor from previous page"
+// @Param order query string false "sort direction" Enums(asc, desc)
+// @Success 200 {object} ListActivityResponse "list activity"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/activity [get]
+func (h *Handler) getListActivity(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+
+	page, ok := bindPage(c)
+	if !ok {
+		return
+	}
+
+	result, next, err := h.ListService.GetActivity(c.Request.Context(), listID, page)
+
+	if err != nil {
+		h.Error(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, UserListsResponse{"success", result})
+	c.JSON(http.StatusOK, ListActivityResponse{"success", result, next})
 }
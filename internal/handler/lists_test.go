@@ -68,16 +68,16 @@ func TestListCreate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ls := new(mocks.ListService)
-			ls.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(
+			ls.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 				tc.createRetID, tc.createRetErr,
 			)
 
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				tc.verifyRetUserID, tc.verifyRetUserUUID, tc.verifyRerErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, nil, getTestLogger())
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -92,8 +92,8 @@ func TestListCreate(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				require.Equal(t, "success", actResp["status"])
 				require.Equal(t, tc.expListID, int64(actResp["list_id"].(float64)))
@@ -155,6 +155,22 @@ func TestGetListByID(t *testing.T) {
 			expList:           nil,
 			errMsg:            models.ErrNoList.Error(),
 		},
+		{
+			name: "Not modified",
+			headers: map[string]string{
+				"Authorization": "Bearer token",
+				"If-None-Match": fmt.Sprintf(`W/"%d"`, testList.Version),
+			},
+			paramListID:       "1",
+			verifyRetUserID:   0,
+			verifyRetUserUUID: "",
+			verifyRerErr:      nil,
+			getRetList:        testList,
+			getRetErr:         nil,
+			code:              http.StatusNotModified,
+			expList:           nil,
+			errMsg:            "",
+		},
 		{
 			name:              "Success get",
 			headers:           map[string]string{"Authorization": "Bearer token"},
@@ -173,13 +189,13 @@ func TestGetListByID(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ls := new(mocks.ListService)
-			ls.On("GetListByID", mock.Anything, mock.Anything).Return(tc.getRetList, tc.getRetErr)
+			ls.On("GetListByID", mock.Anything, mock.Anything, mock.Anything).Return(tc.getRetList, tc.getRetErr)
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				tc.verifyRetUserID, tc.verifyRetUserUUID,
 				tc.verifyRerErr,
 			)
-			handler := New(nil, nil, tsObj, ls, nil, getTestLogger())
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -190,12 +206,14 @@ func TestGetListByID(t *testing.T) {
 				tc.headers,
 			)
 			require.Equal(t, tc.code, code)
-			if tc.code != 200 {
+			if tc.code == http.StatusNotModified {
+				require.Empty(t, data)
+			} else if tc.code != 200 {
 				errResp := &ErrorResponse{}
 				err := json.Unmarshal(data, errResp)
 				require.NoError(t, err)
-				require.Equal(t, "error", errResp.Status)
-				require.Equal(t, tc.errMsg, errResp.Message)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
 			} else {
 				userList := &models.List{}
 				err := json.Unmarshal(data, userList)
@@ -215,6 +233,7 @@ func TestEditRole(t *testing.T) {
 		code         int
 		paramListID  string
 		isListAdmRet error
+		archived     bool
 		editRoleRet  error
 		errMsg       string
 	}{
@@ -246,6 +265,14 @@ func TestEditRole(t *testing.T) {
 			isListAdmRet: ErrUnknown,
 			errMsg:       "Internal server error",
 		},
+		{
+			name:         "List is archived",
+			code:         http.StatusConflict,
+			paramListID:  "1",
+			isListAdmRet: nil,
+			archived:     true,
+			errMsg:       models.ErrListArchived.Error(),
+		},
 		{
 			name:         "EditRole return ErrUserNotFound",
 			code:         http.StatusNotFound,
@@ -275,19 +302,20 @@ func TestEditRole(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
 				tc.isListAdmRet,
 			)
-			ls.On("EditRole", mock.Anything, mock.Anything, mock.Anything).Return(
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(tc.archived, nil)
+			ls.On("EditRole", mock.Anything, mock.Anything, int64(1), mock.Anything, mock.Anything).Return(
 				tc.editRoleRet,
 			)
 
-			handler := New(nil, nil, tsObj, ls, nil, getTestLogger())
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -302,8 +330,8 @@ func TestEditRole(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				require.Equal(t, "success", actResp["status"])
 			}
@@ -312,12 +340,12 @@ func TestEditRole(t *testing.T) {
 }
 
 func TestDeleteList(t *testing.T) {
-	headers := map[string]string{"Authorization": "Bearer token"}
-
 	tests := []struct {
 		name         string
 		code         int
 		paramListID  string
+		ifMatch      string
+		archived     bool
 		deleteRetErr error
 		errMsg       string
 	}{
@@ -335,6 +363,14 @@ func TestDeleteList(t *testing.T) {
 			deleteRetErr: models.ErrNoList,
 			errMsg:       models.ErrNoList.Error(),
 		},
+		{
+			name:         "List is archived",
+			code:         http.StatusConflict,
+			paramListID:  "1",
+			archived:     true,
+			deleteRetErr: nil,
+			errMsg:       models.ErrListArchived.Error(),
+		},
 		{
 			name:         "Delete return unknown error",
 			code:         http.StatusInternalServerError,
@@ -342,6 +378,30 @@ func TestDeleteList(t *testing.T) {
 			deleteRetErr: ErrUnknown,
 			errMsg:       "Internal server error",
 		},
+		{
+			name:         "Weak If-Match never matches",
+			code:         http.StatusPreconditionFailed,
+			paramListID:  "1",
+			ifMatch:      `W/"1"`,
+			deleteRetErr: nil,
+			errMsg:       models.ErrVersionMismatch.Error(),
+		},
+		{
+			name:         "Delete return ErrVersionMismatch",
+			code:         http.StatusPreconditionFailed,
+			paramListID:  "1",
+			ifMatch:      `"1"`,
+			deleteRetErr: models.ErrVersionMismatch,
+			errMsg:       models.ErrVersionMismatch.Error(),
+		},
+		{
+			name:         "Success delete with If-Match",
+			code:         http.StatusOK,
+			paramListID:  "1",
+			ifMatch:      `"1"`,
+			deleteRetErr: nil,
+			errMsg:       "",
+		},
 		{
 			name:         "Success delete",
 			code:         http.StatusOK,
@@ -354,19 +414,25 @@ func TestDeleteList(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
 				nil,
 			)
-			ls.On("Delete", mock.Anything).Return(
+			ls.On("IsListArchived", mock.Anything, int64(1)).Return(tc.archived, nil)
+			ls.On("Delete", mock.Anything, mock.Anything, int64(1), mock.Anything).Return(
 				tc.deleteRetErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, nil, getTestLogger())
+			headers := map[string]string{"Authorization": "Bearer token"}
+			if tc.ifMatch != "" {
+				headers["If-Match"] = tc.ifMatch
+			}
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -381,8 +447,93 @@ func TestDeleteList(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
+			} else {
+				require.Equal(t, "success", actResp["status"])
+			}
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name         string
+		code         int
+		paramUserID  string
+		archived     bool
+		removeRetErr error
+		errMsg       string
+	}{
+		{
+			name:        "List is archived",
+			code:        http.StatusConflict,
+			paramUserID: "2",
+			archived:    true,
+			errMsg:      models.ErrListArchived.Error(),
+		},
+		{
+			name:        "Bad user id",
+			code:        http.StatusBadRequest,
+			paramUserID: "abc",
+			errMsg:      models.ErrBadParam.Error(),
+		},
+		{
+			name:         "User is not a member",
+			code:         http.StatusBadRequest,
+			paramUserID:  "2",
+			removeRetErr: models.ErrNotListMember,
+			errMsg:       models.ErrNotListMember.Error(),
+		},
+		{
+			name:         "Remove return unknown error",
+			code:         http.StatusInternalServerError,
+			paramUserID:  "2",
+			removeRetErr: ErrUnknown,
+			errMsg:       "Internal server error",
+		},
+		{
+			name:        "Success remove",
+			code:        http.StatusOK,
+			paramUserID: "2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
+				nil,
+			)
+			ls.On("IsListArchived", mock.Anything, int64(1)).Return(tc.archived, nil)
+			ls.On("RemoveMember", mock.Anything, int64(1), int64(1), mock.Anything).Return(
+				tc.removeRetErr,
+			)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodDelete,
+				fmt.Sprintf("/api/lists/1/members/%s", tc.paramUserID),
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			actResp := map[string]interface{}{}
+			err := json.Unmarshal(data, &actResp)
+			require.NoError(t, err)
+			if tc.code != 200 {
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				require.Equal(t, "success", actResp["status"])
 			}
@@ -397,6 +548,7 @@ func TestUpdateList(t *testing.T) {
 		name         string
 		code         int
 		input        string
+		archived     bool
 		updateRetErr error
 		errMsg       string
 	}{
@@ -421,6 +573,14 @@ func TestUpdateList(t *testing.T) {
 			updateRetErr: models.ErrNoList,
 			errMsg:       models.ErrNoList.Error(),
 		},
+		{
+			name:         "List is archived",
+			code:         http.StatusConflict,
+			input:        `{"title": "123456", "description": "hello world"}`,
+			archived:     true,
+			updateRetErr: nil,
+			errMsg:       models.ErrListArchived.Error(),
+		},
 		{
 			name:         "Unknown error",
 			code:         http.StatusInternalServerError,
@@ -440,19 +600,20 @@ func TestUpdateList(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("IsListAdmin", mock.Anything, mock.Anything).Return(
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(
 				nil,
 			)
-			ls.On("Update", mock.Anything, mock.Anything).Return(
+			ls.On("IsListArchived", mock.Anything, int64(1)).Return(tc.archived, nil)
+			ls.On("Update", mock.Anything, mock.Anything, int64(1), mock.Anything).Return(
 				tc.updateRetErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, nil, getTestLogger())
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -467,8 +628,84 @@ func TestUpdateList(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
+			} else {
+				require.Equal(t, "success", actResp["status"])
+			}
+		})
+	}
+}
+
+func TestSetListArchived(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name     string
+		path     string
+		archived bool
+		retErr   error
+		code     int
+		errMsg   string
+	}{
+		{
+			name:     "List not found",
+			path:     "archive",
+			archived: true,
+			retErr:   models.ErrNoList,
+			code:     http.StatusNotFound,
+			errMsg:   models.ErrNoList.Error(),
+		},
+		{
+			name:     "SetArchived return unknown error",
+			path:     "archive",
+			archived: true,
+			retErr:   ErrUnknown,
+			code:     http.StatusInternalServerError,
+			errMsg:   "Internal server error",
+		},
+		{
+			name:     "Success archive",
+			path:     "archive",
+			archived: true,
+			code:     http.StatusOK,
+		},
+		{
+			name:     "Success unarchive",
+			path:     "unarchive",
+			archived: false,
+			code:     http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			ls.On("SetArchived", mock.Anything, int64(1), int64(1), tc.archived).Return(tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPost,
+				fmt.Sprintf("/api/lists/1/%s", tc.path),
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			actResp := map[string]interface{}{}
+			err := json.Unmarshal(data, &actResp)
+			require.NoError(t, err)
+			if tc.code != 200 {
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				require.Equal(t, "success", actResp["status"])
 			}
@@ -481,13 +718,44 @@ func TestGetUserLists(t *testing.T) {
 
 	tests := []struct {
 		name     string
+		query    string
+		expPage  *models.PageReq
+		archived bool
 		code     int
 		errMsg   string
 		retErr   error
+		retNext  string
 		expLists []*models.List
 	}{
+		{
+			name:   "Bad limit param",
+			query:  "?limit=abc",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:   "Bad order param",
+			query:  "?order=up",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:    "Bad cursor",
+			query:   "?cursor=abc",
+			expPage: &models.PageReq{Cursor: "abc"},
+			code:    http.StatusBadRequest,
+			errMsg:  models.ErrBadCursor.Error(),
+			retErr:  models.ErrBadCursor,
+		},
+		{
+			name:   "Bad archived param",
+			query:  "?archived=abc",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
 		{
 			name:     "Internal error",
+			expPage:  &models.PageReq{},
 			code:     http.StatusInternalServerError,
 			errMsg:   "Internal server error",
 			retErr:   ErrUnknown,
@@ -495,9 +763,20 @@ func TestGetUserLists(t *testing.T) {
 		},
 		{
 			name:     "Success get",
+			query:    "?limit=2&sort=title&order=desc&cursor=abc",
+			expPage:  &models.PageReq{Limit: 2, Sort: "title", Desc: true, Cursor: "abc"},
 			code:     http.StatusOK,
 			errMsg:   "",
 			retErr:   nil,
+			retNext:  "next",
+			expLists: helpers.ExpLists,
+		},
+		{
+			name:     "Success get archived",
+			query:    "?archived=true",
+			expPage:  &models.PageReq{},
+			archived: true,
+			code:     http.StatusOK,
 			expLists: helpers.ExpLists,
 		},
 	}
@@ -505,23 +784,24 @@ func TestGetUserLists(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				int64(1), "aaa-aaa-aaa-aaa", nil,
 			)
 
 			ls := new(mocks.ListService)
-			ls.On("GetUserLists", mock.Anything).Return(
+			ls.On("GetUserLists", mock.Anything, int64(1), &models.ListFilter{Archived: tc.archived}, tc.expPage).Return(
 				tc.expLists,
+				tc.retNext,
 				tc.retErr,
 			)
 
-			handler := New(nil, nil, tsObj, ls, nil, getTestLogger())
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
 				t,
 				http.MethodGet,
-				"/api/lists",
+				"/api/lists"+tc.query,
 				bytes.NewBuffer([]byte{}),
 				headers,
 			)
@@ -530,13 +810,93 @@ func TestGetUserLists(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				resp := UserListsResponse{}
 				require.NoError(t, json.Unmarshal(data, &resp))
 				require.Equal(t, "success", resp.Status)
 				require.Equal(t, tc.expLists, resp.Result)
+				require.Equal(t, tc.retNext, resp.NextCursor)
+			}
+		})
+	}
+}
+
+func TestGetListActivity(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+	activity := []*models.Activity{
+		{ID: 1, ListID: 1, Action: models.ActionListCreate},
+	}
+
+	tests := []struct {
+		name       string
+		isAdminErr error
+		expPage    *models.PageReq
+		retRes     []*models.Activity
+		retErr     error
+		code       int
+		errMsg     string
+	}{
+		{
+			name:       "Not admin",
+			isAdminErr: models.ErrNoListAccess,
+			code:       http.StatusForbidden,
+			errMsg:     models.ErrNoListAccess.Error(),
+		},
+		{
+			name:    "Bad cursor",
+			expPage: &models.PageReq{Desc: true},
+			retErr:  models.ErrBadCursor,
+			code:    http.StatusBadRequest,
+			errMsg:  models.ErrBadCursor.Error(),
+		},
+		{
+			name:    "GetActivity return unknown error",
+			expPage: &models.PageReq{Desc: true},
+			retErr:  ErrUnknown,
+			code:    http.StatusInternalServerError,
+			errMsg:  "Internal server error",
+		},
+		{
+			name:    "Success get",
+			expPage: &models.PageReq{Desc: true},
+			retRes:  activity,
+			code:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(tc.isAdminErr)
+			ls.On("GetActivity", mock.Anything, int64(1), tc.expPage).Return(tc.retRes, "next", tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/lists/1/activity?order=desc",
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				require.NoError(t, json.Unmarshal(data, errResp))
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := &ListActivityResponse{}
+				require.NoError(t, json.Unmarshal(data, resp))
+				require.Equal(t, tc.retRes, resp.Result)
+				require.Equal(t, "next", resp.NextCursor)
 			}
 		})
 	}
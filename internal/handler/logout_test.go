@@ -136,13 +136,13 @@ func TestLogout(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Verify", mock.Anything).Return(
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
 				tc.verifyRetUserID, tc.verifyRetUserUUID, tc.verifyRerErr,
 			)
 
-			tsObj.On("Logout", mock.Anything, mock.Anything).Return(tc.logoutRetErr)
+			tsObj.On("Logout", mock.Anything, mock.Anything, mock.Anything).Return(tc.logoutRetErr)
 
-			handler := New(nil, nil, tsObj, nil, nil, getTestLogger())
+			handler := New(nil, nil, tsObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -157,8 +157,8 @@ func TestLogout(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				require.Equal(t, "success", actResp["status"])
 			}
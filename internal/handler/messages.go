@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultLanguage is language of error messages in code
+const defaultLanguage = "en"
+
+// translations of error messages by language and error code
+var translations = map[string]map[string]string{
+	"ru": {
+		codeInternal:       "Внутренняя ошибка сервера",
+		codeNotFound:       "Страница не найдена",
+		codeBadContentType: "Поддерживается только Content-Type application/json",
+		codeBadJSON:        "Не удалось разобрать JSON запроса",
+		codeValidation:     "Неверные параметры запроса, см. invalid_params",
+
+		"user_already_exists":     "пользователь уже существует",
+		"confirm_link_not_found":  "ссылка подтверждения не найдена",
+		"bad_credentials":         "неверный email или пароль",
+		"user_not_activated":      "пользователь не активирован",
+		"max_logged_in":           "достигнуто максимальное число входов",
+		"bad_token":               "неверный токен",
+		"user_unauthorized":       "пользователь не авторизован",
+		"token_expired":           "срок действия токена истек",
+		"no_auth_header":          "нет заголовка авторизации",
+		"bad_auth_header":         "неверный заголовок авторизации",
+		"list_not_found":          "список не найден",
+		"item_not_found":          "задача не найдена",
+		"bad_param":               "неверный параметр url",
+		"no_list_access":          "нет доступа к этому списку",
+		"user_not_found":          "пользователь не найден",
+		"empty_update":            "пустые название и описание",
+		"title_too_short":         "слишком короткое название, минимум 5 символов",
+		"comment_not_found":       "комментарий не найден",
+		"no_comment_access":       "нет доступа к этому комментарию",
+		"attachment_not_found":    "вложение не найдено",
+		"no_attachment_access":    "нет доступа к этому вложению",
+		"file_too_large":          "файл слишком большой",
+		"file_type_not_allowed":   "тип файла не разрешен",
+		"blob_not_found":          "файл не найден",
+		"no_file":                 "в запросе нет файла",
+		"not_list_member":         "пользователь не является участником списка",
+		"empty_search_query":      "пустой поисковый запрос",
+		"bad_search_language":     "язык поиска не поддерживается",
+		"bad_cursor":              "неверный курсор",
+		"bad_sort":                "поле сортировки не поддерживается",
+		"bad_page_limit":          "limit должен быть от 1 до 100",
+		"bad_priority":            "приоритет должен быть от 0 до 3",
+		"version_mismatch":        "ресурс был изменен, версия не совпадает",
+		"list_archived":           "список в архиве и доступен только для чтения",
+		"webhook_not_found":       "вебхук не найден",
+		"bad_webhook_url":         "url вебхука должен быть абсолютным http или https url",
+		"bad_webhook_event":       "неизвестное событие вебхука",
+		"bad_sync_token":          "неверный токен синхронизации",
+		"bad_sync_op":             "неизвестная операция синхронизации",
+		"no_sync_title":           "название и описание обязательны",
+		"sync_mutation_not_found": "изменение синхронизации не найдено",
+		"sync_mutation_applied":   "изменение синхронизации уже применено",
+		"bad_idempotency_key":     "ключ идемпотентности должен быть от 1 до 255 символов",
+		"idempotency_key_reused":  "ключ идемпотентности использован с другим запросом",
+		"idempotency_in_flight":   "запрос с этим ключом идемпотентности еще выполняется",
+		"rate_limited":            "слишком много запросов, повторите позже",
+		"request_timeout":         "время выполнения запроса истекло",
+	},
+}
+
+// language returns the most preferred supported language from Accept-Language header
+func language(c *gin.Context) string {
+	best, bestQ := defaultLanguage, 0.0
+
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		params := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(params[0]))
+		tag = strings.SplitN(tag, "-", 2)[0]
+
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		_, ok := translations[tag]
+		if (ok || tag == defaultLanguage) && q > bestQ {
+			best, bestQ = tag, q
+		}
+	}
+
+	return best
+}
+
+// localize returns message of error code in lang, msg is used if there is no translation
+func localize(lang, code, msg string) string {
+	if translated, ok := translations[lang][code]; ok {
+		return translated
+	}
+	return msg
+}
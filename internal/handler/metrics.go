@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// methodLabel returns method of request or OtherMethod, any method is accepted
+// by server and arbitrary methods would create new series
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return metrics.OtherMethod
+}
+
+// metricsMiddleware counts requests and their latency by route template,
+// so path parameters don't create new series
+func metricsMiddleware(c *gin.Context) {
+	start := time.Now()
+
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = metrics.UnmatchedRoute
+	}
+	method := methodLabel(c.Request.Method)
+	code := strconv.Itoa(c.Writer.Status())
+
+	metrics.HTTPRequests.WithLabelValues(route, method, code).Inc()
+	metrics.HTTPDuration.WithLabelValues(route, method, code).Observe(time.Since(start).Seconds())
+}
+
+// metricsAuthMiddleware allows scraping only with MetricsToken in bearer authorization header
+func (h *Handler) metricsAuthMiddleware(c *gin.Context) {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.MetricsToken)) != 1 {
+		h.PageNotFound(c)
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// metricsHandler exposes metrics of default prometheus registry
+var metricsHandler = gin.WrapH(promhttp.Handler())
+
+// recordSignIn counts result of sign in attempt
+func recordSignIn(err error) {
+	result := metrics.SignInSuccess
+	if err != nil {
+		result = metrics.SignInFailure
+	}
+	metrics.SignIns.WithLabelValues(result).Inc()
+}
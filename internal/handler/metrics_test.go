@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/metrics"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		headers map[string]string
+		code    int
+	}{
+		{
+			name:    "Metrics disabled",
+			headers: map[string]string{"Authorization": "Bearer "},
+			code:    http.StatusNotFound,
+		},
+		{
+			name:    "No token",
+			token:   "secret",
+			headers: map[string]string{},
+			code:    http.StatusNotFound,
+		},
+		{
+			name:    "Bad token",
+			token:   "secret",
+			headers: map[string]string{"Authorization": "Bearer bad"},
+			code:    http.StatusNotFound,
+		},
+		{
+			name:    "Success scrape",
+			token:   "secret",
+			headers: map[string]string{"Authorization": "Bearer secret"},
+			code:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			handler.MetricsToken = tc.token
+			r := handler.InitRoutes(gin.TestMode)
+
+			code, _ := helpers.MakeRequest(r, t, http.MethodGet, "/healthz", bytes.NewBuffer([]byte{}), nil)
+			require.Equal(t, http.StatusOK, code)
+
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/metrics",
+				bytes.NewBuffer([]byte{}),
+				tc.headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code == http.StatusOK {
+				require.Contains(
+					t, string(data),
+					`todo_http_requests_total{code="200",method="GET",route="/healthz"}`,
+				)
+			}
+		})
+	}
+}
+
+func TestMetricsRouteLabel(t *testing.T) {
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+	r := handler.InitRoutes(gin.TestMode)
+
+	unmatched := metrics.HTTPRequests.WithLabelValues(metrics.UnmatchedRoute, http.MethodGet, "404")
+	before := testutil.ToFloat64(unmatched)
+
+	code, _ := helpers.MakeRequest(r, t, http.MethodGet, "/no/such/page/5", bytes.NewBuffer([]byte{}), nil)
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, before+1, testutil.ToFloat64(unmatched))
+}
+
+func TestMetricsMethodLabel(t *testing.T) {
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+	r := handler.InitRoutes(gin.TestMode)
+
+	other := metrics.HTTPRequests.WithLabelValues(metrics.UnmatchedRoute, metrics.OtherMethod, "404")
+	before := testutil.ToFloat64(other)
+
+	code, _ := helpers.MakeRequest(r, t, "PURGE", "/healthz", bytes.NewBuffer([]byte{}), nil)
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, before+1, testutil.ToFloat64(other))
+}
+
+func TestSignInMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		retErr error
+		result string
+	}{
+		{
+			name:   "Failed sign in",
+			retErr: models.ErrBadUser,
+			result: metrics.SignInFailure,
+		},
+		{
+			name:   "Success sign in",
+			result: metrics.SignInSuccess,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			us := new(mocks.UserService)
+			us.On("SignIn", mock.Anything, mock.Anything, mock.Anything).Return(&models.User{ID: 1}, tc.retErr)
+
+			tsObj := new(mocks.TokenService)
+			tsObj.On("NewTokenPair", mock.Anything, int64(1)).Return(&models.TokenDetails{}, nil)
+
+			handler := New(us, nil, tsObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+
+			counter := metrics.SignIns.WithLabelValues(tc.result)
+			before := testutil.ToFloat64(counter)
+
+			helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPost,
+				"/auth/sign-in",
+				bytes.NewBuffer([]byte(`{"email":"user@example.com","password":"password"}`)),
+				nil,
+			)
+			require.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
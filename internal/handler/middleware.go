@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,17 +11,16 @@ import (
 )
 
 var (
-	idCtx   = "CtxUserID"
-	CtxUUID = "CtxUUID"
+	idCtx      = "CtxUserID"
+	CtxUUID    = "CtxUUID"
+	CtxIsAdmin = "CtxIsAdmin"
+	expCtx     = "CtxTokenExp"
 )
 
 func (h *Handler) authMiddleware(c *gin.Context) {
 	header := c.GetHeader("Authorization")
 	if header == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": models.ErrNoAuthHeader.Error(),
-		})
+		h.Error(c, models.ErrNoAuthHeader)
 		c.Abort()
 		return
 	}
@@ -28,31 +28,40 @@ func (h *Handler) authMiddleware(c *gin.Context) {
 	headerParts := strings.Split(header, " ")
 
 	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": models.ErrInvalidAuthHeader.Error(),
-		})
+		h.Error(c, models.ErrInvalidAuthHeader)
 		c.Abort()
 		return
 	}
 
-	userID, userUUID, err := h.TokenService.Verify(headerParts[1])
+	h.verifyToken(c, headerParts[1])
+}
+
+// streamAuthMiddleware authenticates event streams. Browser EventSource can't set headers,
+// so access token is also accepted from access_token query parameter. Stream of such token
+// is closed when it expires, there is no header to refresh it
+func (h *Handler) streamAuthMiddleware(c *gin.Context) {
+	token := c.Query("access_token")
+	if token == "" {
+		h.authMiddleware(c)
+		return
+	}
 
+	exp, err := h.TokenService.ExpiresAt(token)
 	if err != nil {
-		switch err {
-		case models.ErrBadToken:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		case models.ErrUserUnauthorized, models.ErrTokenExpired:
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
+		c.Abort()
+		return
+	}
+
+	c.Set(expCtx, exp)
+	h.verifyToken(c, token)
+}
+
+func (h *Handler) verifyToken(c *gin.Context, token string) {
+	userID, userUUID, err := h.TokenService.Verify(c.Request.Context(), token)
+
+	if err != nil {
+		h.Error(c, err)
 		c.Abort()
 		return
 	}
@@ -62,44 +71,51 @@ func (h *Handler) authMiddleware(c *gin.Context) {
 	c.Next()
 }
 
-func (h *Handler) checkAdminAccess(c *gin.Context) error {
+// listAccess returns list of path and current user
+func (h *Handler) listAccess(c *gin.Context) (int64, int64, error) {
 	userID, err := h.GetUserId(c)
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
 
 	listID, err := strconv.ParseInt(c.Param("list_id"), 10, 64)
 	if err != nil {
-		return models.ErrBadParam
+		return 0, 0, models.ErrBadParam
+	}
+
+	return listID, userID, nil
+}
+
+func (h *Handler) checkAdminAccess(c *gin.Context) error {
+	listID, userID, err := h.listAccess(c)
+	if err != nil {
+		return err
 	}
 
-	return h.ListService.IsListAdmin(listID, userID)
+	return h.ListService.IsListAdmin(c.Request.Context(), listID, userID)
 }
 
 func (h *Handler) onlyAdminAccessMiddleware(c *gin.Context) {
 	err := h.checkAdminAccess(c)
 
 	if err != nil {
-		switch err {
-		case models.ErrBadParam:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-
-		case models.ErrNoList:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		case models.ErrNoListAccess:
-			c.JSON(http.StatusForbidden, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+		h.Error(c, err)
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// onlyTrashAdminMiddleware is onlyAdminAccessMiddleware for list in trash
+func (h *Handler) onlyTrashAdminMiddleware(c *gin.Context) {
+	listID, userID, err := h.listAccess(c)
+	if err == nil {
+		err = h.TrashService.IsDeletedListAdmin(c.Request.Context(), listID, userID)
+	}
+
+	if err != nil {
+		h.Error(c, err)
 		c.Abort()
 		return
 	}
@@ -110,24 +126,48 @@ func (h *Handler) onlyAdminAccessMiddleware(c *gin.Context) {
 func (h *Handler) checkAccessToListMiddleware(c *gin.Context) {
 	err := h.checkAdminAccess(c)
 
-	if err != nil && err != models.ErrNoListAccess {
-		switch err {
-		case models.ErrBadParam:
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-
-		case models.ErrNoList:
-			c.JSON(http.StatusNotFound, gin.H{
-				"status":  "error",
-				"message": err.Error(),
-			})
-		default:
-			h.InternalError(c, err)
-		}
+	if err != nil && !errors.Is(err, models.ErrNoListAccess) {
+		h.Error(c, err)
 		c.Abort()
 		return
 	}
+	isAdmin := err == nil
+
+	if !h.checkNotArchived(c) {
+		return
+	}
+
+	c.Set(CtxIsAdmin, isAdmin)
 	c.Next()
 }
+
+// checkNotArchived answers changing request to archived list with 409, then it returns false
+func (h *Handler) checkNotArchived(c *gin.Context) bool {
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		return true
+	}
+
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+
+	archived, err := h.ListService.IsListArchived(c.Request.Context(), listID)
+	if err != nil {
+		h.Error(c, err)
+		c.Abort()
+		return false
+	}
+
+	if archived {
+		h.Error(c, models.ErrListArchived)
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// notArchivedMiddleware makes archived list read-only for admins,
+// it is used after onlyAdminAccessMiddleware
+func (h *Handler) notArchivedMiddleware(c *gin.Context) {
+	if h.checkNotArchived(c) {
+		c.Next()
+	}
+}
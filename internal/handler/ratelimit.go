@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
+
+// rateLimitMiddleware limits requests of route group per user,
+// requests without authorized user are limited per ip.
+// Requests are not limited if redis is unavailable
+func (h *Handler) rateLimitMiddleware(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.RateLimitService == nil {
+			c.Next()
+			return
+		}
+
+		subject := "ip:" + h.clientIP(c)
+		if _, ok := c.Get(idCtx); ok {
+			subject = fmt.Sprintf("user:%d", c.GetInt64(idCtx))
+		}
+
+		res, err := h.RateLimitService.Allow(c.Request.Context(), group, subject)
+		if err != nil {
+			h.log(c).Error(err)
+			c.Next()
+			return
+		}
+
+		if res == nil {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		c.Header("RateLimit-Reset", seconds(res.Reset))
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%s", res.Limit, seconds(res.Window)))
+
+		if !res.Allowed {
+			c.Header("Retry-After", seconds(res.Reset))
+			h.Error(c, models.ErrRateLimited)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
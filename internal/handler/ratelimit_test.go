@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		group      string
+		subject    string
+		retRes     *models.RateLimitResult
+		retErr     error
+		code       int
+		limit      string
+		remaining  string
+		reset      string
+		retryAfter string
+	}{
+		{
+			name:    "Group is not limited",
+			method:  http.MethodGet,
+			path:    "/api/lists",
+			group:   models.RateLimitAPI,
+			subject: "user:1",
+			code:    http.StatusOK,
+		},
+		{
+			name:    "Redis error allows request",
+			method:  http.MethodGet,
+			path:    "/api/lists",
+			group:   models.RateLimitAPI,
+			subject: "user:1",
+			retErr:  ErrUnknown,
+			code:    http.StatusOK,
+		},
+		{
+			name:    "Request allowed",
+			method:  http.MethodGet,
+			path:    "/api/lists",
+			group:   models.RateLimitAPI,
+			subject: "user:1",
+			retRes: &models.RateLimitResult{
+				Allowed: true, Limit: 10, Remaining: 9, Window: time.Minute, Reset: 59500 * time.Millisecond,
+			},
+			code:      http.StatusOK,
+			limit:     "10",
+			remaining: "9",
+			reset:     "60",
+		},
+		{
+			name:    "User limit exceeded",
+			method:  http.MethodGet,
+			path:    "/api/lists",
+			group:   models.RateLimitAPI,
+			subject: "user:1",
+			retRes: &models.RateLimitResult{
+				Limit: 10, Window: time.Minute, Reset: 2 * time.Second,
+			},
+			code:       http.StatusTooManyRequests,
+			limit:      "10",
+			remaining:  "0",
+			reset:      "2",
+			retryAfter: "2",
+		},
+		{
+			name:    "Ip limit exceeded",
+			method:  http.MethodPost,
+			path:    "/auth/sign-in",
+			group:   models.RateLimitAuth,
+			subject: "ip:192.0.2.1",
+			retRes: &models.RateLimitResult{
+				Limit: 5, Window: time.Minute, Reset: 30 * time.Second,
+			},
+			code:       http.StatusTooManyRequests,
+			limit:      "5",
+			remaining:  "0",
+			reset:      "30",
+			retryAfter: "30",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("GetUserLists", mock.Anything, int64(1), mock.Anything, mock.Anything).Return([]*models.List{}, "", nil)
+
+			rs := new(mocks.RateLimitService)
+			rs.On("Allow", mock.Anything, tc.group, tc.subject).Return(tc.retRes, tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, rs, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewBuffer([]byte{}))
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.code, w.Code)
+			require.Equal(t, tc.limit, w.Header().Get("RateLimit-Limit"))
+			require.Equal(t, tc.remaining, w.Header().Get("RateLimit-Remaining"))
+			require.Equal(t, tc.reset, w.Header().Get("RateLimit-Reset"))
+			require.Equal(t, tc.retryAfter, w.Header().Get("Retry-After"))
+			if tc.code == http.StatusTooManyRequests {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(w.Body.Bytes(), errResp)
+				require.NoError(t, err)
+				require.Equal(t, models.ErrRateLimited.Error(), errResp.Detail)
+			}
+			rs.AssertExpectations(t)
+		})
+	}
+}
@@ -67,9 +67,9 @@ func TestRefresh(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			reqData := `{"refresh_token": "token"}`
 			tsObj := new(mocks.TokenService)
-			tsObj.On("Refresh", mock.Anything).Return(tc.tsRetTd, tc.tsRetErr)
+			tsObj.On("Refresh", mock.Anything, mock.Anything).Return(tc.tsRetTd, tc.tsRetErr)
 
-			handler := New(nil, nil, tsObj, nil, nil, getTestLogger())
+			handler := New(nil, nil, tsObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -84,8 +84,8 @@ func TestRefresh(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				require.NotEmpty(t, actResp["access_token"])
 				require.NotEmpty(t, actResp["refresh_token"])
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID allows ids of up to 128 letters, digits and -_.: symbols,
+// so client ids can't inject anything into logs
+func validRequestID(id string) bool {
+	if len(id) == 0 || len(id) > 128 {
+		return false
+	}
+
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// requestIDMiddleware accepts valid X-Request-ID header or generates new id,
+// id and logger are stored in request context, id is sent back in response header
+func (h *Handler) requestIDMiddleware(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if !validRequestID(id) {
+		id = uuid.NewString()
+	}
+
+	ctx := logging.WithRequestID(c.Request.Context(), id)
+	c.Request = c.Request.WithContext(logging.WithLogger(ctx, h.logger))
+	c.Header(requestIDHeader, id)
+	c.Next()
+}
+
+// log returns logger entry with request id and id of authorized user
+func (h *Handler) log(c *gin.Context) *logrus.Entry {
+	entry := logging.Entry(h.logger, c.Request.Context())
+	if userID, ok := c.Get(idCtx); ok {
+		entry = entry.WithField("user_id", userID)
+	}
+	return entry
+}
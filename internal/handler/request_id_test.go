@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		generated bool
+	}{
+		{
+			name:      "Accept client id",
+			requestID: "client-id_1.2:3",
+		},
+		{
+			name:      "Generate id",
+			generated: true,
+		},
+		{
+			name:      "Replace invalid id",
+			requestID: "bad id\n",
+			generated: true,
+		},
+		{
+			name:      "Replace too long id",
+			requestID: strings.Repeat("a", 129),
+			generated: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, hook := test.NewNullLogger()
+
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("Create", mock.Anything, "title", "description", int64(1)).Return(int64(0), ErrUnknown)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, logger)
+			r := handler.InitRoutes(gin.TestMode)
+
+			req := httptest.NewRequest(
+				http.MethodPost, "/api/lists",
+				bytes.NewBufferString(`{"title":"title","description":"description"}`),
+			)
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "test-agent")
+			if tc.requestID != "" {
+				req.Header.Set(requestIDHeader, tc.requestID)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusInternalServerError, w.Code)
+
+			id := w.Header().Get(requestIDHeader)
+			if tc.generated {
+				require.Len(t, id, 36)
+			} else {
+				require.Equal(t, tc.requestID, id)
+			}
+
+			errResp := &ErrorResponse{}
+			err := json.Unmarshal(w.Body.Bytes(), errResp)
+			require.NoError(t, err)
+			require.Equal(t, id, errResp.RequestID)
+
+			entries := hook.AllEntries()
+			require.Len(t, entries, 2)
+
+			internal, access := entries[0], entries[1]
+			require.Equal(t, logrus.ErrorLevel, internal.Level)
+			require.Equal(t, ErrUnknown.Error(), internal.Message)
+			require.Equal(t, id, internal.Data["request_id"])
+			require.Equal(t, int64(1), internal.Data["user_id"])
+
+			require.Equal(t, "access", access.Message)
+			require.Equal(t, id, access.Data["request_id"])
+			require.Equal(t, int64(1), access.Data["user_id"])
+			require.Equal(t, http.StatusInternalServerError, access.Data["code"])
+			require.Equal(t, "test-agent", access.Data["user_agent"])
+			require.Equal(t, "192.0.2.1", access.Data["client_ip"])
+			require.Equal(t, w.Body.Len(), access.Data["size"])
+			require.Contains(t, access.Data, "latency_ms")
+		})
+	}
+}
+
+func TestRequestIDOnNotFound(t *testing.T) {
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+	r := handler.InitRoutes(gin.TestMode)
+
+	req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+	req.Header.Set(requestIDHeader, "abc")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	errResp := &ErrorResponse{}
+	err := json.Unmarshal(w.Body.Bytes(), errResp)
+	require.NoError(t, err)
+	require.Equal(t, "abc", errResp.RequestID)
+	require.Equal(t, codeNotFound, errResp.Code)
+}
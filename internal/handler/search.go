@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Search godoc
+// @Summary Search lists and items
+// @Description Full-text search in titles and descriptions of lists and items available to user.
+// @Description Matched words in snippets are highlighted with <b></b> tags, other text of snippets is HTML-escaped
+// @Tags search
+// @Produce  json
+// @ID search
+// @Security ApiKeyAuth
+// @Param q query string true "search query, websearch syntax"
+// @Param lang query string false "query language, default is set in config" Enums(en, english, ru, russian)
+// @Success 200 {object} SearchResponse "results ordered by rank"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/search [get]
+func (h *Handler) search(c *gin.Context) {
+	userID, err := h.GetUserId(c)
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	result, err := h.SearchService.Search(c.Request.Context(), userID, c.Query("q"), c.Query("lang"))
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SearchResponse{"success", result})
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	expRes := []*models.SearchResult{
+		{
+			Type:    models.SearchTypeItem,
+			ListID:  testItem.ListID,
+			ItemID:  &testItem.ID,
+			Title:   testItem.Title,
+			Snippet: "<b>hello</b> world",
+			Rank:    0.1,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		retRes []*models.SearchResult
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Empty query",
+			query:  "",
+			retErr: models.ErrEmptySearchQuery,
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrEmptySearchQuery.Error(),
+		},
+		{
+			name:   "Bad language",
+			query:  "?q=hello&lang=de",
+			retErr: models.ErrBadSearchLanguage,
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadSearchLanguage.Error(),
+		},
+		{
+			name:   "Search return unknown error",
+			query:  "?q=hello",
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name:   "Success search",
+			query:  "?q=hello&lang=en",
+			retRes: expRes,
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ss := new(mocks.SearchService)
+			ss.On("Search", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(tc.retRes, tc.retErr)
+
+			handler := New(nil, nil, tsObj, nil, nil, nil, nil, ss, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/search"+tc.query,
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.code, errResp.Status)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := &SearchResponse{}
+				require.NoError(t, json.Unmarshal(data, resp))
+				require.Equal(t, "success", resp.Status)
+				require.Equal(t, tc.retRes, resp.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		hstsMaxAge time.Duration
+		isTLS      bool
+		hsts       string
+	}{
+		{
+			name:       "Plain http has no hsts",
+			hstsMaxAge: time.Hour,
+		},
+		{
+			name:  "Hsts disabled",
+			isTLS: true,
+		},
+		{
+			name:       "Hsts over tls",
+			hstsMaxAge: 365 * 24 * time.Hour,
+			isTLS:      true,
+			hsts:       "max-age=31536000",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			handler.HSTSMaxAge = tc.hstsMaxAge
+			r := handler.InitRoutes(gin.TestMode)
+
+			req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+			if tc.isTLS {
+				req.TLS = &tls.ConnectionState{}
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+			require.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+			require.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+			require.Equal(t, tc.hsts, w.Header().Get("Strict-Transport-Security"))
+		})
+	}
+}
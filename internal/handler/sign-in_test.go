@@ -90,11 +90,11 @@ func TestSignIn(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			reqData := `{"email": "test@test.com", "password": "123456789"}`
 			usObj := new(mocks.UserService)
-			usObj.On("SignIn", mock.Anything, mock.Anything).Return(tc.usRetUser, tc.usRetErr)
+			usObj.On("SignIn", mock.Anything, mock.Anything, mock.Anything).Return(tc.usRetUser, tc.usRetErr)
 			tsObj := new(mocks.TokenService)
-			tsObj.On("NewTokenPair", mock.Anything).Return(tc.tsRetTd, tc.tsRetErr)
+			tsObj.On("NewTokenPair", mock.Anything, mock.Anything).Return(tc.tsRetTd, tc.tsRetErr)
 
-			handler := New(usObj, nil, tsObj, nil, nil, getTestLogger())
+			handler := New(usObj, nil, tsObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -109,8 +109,8 @@ func TestSignIn(t *testing.T) {
 			err := json.Unmarshal(data, &actResp)
 			require.NoError(t, err)
 			if tc.code != 200 {
-				require.Equal(t, "error", actResp["status"])
-				require.Equal(t, tc.errMsg, actResp["message"])
+				require.Equal(t, float64(tc.code), actResp["status"])
+				require.Equal(t, tc.errMsg, actResp["detail"])
 			} else {
 				require.NotEmpty(t, actResp["access_token"])
 				require.NotEmpty(t, actResp["refresh_token"])
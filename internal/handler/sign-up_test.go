@@ -24,10 +24,10 @@ func TestSendMailReturnForSignUp(t *testing.T) {
 	reqData := `{"email": "test@test.com", "password": "123456789"}`
 	usObj := new(mocks.UserService)
 	msObj := new(mocks.MailService)
-	msObj.On("SendConfirmationsEmail", mock.Anything).Return(errors.New("Send mail error"))
-	usObj.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+	msObj.On("SendConfirmationsEmail", mock.Anything, mock.Anything).Return(errors.New("Send mail error"))
+	usObj.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
-	handler := New(usObj, msObj, nil, nil, nil, getTestLogger())
+	handler := New(usObj, msObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 	r := handler.InitRoutes(gin.TestMode)
 	code, _ := helpers.MakeRequest(
 		r,
@@ -57,10 +57,10 @@ func TestCreateErrorForSignUp(t *testing.T) {
 			reqData := `{"email": "test@test.com", "password": "123456789"}`
 			usObj := new(mocks.UserService)
 			msObj := new(mocks.MailService)
-			msObj.On("SendConfirmationsEmail", mock.Anything).Return(nil)
-			usObj.On("Create", mock.Anything, mock.Anything).Return(nil, tc.retErr)
+			msObj.On("SendConfirmationsEmail", mock.Anything, mock.Anything).Return(nil)
+			usObj.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.retErr)
 
-			handler := New(usObj, msObj, nil, nil, nil, getTestLogger())
+			handler := New(usObj, msObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, _ := helpers.MakeRequest(
 				r,
@@ -79,11 +79,11 @@ func TestCreateErrorForSignUp(t *testing.T) {
 
 func TestBadContentType(t *testing.T) {
 	usObj := new(mocks.UserService)
-	usObj.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+	usObj.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 	msObj := new(mocks.MailService)
-	msObj.On("SendConfirmationsEmail", mock.Anything).Return(nil)
+	msObj.On("SendConfirmationsEmail", mock.Anything, mock.Anything).Return(nil)
 
-	handler := New(usObj, msObj, nil, nil, nil, getTestLogger())
+	handler := New(usObj, msObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 	r := handler.InitRoutes(gin.TestMode)
 	req := httptest.NewRequest(http.MethodPost, "/auth/sign-up", nil)
 	w := httptest.NewRecorder()
@@ -96,18 +96,26 @@ func TestBadContentType(t *testing.T) {
 
 	require.Equal(t, http.StatusBadRequest, res.StatusCode)
 
-	expResp := map[string]interface{}{
-		"error": "/auth/sign-up only accepts Content-Type application/json",
+	expResp := &ErrorResponse{
+		Type:     problemTypeBase + codeBadContentType,
+		Title:    http.StatusText(http.StatusBadRequest),
+		Status:   http.StatusBadRequest,
+		Detail:   "only Content-Type application/json is accepted",
+		Instance: "/auth/sign-up",
+		Code:     codeBadContentType,
 	}
 
-	actResp := map[string]interface{}{}
+	actResp := &ErrorResponse{}
 
 	data, err := ioutil.ReadAll(res.Body)
 
 	require.NoError(t, err)
-	err = json.Unmarshal(data, &actResp)
+	require.Equal(t, "application/problem+json", res.Header.Get("Content-Type"))
+	require.NotEmpty(t, res.Header.Get("X-Request-ID"))
+	expResp.RequestID = res.Header.Get("X-Request-ID")
+	err = json.Unmarshal(data, actResp)
 	require.NoError(t, err)
-	require.True(t, reflect.DeepEqual(expResp, actResp))
+	require.Equal(t, expResp, actResp)
 
 	// usObj.AssertExpectations(t)
 }
@@ -154,11 +162,11 @@ func TestSignUpInput(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			usObj := new(mocks.UserService)
-			usObj.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+			usObj.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 			msObj := new(mocks.MailService)
-			msObj.On("SendConfirmationsEmail", mock.Anything).Return(nil)
+			msObj.On("SendConfirmationsEmail", mock.Anything, mock.Anything).Return(nil)
 
-			handler := New(usObj, msObj, nil, nil, nil, getTestLogger())
+			handler := New(usObj, msObj, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
 			r := handler.InitRoutes(gin.TestMode)
 			code, data := helpers.MakeRequest(
 				r,
@@ -172,12 +180,12 @@ func TestSignUpInput(t *testing.T) {
 			require.Equal(t, tc.code, code)
 
 			if tc.expArg != nil {
-				resp := BindDataError{}
+				resp := ErrorResponse{}
 				err := json.Unmarshal(data, &resp)
 
 				require.NoError(t, err)
 
-				require.True(t, reflect.DeepEqual(tc.expArg, &resp.InvalidArgs[0]))
+				require.True(t, reflect.DeepEqual(tc.expArg, &resp.InvalidParams[0]))
 			}
 
 			if tc.code == http.StatusOK {
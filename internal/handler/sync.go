@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSyncChanges godoc
+// @Summary Get changes for offline client
+// @Description Lists, items and members changed after since token, including deleted ones.
+// @Description Without token all lists and items of user are returned. Returned token is passed as since on the next sync
+// @Description Changes made concurrently with sync may be returned again by the next sync, so clients apply them idempotently
+// @Tags sync
+// @Produce  json
+// @ID get-sync-changes
+// @Security ApiKeyAuth
+// @Param since query string false "token from previous sync"
+// @Success 200 {object} SyncResponse
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/sync [get]
+func (h *Handler) getSyncChanges(c *gin.Context) {
+	result, err := h.SyncService.GetChanges(c.Request.Context(), c.GetInt64(idCtx), c.Query("since"))
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SyncResponse{"success", result})
+}
+
+// ApplySyncMutations godoc
+// @Summary Apply mutations of offline client
+// @Description Mutations are applied in order, each one gets applied, conflict or rejected status.
+// @Description Conflict contains current server version of list or item. Retried mutation with the same client_id is not applied twice
+// @Tags sync
+// @Accept  json
+// @Produce  json
+// @ID apply-sync-mutations
+// @Security ApiKeyAuth
+// @Param input body models.SyncReq true "mutations"
+// @Success 200 {object} SyncApplyResponse
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/sync [post]
+func (h *Handler) applySyncMutations(c *gin.Context) {
+	var req models.SyncReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	result, err := h.SyncService.Apply(c.Request.Context(), c.GetInt64(idCtx), req.Mutations)
+
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SyncApplyResponse{"success", result})
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSyncChanges(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	changes := &models.SyncChanges{
+		Lists:          []*models.List{},
+		Items:          []*models.Item{},
+		Members:        []*models.UsersList{},
+		DeletedLists:   []int64{3},
+		DeletedItems:   []int64{},
+		RemovedMembers: []*models.UsersList{},
+		Token:          "42",
+	}
+
+	tests := []struct {
+		name   string
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Bad token",
+			retErr: models.ErrBadSyncToken,
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadSyncToken.Error(),
+		},
+		{
+			name:   "GetChanges return unknown error",
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name: "Success sync",
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ss := new(mocks.SyncService)
+			ss.On("GetChanges", mock.Anything, int64(1), "10").Return(changes, tc.retErr)
+
+			handler := New(nil, nil, tsObj, nil, nil, nil, nil, nil, nil, nil, nil, ss, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/sync?since=10",
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := &SyncResponse{}
+				err := json.Unmarshal(data, resp)
+				require.NoError(t, err)
+				require.Equal(t, changes, resp.Result)
+			}
+		})
+	}
+}
+
+func TestApplySyncMutations(t *testing.T) {
+	headers := map[string]string{
+		"Authorization": "Bearer token",
+		"Content-Type":  "application/json",
+	}
+
+	results := []*models.SyncResult{
+		{ClientID: "c1", Status: models.SyncApplied, ListID: 5},
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		retErr error
+		code   int
+	}{
+		{
+			name: "No mutations",
+			body: `{"mutations":[]}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "Mutation without client id",
+			body: `{"mutations":[{"op":"list.create"}]}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name:   "Apply return unknown error",
+			body:   `{"mutations":[{"client_id":"c1","op":"list.create","title":"t","description":"d"}]}`,
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+		},
+		{
+			name: "Success apply",
+			body: `{"mutations":[{"client_id":"c1","op":"list.create","title":"t","description":"d"}]}`,
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ss := new(mocks.SyncService)
+			ss.On("Apply", mock.Anything, int64(1), mock.MatchedBy(func(m []*models.SyncMutation) bool {
+				return len(m) == 1 && m[0].ClientID == "c1" && *m[0].Title == "t"
+			})).Return(results, tc.retErr)
+
+			handler := New(nil, nil, tsObj, nil, nil, nil, nil, nil, nil, nil, nil, ss, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPost,
+				"/api/sync",
+				bytes.NewBuffer([]byte(tc.body)),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code == 200 {
+				resp := &SyncApplyResponse{}
+				err := json.Unmarshal(data, resp)
+				require.NoError(t, err)
+				require.Equal(t, results, resp.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timeoutMiddleware sets deadline of request context, so queries to postgres and redis
+// are cancelled when request takes longer than RequestTimeout. Zero timeout disables it
+func (h *Handler) timeoutMiddleware(c *gin.Context) {
+	if h.RequestTimeout <= 0 {
+		c.Next()
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.RequestTimeout)
+	defer cancel()
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
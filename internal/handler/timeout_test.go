@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		retErr      error
+		hasDeadline bool
+		code        int
+		errCode     string
+	}{
+		{
+			name:    "Timeout disabled",
+			timeout: 0,
+			code:    http.StatusOK,
+		},
+		{
+			name:        "Request with deadline",
+			timeout:     time.Minute,
+			hasDeadline: true,
+			code:        http.StatusOK,
+		},
+		{
+			name:        "Deadline exceeded",
+			timeout:     time.Minute,
+			retErr:      fmt.Errorf("select lists: %w", context.DeadlineExceeded),
+			hasDeadline: true,
+			code:        http.StatusServiceUnavailable,
+			errCode:     "request_timeout",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			var hasDeadline bool
+			ls := new(mocks.ListService)
+			ls.On("GetUserLists", mock.Anything, int64(1), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				_, hasDeadline = args.Get(0).(context.Context).Deadline()
+			}).Return([]*models.List{}, "", tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, getTestLogger())
+			handler.RequestTimeout = tc.timeout
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/lists",
+				bytes.NewBuffer([]byte{}),
+				map[string]string{"Authorization": "Bearer token"},
+			)
+
+			require.Equal(t, tc.code, code)
+			require.Equal(t, tc.hasDeadline, hasDeadline)
+			if tc.code != http.StatusOK {
+				errResp := &ErrorResponse{}
+				require.NoError(t, json.Unmarshal(data, errResp))
+				require.Equal(t, tc.errCode, errResp.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/VladimirStepanov/todo-app/internal/metrics"
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// serverName is http.server_name attribute of request spans
+const serverName = "todo-app"
+
+// tracingMiddleware starts server span of request, parent span is taken from
+// W3C traceparent header. Span is named by route template like metrics
+func tracingMiddleware(c *gin.Context) {
+	ctx := otel.GetTextMapPropagator().Extract(
+		c.Request.Context(), propagation.HeaderCarrier(c.Request.Header),
+	)
+
+	route := c.FullPath()
+	if route == "" {
+		route = metrics.UnmatchedRoute
+	}
+
+	ctx, span := tracing.Tracer().Start(
+		ctx, c.Request.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(serverName, route, c.Request)...),
+		trace.WithAttributes(attribute.String("request_id", logging.RequestID(ctx))),
+	)
+	defer span.End()
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+
+	status := c.Writer.Status()
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(status)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindServer))
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	tests := []struct {
+		name      string
+		retErr    error
+		code      int
+		spanCode  codes.Code
+		loggedErr bool
+	}{
+		{
+			name:     "Client error",
+			retErr:   models.ErrNoList,
+			code:     http.StatusNotFound,
+			spanCode: codes.Unset,
+		},
+		{
+			name:      "Internal error",
+			retErr:    ErrUnknown,
+			code:      http.StatusInternalServerError,
+			spanCode:  codes.Error,
+			loggedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, hook := test.NewNullLogger()
+
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("GetListByID", mock.Anything, int64(5), int64(1)).Return(nil, tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, logger)
+			r := handler.InitRoutes(gin.TestMode)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/lists/5", bytes.NewBuffer([]byte{}))
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, tc.code, w.Code)
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			require.Equal(t, "GET /api/lists/:list_id", span.Name())
+			require.Equal(t, traceID, span.SpanContext().TraceID().String())
+			require.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+			require.Equal(t, tc.spanCode, span.Status().Code)
+
+			if tc.loggedErr {
+				entry := hook.AllEntries()[0]
+				require.Equal(t, traceID, entry.Data["trace_id"])
+				require.Equal(t, span.SpanContext().SpanID().String(), entry.Data["span_id"])
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTrash godoc
+// @Summary Get deleted lists and items
+// @Description Lists are returned if current user is admin, items are returned from not deleted lists
+// @Tags trash
+// @Produce  json
+// @ID get-trash
+// @Security ApiKeyAuth
+// @Success 200 {object} TrashResponse "deleted lists and items"
+// @Failure 400 {object} ErrorResponse	"auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/me/trash [get]
+func (h *Handler) getTrash(c *gin.Context) {
+	trash, err := h.TrashService.GetTrash(c.Request.Context(), c.GetInt64(idCtx))
+
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TrashResponse{"success", trash})
+}
+
+// RestoreList godoc
+// @Summary Restore list from trash
+// @Description Only list admin can restore list
+// @Tags trash
+// @Produce  json
+// @ID restore-list
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list not found in trash"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/restore [post]
+func (h *Handler) restoreList(c *gin.Context) {
+	listID, err := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	err = h.TrashService.RestoreList(c.Request.Context(), listID, c.GetInt64(idCtx))
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// RestoreItem godoc
+// @Summary Restore item from trash
+// @Tags trash
+// @Produce  json
+// @ID restore-item
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param item_id path int true "item_id"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 404 {object} ErrorResponse "list not found, item not found in trash"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/items/{item_id}/restore [post]
+func (h *Handler) restoreItem(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	err = h.TrashService.RestoreItem(c.Request.Context(), listID, itemID, c.GetInt64(idCtx))
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrash(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name   string
+		retRes *models.Trash
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "GetTrash return unknown error",
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name: "Success get trash",
+			retRes: &models.Trash{
+				Lists: []*models.List{testList},
+				Items: []*models.Item{testItem},
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			trs := new(mocks.TrashService)
+			trs.On("GetTrash", mock.Anything, int64(1)).Return(tc.retRes, tc.retErr)
+
+			handler := New(nil, nil, tsObj, nil, nil, nil, nil, nil, trs, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/me/trash",
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := &TrashResponse{}
+				err := json.Unmarshal(data, resp)
+				require.NoError(t, err)
+				require.Equal(t, tc.retRes, resp.Result)
+			}
+		})
+	}
+}
+
+func TestRestoreList(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name     string
+		listID   string
+		adminErr error
+		retErr   error
+		code     int
+		errMsg   string
+	}{
+		{
+			name:   "Bad listID",
+			listID: "bad",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:     "List not found",
+			listID:   "1",
+			adminErr: models.ErrNoList,
+			code:     http.StatusNotFound,
+			errMsg:   models.ErrNoList.Error(),
+		},
+		{
+			name:     "User is not admin",
+			listID:   "1",
+			adminErr: models.ErrNoListAccess,
+			code:     http.StatusForbidden,
+			errMsg:   models.ErrNoListAccess.Error(),
+		},
+		{
+			name:   "Restore return ErrNoList",
+			listID: "1",
+			retErr: models.ErrNoList,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoList.Error(),
+		},
+		{
+			name:   "Restore return unknown error",
+			listID: "1",
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name:   "Success restore",
+			listID: "1",
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			trs := new(mocks.TrashService)
+			trs.On("IsDeletedListAdmin", mock.Anything, int64(1), int64(1)).Return(tc.adminErr)
+			trs.On("RestoreList", mock.Anything, int64(1), int64(1)).Return(tc.retErr)
+
+			handler := New(nil, nil, tsObj, nil, nil, nil, nil, nil, trs, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPost,
+				fmt.Sprintf("/api/lists/%s/restore", tc.listID),
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			}
+		})
+	}
+}
+
+func TestRestoreItem(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name   string
+		itemID string
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Bad itemID",
+			itemID: "bad",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:   "Item not found",
+			itemID: "1",
+			retErr: models.ErrNoItem,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoItem.Error(),
+		},
+		{
+			name:   "Success restore",
+			itemID: "1",
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListArchived", mock.Anything, mock.Anything).Return(false, nil)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			trs := new(mocks.TrashService)
+			trs.On("RestoreItem", mock.Anything, int64(1), int64(1), int64(1)).Return(tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, trs, nil, nil, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPost,
+				fmt.Sprintf("/api/lists/1/items/%s/restore", tc.itemID),
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			}
+		})
+	}
+}
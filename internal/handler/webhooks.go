@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookCreate godoc
+// @Summary Create list webhook
+// @Description Activity of the list is sent to url as POST with X-Todo-Signature header,
+// @Description it contains "sha256=" and hex HMAC-SHA256 of request body with webhook secret.
+// @Description Empty events means all actions
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @ID create-webhook
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param input body models.CreateWebhookReq true "webhook info"
+// @Success 200 {object} WebhookCreateResponse
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 409 {object} ErrorResponse "list is archived"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/webhooks [post]
+func (h *Handler) webhookCreate(c *gin.Context) {
+	var req models.CreateWebhookReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+
+	webhookID, err := h.WebhookService.Create(c.Request.Context(), listID, c.GetInt64(idCtx), &req)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookCreateResponse{"success", webhookID})
+}
+
+// GetWebhooks godoc
+// @Summary Get list webhooks
+// @Tags webhooks
+// @Produce  json
+// @ID get-webhooks
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Success 200 {object} ListWebhooksResponse
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/webhooks [get]
+func (h *Handler) getWebhooks(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+
+	result, err := h.WebhookService.GetWebhooks(c.Request.Context(), listID)
+
+	if err != nil {
+		h.InternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListWebhooksResponse{"success", result})
+}
+
+// UpdateWebhook godoc
+// @Summary Update list webhook
+// @Description Setting active to true enables webhook disabled after repeated failures
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @ID update-webhook
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param webhook_id path int true "webhook_id"
+// @Param input body models.UpdateWebhookReq true "input"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list or webhook not found"
+// @Failure 409 {object} ErrorResponse "list is archived"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/webhooks/{webhook_id} [patch]
+func (h *Handler) updateWebhook(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	webhookID, err := strconv.ParseInt(c.Param("webhook_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	var req models.UpdateWebhookReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	err = h.WebhookService.Update(c.Request.Context(), listID, webhookID, &req)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// DeleteWebhook godoc
+// @Summary Delete list webhook
+// @Tags webhooks
+// @Produce  json
+// @ID delete-webhook
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param webhook_id path int true "webhook_id"
+// @Success 200 {string} status	"success"
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list or webhook not found"
+// @Failure 409 {object} ErrorResponse "list is archived"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/webhooks/{webhook_id} [delete]
+func (h *Handler) deleteWebhook(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	webhookID, err := strconv.ParseInt(c.Param("webhook_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	err = h.WebhookService.Delete(c.Request.Context(), listID, webhookID)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get webhook delivery log
+// @Description Pending deliveries have next_attempt_at, failed ones ran out of attempts
+// @Tags webhooks
+// @Produce  json
+// @ID get-webhook-deliveries
+// @Security ApiKeyAuth
+// @Param list_id path int true "list_id"
+// @Param webhook_id path int true "webhook_id"
+// @Param limit query int false "page size, 20 by default" minimum(1) maximum(100)
+// @Param cursor query string false "next_cursor from previous page"
+// @Param order query string false "sort direction" Enums(asc, desc)
+// @Success 200 {object} WebhookDeliveriesResponse
+// @Failure 400 {object} ErrorResponse	"bad input, auth header errors"
+// @Failure 401 {object} ErrorResponse "user is not authorized"
+// @Failure 403 {object} ErrorResponse "current user is not admin"
+// @Failure 404 {object} ErrorResponse "list or webhook not found"
+// @Failure 500 {object} ErrorResponse "internal error"
+// @Router /api/lists/{list_id}/webhooks/{webhook_id}/deliveries [get]
+func (h *Handler) getWebhookDeliveries(c *gin.Context) {
+	listID, _ := strconv.ParseInt(c.Param("list_id"), 10, 64)
+	webhookID, err := strconv.ParseInt(c.Param("webhook_id"), 10, 64)
+
+	if err != nil {
+		h.Error(c, models.ErrBadParam)
+		return
+	}
+
+	page, ok := bindPage(c)
+	if !ok {
+		return
+	}
+
+	result, next, err := h.WebhookService.GetDeliveries(c.Request.Context(), listID, webhookID, page)
+
+	if err != nil {
+		h.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookDeliveriesResponse{"success", result, next})
+}
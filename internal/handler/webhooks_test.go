@@ -0,0 +1,398 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testWebhook = &models.Webhook{
+		ID:     1,
+		ListID: 1,
+		URL:    "https://example.com/hook",
+		Events: []string{models.ActionItemCreate},
+		Active: true,
+	}
+)
+
+func TestWebhookCreate(t *testing.T) {
+	headers := map[string]string{
+		"Authorization": "Bearer token",
+		"Content-Type":  "application/json",
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		isAdminErr error
+		archived   bool
+		retErr     error
+		code       int
+		errMsg     string
+	}{
+		{
+			name:       "Not admin",
+			body:       `{"url":"https://example.com/hook","secret":"s"}`,
+			isAdminErr: models.ErrNoListAccess,
+			code:       http.StatusForbidden,
+			errMsg:     models.ErrNoListAccess.Error(),
+		},
+		{
+			name:     "List is archived",
+			body:     `{"url":"https://example.com/hook","secret":"s"}`,
+			archived: true,
+			code:     http.StatusConflict,
+			errMsg:   models.ErrListArchived.Error(),
+		},
+		{
+			name:   "Bad url",
+			body:   `{"url":"example.com","secret":"s"}`,
+			retErr: models.ErrBadWebhookURL,
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadWebhookURL.Error(),
+		},
+		{
+			name:   "Unknown event",
+			body:   `{"url":"https://example.com/hook","secret":"s","events":["bad"]}`,
+			retErr: models.ErrBadWebhookEvent,
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadWebhookEvent.Error(),
+		},
+		{
+			name:   "Create return unknown error",
+			body:   `{"url":"https://example.com/hook","secret":"s"}`,
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name: "Success create",
+			body: `{"url":"https://example.com/hook","secret":"s"}`,
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(tc.isAdminErr)
+			ls.On("IsListArchived", mock.Anything, int64(1)).Return(tc.archived, nil)
+
+			ws := new(mocks.WebhookService)
+			ws.On("Create", mock.Anything, int64(1), int64(1), mock.Anything).Return(int64(5), tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, ws, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPost,
+				"/api/lists/1/webhooks",
+				bytes.NewBuffer([]byte(tc.body)),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				crResp := &WebhookCreateResponse{}
+				err := json.Unmarshal(data, crResp)
+				require.NoError(t, err)
+				require.Equal(t, int64(5), crResp.WebhookID)
+			}
+		})
+	}
+}
+
+func TestGetWebhooks(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name   string
+		retRes []*models.Webhook
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "GetWebhooks return unknown error",
+			retErr: ErrUnknown,
+			code:   http.StatusInternalServerError,
+			errMsg: "Internal server error",
+		},
+		{
+			name:   "Success get",
+			retRes: []*models.Webhook{testWebhook},
+			code:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			ws := new(mocks.WebhookService)
+			ws.On("GetWebhooks", mock.Anything, int64(1)).Return(tc.retRes, tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, ws, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/lists/1/webhooks",
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := &ListWebhooksResponse{}
+				err := json.Unmarshal(data, resp)
+				require.NoError(t, err)
+				require.Equal(t, tc.retRes, resp.Result)
+			}
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	headers := map[string]string{
+		"Authorization": "Bearer token",
+		"Content-Type":  "application/json",
+	}
+
+	tests := []struct {
+		name      string
+		webhookID string
+		retErr    error
+		code      int
+		errMsg    string
+	}{
+		{
+			name:      "Bad webhookID",
+			webhookID: "bad",
+			code:      http.StatusBadRequest,
+			errMsg:    models.ErrBadParam.Error(),
+		},
+		{
+			name:      "Bad url",
+			webhookID: "1",
+			retErr:    models.ErrBadWebhookURL,
+			code:      http.StatusBadRequest,
+			errMsg:    models.ErrBadWebhookURL.Error(),
+		},
+		{
+			name:      "Webhook not found",
+			webhookID: "1",
+			retErr:    models.ErrNoWebhook,
+			code:      http.StatusNotFound,
+			errMsg:    models.ErrNoWebhook.Error(),
+		},
+		{
+			name:      "Success update",
+			webhookID: "1",
+			code:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			ls.On("IsListArchived", mock.Anything, int64(1)).Return(false, nil)
+
+			ws := new(mocks.WebhookService)
+			ws.On("Update", mock.Anything, int64(1), int64(1), mock.Anything).Return(tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, ws, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodPatch,
+				fmt.Sprintf("/api/lists/1/webhooks/%s", tc.webhookID),
+				bytes.NewBuffer([]byte(`{"active":true}`)),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			}
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	tests := []struct {
+		name   string
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Webhook not found",
+			retErr: models.ErrNoWebhook,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoWebhook.Error(),
+		},
+		{
+			name: "Success delete",
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			ls.On("IsListArchived", mock.Anything, int64(1)).Return(false, nil)
+
+			ws := new(mocks.WebhookService)
+			ws.On("Delete", mock.Anything, int64(1), int64(2)).Return(tc.retErr)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, ws, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodDelete,
+				"/api/lists/1/webhooks/2",
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			}
+		})
+	}
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+
+	code := 200
+	deliveries := []*models.WebhookDelivery{
+		{ID: 1, WebhookID: 2, ActivityID: 3, Event: models.ActionItemCreate, Status: models.DeliverySuccess, Attempts: 1, ResponseCode: &code},
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		retErr error
+		code   int
+		errMsg string
+	}{
+		{
+			name:   "Bad limit",
+			query:  "?limit=bad",
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadParam.Error(),
+		},
+		{
+			name:   "Bad cursor",
+			query:  "?cursor=bad",
+			retErr: models.ErrBadCursor,
+			code:   http.StatusBadRequest,
+			errMsg: models.ErrBadCursor.Error(),
+		},
+		{
+			name:   "Webhook not found",
+			retErr: models.ErrNoWebhook,
+			code:   http.StatusNotFound,
+			errMsg: models.ErrNoWebhook.Error(),
+		},
+		{
+			name: "Success get",
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsObj := new(mocks.TokenService)
+			tsObj.On("Verify", mock.Anything, mock.Anything).Return(
+				int64(1), "aaa-aaa-aaa-aaa", nil,
+			)
+
+			ls := new(mocks.ListService)
+			ls.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			ws := new(mocks.WebhookService)
+			ws.On("GetDeliveries", mock.Anything, int64(1), int64(2), mock.Anything).Return(
+				deliveries, "next", tc.retErr,
+			)
+
+			handler := New(nil, nil, tsObj, ls, nil, nil, nil, nil, nil, nil, ws, nil, nil, nil, nil, getTestLogger())
+			r := handler.InitRoutes(gin.TestMode)
+			code, data := helpers.MakeRequest(
+				r,
+				t,
+				http.MethodGet,
+				"/api/lists/1/webhooks/2/deliveries"+tc.query,
+				bytes.NewBuffer([]byte{}),
+				headers,
+			)
+			require.Equal(t, tc.code, code)
+			if tc.code != 200 {
+				errResp := &ErrorResponse{}
+				err := json.Unmarshal(data, errResp)
+				require.NoError(t, err)
+				require.Equal(t, tc.errMsg, errResp.Detail)
+			} else {
+				resp := &WebhookDeliveriesResponse{}
+				err := json.Unmarshal(data, resp)
+				require.NoError(t, err)
+				require.Equal(t, deliveries, resp.Result)
+				require.Equal(t, "next", resp.NextCursor)
+			}
+		})
+	}
+}
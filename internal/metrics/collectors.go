@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	redisHits = prometheus.NewDesc(
+		namespace+"_redis_pool_hits_total", "Number of times free connection was found in the pool.", nil, nil,
+	)
+	redisMisses = prometheus.NewDesc(
+		namespace+"_redis_pool_misses_total", "Number of times free connection was not found in the pool.", nil, nil,
+	)
+	redisTimeouts = prometheus.NewDesc(
+		namespace+"_redis_pool_timeouts_total", "Number of times a wait timeout occurred.", nil, nil,
+	)
+	redisTotalConns = prometheus.NewDesc(
+		namespace+"_redis_pool_total_connections", "Number of total connections in the pool.", nil, nil,
+	)
+	redisIdleConns = prometheus.NewDesc(
+		namespace+"_redis_pool_idle_connections", "Number of idle connections in the pool.", nil, nil,
+	)
+	redisStaleConns = prometheus.NewDesc(
+		namespace+"_redis_pool_stale_connections_total", "Number of stale connections removed from the pool.", nil, nil,
+	)
+
+	activeSessions = prometheus.NewDesc(
+		namespace+"_active_sessions", "Number of sessions with valid refresh token.", nil, nil,
+	)
+)
+
+type redisPoolCollector struct {
+	client *redis.Client
+}
+
+func (rc *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- redisHits
+	ch <- redisMisses
+	ch <- redisTimeouts
+	ch <- redisTotalConns
+	ch <- redisIdleConns
+	ch <- redisStaleConns
+}
+
+func (rc *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := rc.client.PoolStats()
+
+	ch <- prometheus.MustNewConstMetric(redisHits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(redisMisses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(redisTimeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(redisTotalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(redisIdleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(redisStaleConns, prometheus.CounterValue, float64(stats.StaleConns))
+}
+
+type sessionsCollector struct {
+	count   func(ctx context.Context) (int, error)
+	timeout time.Duration
+}
+
+func (sc *sessionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeSessions
+}
+
+func (sc *sessionsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	count, err := sc.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(activeSessions, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(activeSessions, prometheus.GaugeValue, float64(count))
+}
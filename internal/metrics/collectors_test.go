@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionsCollector(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		countErr error
+		expErr   bool
+	}{
+		{
+			name:     "Count return error",
+			countErr: errors.New("unknown error"),
+			expErr:   true,
+		},
+		{
+			name:  "Success collect",
+			count: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := &sessionsCollector{
+				count: func(ctx context.Context) (int, error) {
+					_, ok := ctx.Deadline()
+					require.True(t, ok)
+					return tc.count, tc.countErr
+				},
+				timeout: time.Second,
+			}
+
+			err := testutil.CollectAndCompare(sc, strings.NewReader(`
+# HELP todo_active_sessions Number of sessions with valid refresh token.
+# TYPE todo_active_sessions gauge
+todo_active_sessions 3
+`))
+			require.Equal(t, tc.expErr, err != nil)
+		})
+	}
+}
+
+func TestRedisPoolCollector(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	defer client.Close()
+
+	require.Equal(t, 6, testutil.CollectAndCount(&redisPoolCollector{client: client}))
+}
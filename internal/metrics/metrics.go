@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "todo"
+
+// UnmatchedRoute is route label of requests which didn't match any route,
+// raw paths are not used as labels to keep cardinality bounded
+const UnmatchedRoute = "unmatched"
+
+// OtherMethod is method label of requests with non-standard method
+const OtherMethod = "other"
+
+// sign in results
+const (
+	SignInSuccess = "success"
+	SignInFailure = "failure"
+)
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of http requests by route template, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of http requests by route template, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "code"})
+
+	SignIns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ins_total",
+		Help:      "Number of sign in attempts by result.",
+	}, []string{"result"})
+
+	Activities = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "activities_total",
+		Help:      "Number of recorded changes of lists and items by action, e.g. list.create or item.create.",
+	}, []string{"action"})
+
+	EventPublishFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "event_publish_failures_total",
+		Help:      "Number of saved activities which were not published to subscribers.",
+	})
+)
+
+// RegisterDB registers collector of postgres connection pool stats
+func RegisterDB(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// RegisterRedis registers collector of redis connection pool stats
+func RegisterRedis(client *redis.Client) {
+	prometheus.MustRegister(&redisPoolCollector{client: client})
+}
+
+// RegisterSessions registers gauge of active sessions, count is called on every scrape
+func RegisterSessions(count func(ctx context.Context) (int, error), timeout time.Duration) {
+	prometheus.MustRegister(&sessionsCollector{count: count, timeout: timeout})
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	ActionListCreate    = "list.create"
+	ActionListUpdate    = "list.update"
+	ActionListDelete    = "list.delete"
+	ActionListRestore   = "list.restore"
+	ActionListArchive   = "list.archive"
+	ActionListUnarchive = "list.unarchive"
+	ActionItemCreate    = "item.create"
+	ActionItemUpdate    = "item.update"
+	ActionItemDone      = "item.done"
+	ActionItemDelete    = "item.delete"
+	ActionItemRestore   = "item.restore"
+	ActionMemberAdd     = "member.add"
+	ActionRoleChange    = "member.role"
+	ActionMemberRemove  = "member.remove"
+)
+
+var actions = map[string]bool{
+	ActionListCreate: true, ActionListUpdate: true, ActionListDelete: true,
+	ActionListRestore: true, ActionListArchive: true, ActionListUnarchive: true,
+	ActionItemCreate: true, ActionItemUpdate: true, ActionItemDone: true,
+	ActionItemDelete: true, ActionItemRestore: true,
+	ActionMemberAdd: true, ActionRoleChange: true, ActionMemberRemove: true,
+}
+
+// IsAction reports whether action is a known activity action
+func IsAction(action string) bool {
+	return actions[action]
+}
+
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Changes maps changed field to its values before and after mutation
+type Changes map[string]*FieldChange
+
+// Add adds field to changes if its value is changed
+func (c Changes) Add(field string, before, after interface{}) {
+	if before != after {
+		c[field] = &FieldChange{before, after}
+	}
+}
+
+func (c Changes) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+func (c *Changes) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(data, c)
+	case string:
+		return json.Unmarshal([]byte(data), c)
+	}
+	return errors.New("unsupported type of changes")
+}
+
+// Activity is a mutation of list made by user, MemberID is set for membership actions
+type Activity struct {
+	ID        int64     `json:"id" db:"id"`
+	ListID    int64     `json:"list_id" db:"list_id"`
+	ItemID    *int64    `json:"item_id" db:"item_id"`
+	UserID    *int64    `json:"user_id" db:"user_id"`
+	MemberID  *int64    `json:"member_id,omitempty" db:"member_id"`
+	Action    string    `json:"action" db:"action"`
+	Changes   Changes   `json:"changes,omitempty" db:"changes"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
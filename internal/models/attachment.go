@@ -0,0 +1,11 @@
+package models
+
+type Attachment struct {
+	ID          int64  `json:"id" db:"id"`
+	ItemID      int64  `json:"item_id" db:"item_id"`
+	UserID      int64  `json:"user_id" db:"user_id"`
+	FileName    string `json:"file_name" db:"file_name"`
+	ContentType string `json:"content_type" db:"content_type"`
+	Size        int64  `json:"size" db:"size"`
+	StorageKey  string `json:"-" db:"storage_key"`
+}
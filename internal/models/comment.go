@@ -0,0 +1,9 @@
+package models
+
+type Comment struct {
+	ID     int64  `json:"id" db:"id"`
+	ItemID int64  `json:"item_id" db:"item_id"`
+	UserID int64  `json:"user_id" db:"user_id"`
+	Author string `json:"author" db:"author"`
+	Body   string `json:"body" db:"body"`
+}
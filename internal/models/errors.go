@@ -20,4 +20,34 @@ var (
 	ErrUserNotFound         = errors.New("user not found")
 	ErrUpdateEmptyArgs      = errors.New("empty title and description")
 	ErrTitleTooShort        = errors.New("title too short. min length is 5")
+	ErrNoComment            = errors.New("comment not found")
+	ErrNoCommentAccess      = errors.New("no access to this comment")
+	ErrNoAttachment         = errors.New("attachment not found")
+	ErrNoAttachmentAccess   = errors.New("no access to this attachment")
+	ErrFileTooLarge         = errors.New("file is too large")
+	ErrFileTypeNotAllowed   = errors.New("file type is not allowed")
+	ErrNoBlob               = errors.New("blob not found")
+	ErrNoFile               = errors.New("no file in request")
+	ErrNotListMember        = errors.New("user is not a member of the list")
+	ErrEmptySearchQuery     = errors.New("search query is empty")
+	ErrBadSearchLanguage    = errors.New("unsupported search language")
+	ErrBadCursor            = errors.New("invalid cursor")
+	ErrBadSort              = errors.New("unsupported sort field")
+	ErrBadPageLimit         = errors.New("limit must be between 1 and 100")
+	ErrBadPriority          = errors.New("priority must be between 0 and 3")
+	ErrVersionMismatch      = errors.New("resource was modified, version does not match")
+	ErrListArchived         = errors.New("list is archived and read-only")
+	ErrNoWebhook            = errors.New("webhook not found")
+	ErrBadWebhookURL        = errors.New("webhook url must be absolute http or https url")
+	ErrBadWebhookEvent      = errors.New("unknown webhook event")
+	ErrBadSyncToken         = errors.New("invalid sync token")
+	ErrBadSyncOp            = errors.New("unknown sync operation")
+	ErrNoSyncTitle          = errors.New("title and description are required")
+	ErrNoSyncMutation       = errors.New("sync mutation not found")
+	ErrSyncMutationApplied  = errors.New("sync mutation is already applied")
+	ErrBadIdempotencyKey    = errors.New("idempotency key must be 1 to 255 characters")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used with another request")
+	ErrIdempotencyInFlight  = errors.New("request with this idempotency key is in progress")
+	ErrRateLimited          = errors.New("too many requests, retry later")
+	ErrRequestTimeout       = errors.New("request timed out")
 )
@@ -0,0 +1,19 @@
+package models
+
+// statuses of service and its dependencies
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+type HealthCheck struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	// Err is logged, but not shown to clients
+	Err error `json:"-"`
+}
+
+type HealthReport struct {
+	Status string                  `json:"status"`
+	Checks map[string]*HealthCheck `json:"checks"`
+}
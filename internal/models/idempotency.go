@@ -0,0 +1,12 @@
+package models
+
+// IdempotentResponse is a response stored for Idempotency-Key and replayed on retries
+type IdempotentResponse struct {
+	// hash of request method, uri and body, retry with the same key must match it
+	Fingerprint string `json:"fingerprint"`
+	// false while the first request is processed
+	Done   bool              `json:"done"`
+	Code   int               `json:"code"`
+	Header map[string]string `json:"header"`
+	Body   []byte            `json:"body"`
+}
@@ -1,70 +1,253 @@
 package models
 
-import "time"
+import (
+	"context"
+	"io"
+	"time"
+)
 
 type UserService interface {
-	Create(Email, Password string) (*User, error)
-	ConfirmEmail(Link string) error
-	SignIn(Email, Password string) (*User, error)
+	Create(ctx context.Context, Email, Password string) (*User, error)
+	ConfirmEmail(ctx context.Context, Link string) error
+	SignIn(ctx context.Context, Email, Password string) (*User, error)
 }
 
 type UserRepository interface {
-	Create(user *User) (*User, error)
-	ConfirmEmail(Link string) error
-	FindUserByEmail(Email string) (*User, error)
+	Create(ctx context.Context, user *User) (*User, error)
+	ConfirmEmail(ctx context.Context, Link string) error
+	FindUserByEmail(ctx context.Context, Email string) (*User, error)
 }
 
 type MailService interface {
-	SendConfirmationsEmail(user *User) error
+	SendConfirmationsEmail(ctx context.Context, user *User) error
+	SendMentionEmail(ctx context.Context, user *User, listID, itemID int64, body string) error
+	SendAssignEmail(ctx context.Context, user *User, item *Item) error
 }
 
 type TokenService interface {
-	NewTokenPair(userID int64) (*TokenDetails, error)
-	Refresh(refreshToken string) (*TokenDetails, error)
-	Verify(token string) (int64, string, error)
-	Logout(userID int64, userUUID string) error
+	NewTokenPair(ctx context.Context, userID int64) (*TokenDetails, error)
+	Refresh(ctx context.Context, refreshToken string) (*TokenDetails, error)
+	Verify(ctx context.Context, token string) (int64, string, error)
+	// ExpiresAt returns expiration time of access token
+	ExpiresAt(token string) (time.Time, error)
+	Logout(ctx context.Context, userID int64, userUUID string) error
+	// ActiveSessions returns number of sessions with not expired refresh token
+	ActiveSessions(ctx context.Context) (int, error)
 }
 
 type TokenRepository interface {
-	Get(key string) (bool, error)
-	SetTokens(accessKey string, accessExp time.Duration, refreshKey string, refreshExp time.Duration) error
-	Count(pattern string) (int, error)
-	Delete(keys ...string) error
+	Get(ctx context.Context, key string) (bool, error)
+	SetTokens(ctx context.Context, accessKey string, accessExp time.Duration, refreshKey string, refreshExp time.Duration) error
+	Count(ctx context.Context, pattern string) (int, error)
+	Delete(ctx context.Context, keys ...string) error
 }
 
 type ListService interface {
-	Create(title, description string, userID int64) (int64, error)
-	EditRole(listID, userID int64, role bool) error
-	GetListByID(listID, userID int64) (*List, error)
-	GetUserLists(userID int64) ([]*List, error)
-	Delete(listID int64) error
-	Update(listID int64, list *UpdateListReq) error
-	IsListAdmin(ListID, userID int64) error
+	Create(ctx context.Context, title, description string, userID int64) (int64, error)
+	EditRole(ctx context.Context, listID, adminID, userID int64, role bool) error
+	GetListByID(ctx context.Context, listID, userID int64) (*List, error)
+	GetUserLists(ctx context.Context, userID int64, filter *ListFilter, page *PageReq) ([]*List, string, error)
+	Delete(ctx context.Context, listID, userID int64, version *int64) error
+	Update(ctx context.Context, listID, userID int64, list *UpdateListReq) error
+	IsListAdmin(ctx context.Context, ListID, userID int64) error
+	RemoveMember(ctx context.Context, listID, adminID, userID int64) error
+	GetActivity(ctx context.Context, listID int64, page *PageReq) ([]*Activity, string, error)
+	SetArchived(ctx context.Context, listID, userID int64, archived bool) error
+	IsListArchived(ctx context.Context, listID int64) (bool, error)
 }
 
 type ListRepository interface {
-	Create(title, description string, userID int64) (int64, error)
-	EditRole(listID, userID int64, role bool) error
-	GetListByID(listID, userID int64) (*List, error)
-	GetUserLists(userID int64) ([]*List, error)
-	Delete(listID int64) error
-	Update(listID int64, list *UpdateListReq) error
-	IsListAdmin(ListID, userID int64) error
+	Create(ctx context.Context, title, description string, userID int64) (int64, error)
+	EditRole(ctx context.Context, listID, userID int64, role bool) error
+	GetListByID(ctx context.Context, listID, userID int64) (*List, error)
+	// GetListForUpdate returns list and locks it until end of transaction of ctx
+	GetListForUpdate(ctx context.Context, listID, userID int64) (*List, error)
+	GetUserLists(ctx context.Context, userID int64, filter *ListFilter, page *PageReq) ([]*List, string, error)
+	Delete(ctx context.Context, listID int64, version *int64) error
+	Update(ctx context.Context, listID, userID int64, list *UpdateListReq) error
+	IsListAdmin(ctx context.Context, ListID, userID int64) error
+	// IsDeletedListAdmin is IsListAdmin for list in trash
+	IsDeletedListAdmin(ctx context.Context, listID, userID int64) error
+	GetMembersByEmails(ctx context.Context, listID int64, emails []string) ([]*User, error)
+	GetMember(ctx context.Context, listID, userID int64) (*User, error)
+	RemoveMember(ctx context.Context, listID, userID int64) error
+	// UnassignMember removes member from assignees of list items and returns ids of the items
+	UnassignMember(ctx context.Context, listID, userID, updatedBy int64) ([]int64, error)
+	GetDeletedLists(ctx context.Context, userID int64) ([]*List, error)
+	Restore(ctx context.Context, listID, userID int64) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	SetArchived(ctx context.Context, listID, userID int64, archived bool) error
+	IsListArchived(ctx context.Context, listID int64) (bool, error)
 }
 
 type ItemService interface {
-	Create(title, description string, listID int64) (int64, error)
-	GetItems(listID int64) ([]*Item, error)
-	GetItemByID(listID, itemID int64) (*Item, error)
-	Update(listID, itemID int64, item *UpdateItemReq) error
-	Done(listID, itemID int64) error
-	Delete(listID, itemID int64) error
+	Create(ctx context.Context, listID, userID int64, item *CreateItemReq) (int64, error)
+	GetItems(ctx context.Context, listID int64, filter *ItemFilter, page *PageReq) ([]*Item, string, error)
+	GetUserItems(ctx context.Context, userID int64, onlyAssigned bool) ([]*Item, error)
+	GetItemByID(ctx context.Context, listID, itemID int64) (*Item, error)
+	Update(ctx context.Context, listID, itemID, userID int64, item *UpdateItemReq) error
+	Done(ctx context.Context, listID, itemID, userID int64, version *int64) error
+	Delete(ctx context.Context, listID, itemID, userID int64, version *int64) error
 }
 
 type ItemRepository interface {
-	Create(title, description string, listID int64) (int64, error)
-	GetItems(listID int64) ([]*Item, error)
-	GetItemByID(listID, itemID int64) (*Item, error)
-	Update(listID, itemID int64, item *UpdateItemReq) error
-	Delete(listID, itemID int64) error
+	Create(ctx context.Context, listID, userID int64, item *CreateItemReq) (int64, error)
+	GetItems(ctx context.Context, listID int64, filter *ItemFilter, page *PageReq) ([]*Item, string, error)
+	GetUserItems(ctx context.Context, userID int64, onlyAssigned bool) ([]*Item, error)
+	GetItemByID(ctx context.Context, listID, itemID int64) (*Item, error)
+	// GetItemForUpdate returns item and locks it until end of transaction of ctx
+	GetItemForUpdate(ctx context.Context, listID, itemID int64) (*Item, error)
+	Update(ctx context.Context, listID, itemID, userID int64, item *UpdateItemReq) error
+	Delete(ctx context.Context, listID, itemID int64, version *int64) error
+	GetDeletedItems(ctx context.Context, userID int64) ([]*Item, error)
+	Restore(ctx context.Context, listID, itemID, userID int64) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type TrashService interface {
+	GetTrash(ctx context.Context, userID int64) (*Trash, error)
+	IsDeletedListAdmin(ctx context.Context, listID, userID int64) error
+	RestoreList(ctx context.Context, listID, userID int64) error
+	RestoreItem(ctx context.Context, listID, itemID, userID int64) error
+	Purge(ctx context.Context) error
+}
+
+type CommentService interface {
+	Create(ctx context.Context, listID, itemID, userID int64, body string) (int64, error)
+	GetComments(ctx context.Context, listID, itemID int64) ([]*Comment, error)
+	Update(ctx context.Context, listID, itemID, commentID, userID int64, body string) error
+	Delete(ctx context.Context, listID, itemID, commentID, userID int64, isAdmin bool) error
+}
+
+type CommentRepository interface {
+	Create(ctx context.Context, itemID, userID int64, body string) (int64, error)
+	GetComments(ctx context.Context, itemID int64) ([]*Comment, error)
+	GetCommentByID(ctx context.Context, itemID, commentID int64) (*Comment, error)
+	Update(ctx context.Context, commentID int64, body string) error
+	Delete(ctx context.Context, commentID int64) error
+}
+
+type AttachmentService interface {
+	Create(ctx context.Context, listID, itemID, userID int64, fileName string, size int64, r io.Reader) (int64, error)
+	GetAttachments(ctx context.Context, listID, itemID int64) ([]*Attachment, error)
+	Download(ctx context.Context, listID, itemID, attachmentID int64) (*Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, listID, itemID, attachmentID, userID int64, isAdmin bool) error
+	Cleanup(ctx context.Context) error
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *Attachment) (int64, error)
+	GetAttachments(ctx context.Context, itemID int64) ([]*Attachment, error)
+	GetAttachmentByID(ctx context.Context, itemID, attachmentID int64) (*Attachment, error)
+	Delete(ctx context.Context, attachmentID int64) error
+	GetDeletedKeys(ctx context.Context, limit int) ([]string, error)
+	RemoveDeletedKeys(ctx context.Context, keys []string) error
+}
+
+// Transactor runs functions in database transaction. Repositories called with ctx of fn
+// use the transaction, InTx called inside of fn joins it
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit runs fn after commit of transaction of ctx, at once without transaction.
+	// fn is not run if transaction is rolled back
+	AfterCommit(ctx context.Context, fn func())
+}
+
+type ActivityRepository interface {
+	Create(ctx context.Context, activity *Activity) error
+	GetActivity(ctx context.Context, listID int64, page *PageReq) ([]*Activity, string, error)
+}
+
+// EventBus delivers list activity to subscribers on all server replicas.
+// Subscription channel is closed when ctx is done
+type EventBus interface {
+	Publish(ctx context.Context, activity *Activity) error
+	Subscribe(ctx context.Context, listID int64) (<-chan *Activity, error)
+}
+
+type WebhookService interface {
+	Create(ctx context.Context, listID, userID int64, req *CreateWebhookReq) (int64, error)
+	GetWebhooks(ctx context.Context, listID int64) ([]*Webhook, error)
+	Update(ctx context.Context, listID, webhookID int64, req *UpdateWebhookReq) error
+	Delete(ctx context.Context, listID, webhookID int64) error
+	GetDeliveries(ctx context.Context, listID, webhookID int64, page *PageReq) ([]*WebhookDelivery, string, error)
+	Deliver(ctx context.Context) error
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *Webhook) (int64, error)
+	GetWebhooks(ctx context.Context, listID int64) ([]*Webhook, error)
+	GetWebhookByID(ctx context.Context, listID, webhookID int64) (*Webhook, error)
+	Update(ctx context.Context, listID, webhookID int64, req *UpdateWebhookReq) error
+	Delete(ctx context.Context, listID, webhookID int64) error
+	GetDeliveries(ctx context.Context, webhookID int64, page *PageReq) ([]*WebhookDelivery, string, error)
+	// ClaimDeliveries returns due pending deliveries of active webhooks,
+	// other workers don't get them until lease expires
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookJob, error)
+	SaveResult(ctx context.Context, result *DeliveryResult, maxFailures int) error
+}
+
+type SyncService interface {
+	GetChanges(ctx context.Context, userID int64, since string) (*SyncChanges, error)
+	Apply(ctx context.Context, userID int64, mutations []*SyncMutation) ([]*SyncResult, error)
+}
+
+type SyncRepository interface {
+	// GetChanges returns changes made since token and token of the next call
+	GetChanges(ctx context.Context, userID, since int64) (*SyncChanges, int64, error)
+	GetMutation(ctx context.Context, userID int64, clientID string) (*SyncResult, error)
+	// SaveMutation returns ErrSyncMutationApplied if mutation with the client id is saved
+	SaveMutation(ctx context.Context, userID int64, result *SyncResult) error
+}
+
+type IdempotencyService interface {
+	// Begin returns stored response to replay or nil if request has to be processed
+	Begin(ctx context.Context, userID int64, key, fingerprint string) (*IdempotentResponse, error)
+	Complete(ctx context.Context, userID int64, key string, resp *IdempotentResponse) error
+	// Abort releases key of failed request, so it can be retried
+	Abort(ctx context.Context, userID int64, key string) error
+}
+
+type IdempotencyRepository interface {
+	// Reserve stores resp if key is free, otherwise returns response stored earlier
+	Reserve(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) (*IdempotentResponse, error)
+	Save(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type RateLimitService interface {
+	// Allow counts request of subject in route group, nil result means group is not limited
+	Allow(ctx context.Context, group, subject string) (*RateLimitResult, error)
+}
+
+type RateLimitRepository interface {
+	// Allow adds request with unique member to window of key if limit is not exceeded
+	Allow(ctx context.Context, key string, limit RateLimit, now time.Time, member string) (*RateLimitResult, error)
+}
+
+type HealthService interface {
+	// Check pings all dependencies, report is up only if all of them are up
+	Check(ctx context.Context) *HealthReport
+}
+
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+}
+
+type EventService interface {
+	Subscribe(ctx context.Context, listID int64) (<-chan *Activity, error)
+}
+
+type SearchService interface {
+	Search(ctx context.Context, userID int64, query, lang string) ([]*SearchResult, error)
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, userID int64, query, lang string, limit int) ([]*SearchResult, error)
+}
+
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, r io.Reader, size int64) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
 }
@@ -1,15 +1,54 @@
 package models
 
+import "time"
+
+const (
+	PriorityNone = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
 type Item struct {
-	ID          int64  `json:"id" db:"id"`
-	ListID      int64  `json:"list_id" db:"list_id"`
-	Title       string `json:"title" db:"title"`
-	Description string `json:"description" db:"description"`
-	Done        bool   `json:"done"  db:"done"`
+	ID            int64      `json:"id" db:"id"`
+	ListID        int64      `json:"list_id" db:"list_id"`
+	Title         string     `json:"title" db:"title"`
+	Description   string     `json:"description" db:"description"`
+	Done          bool       `json:"done"  db:"done"`
+	AssigneeID    *int64     `json:"assignee_id" db:"assignee_id"`
+	DueAt         *time.Time `json:"due_at" db:"due_at"`
+	Priority      int        `json:"priority" db:"priority"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	CreatedBy     *int64     `json:"created_by" db:"created_by"`
+	UpdatedBy     *int64     `json:"updated_by" db:"updated_by"`
+	Version       int64      `json:"version" db:"version"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CommentsCount int64      `json:"comments_count" db:"comments_count"`
+}
+
+type CreateItemReq struct {
+	Title       string     `json:"title" binding:"required,gte=1,lte=255"`
+	Description string     `json:"description" binding:"required"`
+	AssigneeID  *int64     `json:"assignee_id"`
+	DueAt       *time.Time `json:"due_at"`
+	// 0 - none, 1 - low, 2 - medium, 3 - high
+	Priority int `json:"priority"`
 }
 
 type UpdateItemReq struct {
 	Title       *string `json:"title"`
 	Description *string `json:"description"`
-	Done        *bool   `json:"-"`
+	// 0 removes current assignee
+	AssigneeID *int64 `json:"assignee_id"`
+	// zero time removes current due date
+	DueAt    *time.Time `json:"due_at"`
+	Priority *int       `json:"priority"`
+	Done     *bool      `json:"-"`
+	// expected version from If-Match header, nil if any version matches
+	Version *int64 `json:"-"`
+}
+
+type ItemFilter struct {
+	Done *bool
 }
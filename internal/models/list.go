@@ -1,18 +1,34 @@
 package models
 
+import "time"
+
 type List struct {
-	ID          int64  `json:"list_id" db:"id"`
-	Title       string `json:"title" db:"title"`
-	Description string `json:"description" db:"description"`
+	ID          int64      `json:"list_id" db:"id"`
+	Title       string     `json:"title" db:"title"`
+	Description string     `json:"description" db:"description"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	CreatedBy   *int64     `json:"created_by" db:"created_by"`
+	UpdatedBy   *int64     `json:"updated_by" db:"updated_by"`
+	Version     int64      `json:"version" db:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ArchivedAt  *time.Time `json:"archived_at" db:"archived_at"`
 }
 
 type UpdateListReq struct {
 	Title       *string `json:"title"`
 	Description *string `json:"description"`
+	// expected version from If-Match header, nil if any version matches
+	Version *int64 `json:"-"`
+}
+
+type ListFilter struct {
+	// Archived selects archived lists instead of active ones
+	Archived bool
 }
 
 type UsersList struct {
-	UserID  int64 `db:"user_id"`
-	ListID  int64 `db:"list_id"`
-	IsAdmin bool  `db:"is_admin"`
+	UserID  int64 `json:"user_id" db:"user_id"`
+	ListID  int64 `json:"list_id" db:"list_id"`
+	IsAdmin bool  `json:"is_admin" db:"is_admin"`
 }
@@ -0,0 +1,59 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ActivityRepository is an autogenerated mock type for the ActivityRepository type
+type ActivityRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, activity
+func (_m *ActivityRepository) Create(ctx context.Context, activity *models.Activity) error {
+	ret := _m.Called(ctx, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Activity) error); ok {
+		r0 = rf(ctx, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActivity provides a mock function with given fields: ctx, listID, page
+func (_m *ActivityRepository) GetActivity(ctx context.Context, listID int64, page *models.PageReq) ([]*models.Activity, string, error) {
+	ret := _m.Called(ctx, listID, page)
+
+	var r0 []*models.Activity
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PageReq) []*models.Activity); ok {
+		r0 = rf(ctx, listID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Activity)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.PageReq) string); ok {
+		r1 = rf(ctx, listID, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *models.PageReq) error); ok {
+		r2 = rf(ctx, listID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
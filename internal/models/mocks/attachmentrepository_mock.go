@@ -0,0 +1,133 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttachmentRepository is an autogenerated mock type for the AttachmentRepository type
+type AttachmentRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, attachment
+func (_m *AttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) (int64, error) {
+	ret := _m.Called(ctx, attachment)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Attachment) int64); ok {
+		r0 = rf(ctx, attachment)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Attachment) error); ok {
+		r1 = rf(ctx, attachment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, attachmentID
+func (_m *AttachmentRepository) Delete(ctx context.Context, attachmentID int64) error {
+	ret := _m.Called(ctx, attachmentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAttachmentByID provides a mock function with given fields: ctx, itemID, attachmentID
+func (_m *AttachmentRepository) GetAttachmentByID(ctx context.Context, itemID int64, attachmentID int64) (*models.Attachment, error) {
+	ret := _m.Called(ctx, itemID, attachmentID)
+
+	var r0 *models.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Attachment); ok {
+		r0 = rf(ctx, itemID, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, itemID, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAttachments provides a mock function with given fields: ctx, itemID
+func (_m *AttachmentRepository) GetAttachments(ctx context.Context, itemID int64) ([]*models.Attachment, error) {
+	ret := _m.Called(ctx, itemID)
+
+	var r0 []*models.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*models.Attachment); ok {
+		r0 = rf(ctx, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeletedKeys provides a mock function with given fields: ctx, limit
+func (_m *AttachmentRepository) GetDeletedKeys(ctx context.Context, limit int) ([]string, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDeletedKeys provides a mock function with given fields: ctx, keys
+func (_m *AttachmentRepository) RemoveDeletedKeys(ctx context.Context, keys []string) error {
+	ret := _m.Called(ctx, keys)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
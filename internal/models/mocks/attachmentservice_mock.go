@@ -0,0 +1,121 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+// AttachmentService is an autogenerated mock type for the AttachmentService type
+type AttachmentService struct {
+	mock.Mock
+}
+
+// Cleanup provides a mock function with given fields: ctx
+func (_m *AttachmentService) Cleanup(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, listID, itemID, userID, fileName, size, r
+func (_m *AttachmentService) Create(ctx context.Context, listID int64, itemID int64, userID int64, fileName string, size int64, r io.Reader) (int64, error) {
+	ret := _m.Called(ctx, listID, itemID, userID, fileName, size, r)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, string, int64, io.Reader) int64); ok {
+		r0 = rf(ctx, listID, itemID, userID, fileName, size, r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64, string, int64, io.Reader) error); ok {
+		r1 = rf(ctx, listID, itemID, userID, fileName, size, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, listID, itemID, attachmentID, userID, isAdmin
+func (_m *AttachmentService) Delete(ctx context.Context, listID int64, itemID int64, attachmentID int64, userID int64, isAdmin bool) error {
+	ret := _m.Called(ctx, listID, itemID, attachmentID, userID, isAdmin)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, int64, bool) error); ok {
+		r0 = rf(ctx, listID, itemID, attachmentID, userID, isAdmin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Download provides a mock function with given fields: ctx, listID, itemID, attachmentID
+func (_m *AttachmentService) Download(ctx context.Context, listID int64, itemID int64, attachmentID int64) (*models.Attachment, io.ReadCloser, error) {
+	ret := _m.Called(ctx, listID, itemID, attachmentID)
+
+	var r0 *models.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) *models.Attachment); ok {
+		r0 = rf(ctx, listID, itemID, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Attachment)
+		}
+	}
+
+	var r1 io.ReadCloser
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) io.ReadCloser); ok {
+		r1 = rf(ctx, listID, itemID, attachmentID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64, int64) error); ok {
+		r2 = rf(ctx, listID, itemID, attachmentID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAttachments provides a mock function with given fields: ctx, listID, itemID
+func (_m *AttachmentService) GetAttachments(ctx context.Context, listID int64, itemID int64) ([]*models.Attachment, error) {
+	ret := _m.Called(ctx, listID, itemID)
+
+	var r0 []*models.Attachment
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []*models.Attachment); ok {
+		r0 = rf(ctx, listID, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
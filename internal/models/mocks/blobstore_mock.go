@@ -0,0 +1,66 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlobStore is an autogenerated mock type for the BlobStore type
+type BlobStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *BlobStore) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Put provides a mock function with given fields: ctx, key, contentType, r, size
+func (_m *BlobStore) Put(ctx context.Context, key string, contentType string, r io.Reader, size int64) error {
+	ret := _m.Called(ctx, key, contentType, r, size)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader, int64) error); ok {
+		r0 = rf(ctx, key, contentType, r, size)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CommentRepository is an autogenerated mock type for the CommentRepository type
+type CommentRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, itemID, userID, body
+func (_m *CommentRepository) Create(ctx context.Context, itemID int64, userID int64, body string) (int64, error) {
+	ret := _m.Called(ctx, itemID, userID, body)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) int64); ok {
+		r0 = rf(ctx, itemID, userID, body)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string) error); ok {
+		r1 = rf(ctx, itemID, userID, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, commentID
+func (_m *CommentRepository) Delete(ctx context.Context, commentID int64) error {
+	ret := _m.Called(ctx, commentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCommentByID provides a mock function with given fields: ctx, itemID, commentID
+func (_m *CommentRepository) GetCommentByID(ctx context.Context, itemID int64, commentID int64) (*models.Comment, error) {
+	ret := _m.Called(ctx, itemID, commentID)
+
+	var r0 *models.Comment
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Comment); ok {
+		r0 = rf(ctx, itemID, commentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Comment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, itemID, commentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetComments provides a mock function with given fields: ctx, itemID
+func (_m *CommentRepository) GetComments(ctx context.Context, itemID int64) ([]*models.Comment, error) {
+	ret := _m.Called(ctx, itemID)
+
+	var r0 []*models.Comment
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*models.Comment); ok {
+		r0 = rf(ctx, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Comment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, commentID, body
+func (_m *CommentRepository) Update(ctx context.Context, commentID int64, body string) error {
+	ret := _m.Called(ctx, commentID, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, commentID, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CommentService is an autogenerated mock type for the CommentService type
+type CommentService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, listID, itemID, userID, body
+func (_m *CommentService) Create(ctx context.Context, listID int64, itemID int64, userID int64, body string) (int64, error) {
+	ret := _m.Called(ctx, listID, itemID, userID, body)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, string) int64); ok {
+		r0 = rf(ctx, listID, itemID, userID, body)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64, string) error); ok {
+		r1 = rf(ctx, listID, itemID, userID, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, listID, itemID, commentID, userID, isAdmin
+func (_m *CommentService) Delete(ctx context.Context, listID int64, itemID int64, commentID int64, userID int64, isAdmin bool) error {
+	ret := _m.Called(ctx, listID, itemID, commentID, userID, isAdmin)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, int64, bool) error); ok {
+		r0 = rf(ctx, listID, itemID, commentID, userID, isAdmin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetComments provides a mock function with given fields: ctx, listID, itemID
+func (_m *CommentService) GetComments(ctx context.Context, listID int64, itemID int64) ([]*models.Comment, error) {
+	ret := _m.Called(ctx, listID, itemID)
+
+	var r0 []*models.Comment
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []*models.Comment); ok {
+		r0 = rf(ctx, listID, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Comment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, listID, itemID, commentID, userID, body
+func (_m *CommentService) Update(ctx context.Context, listID int64, itemID int64, commentID int64, userID int64, body string) error {
+	ret := _m.Called(ctx, listID, itemID, commentID, userID, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, int64, string) error); ok {
+		r0 = rf(ctx, listID, itemID, commentID, userID, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
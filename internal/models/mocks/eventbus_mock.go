@@ -0,0 +1,52 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventBus is an autogenerated mock type for the EventBus type
+type EventBus struct {
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: ctx, activity
+func (_m *EventBus) Publish(ctx context.Context, activity *models.Activity) error {
+	ret := _m.Called(ctx, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Activity) error); ok {
+		r0 = rf(ctx, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: ctx, listID
+func (_m *EventBus) Subscribe(ctx context.Context, listID int64) (<-chan *models.Activity, error) {
+	ret := _m.Called(ctx, listID)
+
+	var r0 <-chan *models.Activity
+	if rf, ok := ret.Get(0).(func(context.Context, int64) <-chan *models.Activity); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *models.Activity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, listID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,31 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthService is an autogenerated mock type for the HealthService type
+type HealthService struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx
+func (_m *HealthService) Check(ctx context.Context) *models.HealthReport {
+	ret := _m.Called(ctx)
+
+	var r0 *models.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context) *models.HealthReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.HealthReport)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,68 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *IdempotencyRepository) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: ctx, key, resp, ttl
+func (_m *IdempotencyRepository) Reserve(ctx context.Context, key string, resp *models.IdempotentResponse, ttl time.Duration) (*models.IdempotentResponse, error) {
+	ret := _m.Called(ctx, key, resp, ttl)
+
+	var r0 *models.IdempotentResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.IdempotentResponse, time.Duration) *models.IdempotentResponse); ok {
+		r0 = rf(ctx, key, resp, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotentResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.IdempotentResponse, time.Duration) error); ok {
+		r1 = rf(ctx, key, resp, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, key, resp, ttl
+func (_m *IdempotencyRepository) Save(ctx context.Context, key string, resp *models.IdempotentResponse, ttl time.Duration) error {
+	ret := _m.Called(ctx, key, resp, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.IdempotentResponse, time.Duration) error); ok {
+		r0 = rf(ctx, key, resp, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,66 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyService is an autogenerated mock type for the IdempotencyService type
+type IdempotencyService struct {
+	mock.Mock
+}
+
+// Abort provides a mock function with given fields: ctx, userID, key
+func (_m *IdempotencyService) Abort(ctx context.Context, userID int64, key string) error {
+	ret := _m.Called(ctx, userID, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Begin provides a mock function with given fields: ctx, userID, key, fingerprint
+func (_m *IdempotencyService) Begin(ctx context.Context, userID int64, key string, fingerprint string) (*models.IdempotentResponse, error) {
+	ret := _m.Called(ctx, userID, key, fingerprint)
+
+	var r0 *models.IdempotentResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) *models.IdempotentResponse); ok {
+		r0 = rf(ctx, userID, key, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotentResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string) error); ok {
+		r1 = rf(ctx, userID, key, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Complete provides a mock function with given fields: ctx, userID, key, resp
+func (_m *IdempotencyService) Complete(ctx context.Context, userID int64, key string, resp *models.IdempotentResponse) error {
+	ret := _m.Called(ctx, userID, key, resp)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *models.IdempotentResponse) error); ok {
+		r0 = rf(ctx, userID, key, resp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
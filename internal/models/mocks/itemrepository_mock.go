@@ -3,8 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/VladimirStepanov/todo-app/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ItemRepository is an autogenerated mock type for the ItemRepository type
@@ -12,20 +16,20 @@ type ItemRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: title, description, listID
-func (_m *ItemRepository) Create(title string, description string, listID int64) (int64, error) {
-	ret := _m.Called(title, description, listID)
+// Create provides a mock function with given fields: ctx, listID, userID, item
+func (_m *ItemRepository) Create(ctx context.Context, listID int64, userID int64, item *models.CreateItemReq) (int64, error) {
+	ret := _m.Called(ctx, listID, userID, item)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string, int64) int64); ok {
-		r0 = rf(title, description, listID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *models.CreateItemReq) int64); ok {
+		r0 = rf(ctx, listID, userID, item)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
-		r1 = rf(title, description, listID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, *models.CreateItemReq) error); ok {
+		r1 = rf(ctx, listID, userID, item)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +37,13 @@ func (_m *ItemRepository) Create(title string, description string, listID int64)
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: listID, itemID
-func (_m *ItemRepository) Delete(listID int64, itemID int64) error {
-	ret := _m.Called(listID, itemID)
+// Delete provides a mock function with given fields: ctx, listID, itemID, version
+func (_m *ItemRepository) Delete(ctx context.Context, listID int64, itemID int64, version *int64) error {
+	ret := _m.Called(ctx, listID, itemID, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
-		r0 = rf(listID, itemID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *int64) error); ok {
+		r0 = rf(ctx, listID, itemID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +51,36 @@ func (_m *ItemRepository) Delete(listID int64, itemID int64) error {
 	return r0
 }
 
-// GetItemByID provides a mock function with given fields: listID, itemID
-func (_m *ItemRepository) GetItemByID(listID int64, itemID int64) (*models.Item, error) {
-	ret := _m.Called(listID, itemID)
+// GetDeletedItems provides a mock function with given fields: ctx, userID
+func (_m *ItemRepository) GetDeletedItems(ctx context.Context, userID int64) ([]*models.Item, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Item
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*models.Item); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Item)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetItemByID provides a mock function with given fields: ctx, listID, itemID
+func (_m *ItemRepository) GetItemByID(ctx context.Context, listID int64, itemID int64) (*models.Item, error) {
+	ret := _m.Called(ctx, listID, itemID)
 
 	var r0 *models.Item
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.Item); ok {
-		r0 = rf(listID, itemID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Item); ok {
+		r0 = rf(ctx, listID, itemID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Item)
@@ -61,8 +88,8 @@ func (_m *ItemRepository) GetItemByID(listID int64, itemID int64) (*models.Item,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(listID, itemID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, itemID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,22 +97,96 @@ func (_m *ItemRepository) GetItemByID(listID int64, itemID int64) (*models.Item,
 	return r0, r1
 }
 
-// GetItems provides a mock function with given fields: listID
-func (_m *ItemRepository) GetItems(listID int64) ([]*models.Item, error) {
-	ret := _m.Called(listID)
+// GetItemForUpdate provides a mock function with given fields: ctx, listID, itemID
+func (_m *ItemRepository) GetItemForUpdate(ctx context.Context, listID int64, itemID int64) (*models.Item, error) {
+	ret := _m.Called(ctx, listID, itemID)
+
+	var r0 *models.Item
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Item); ok {
+		r0 = rf(ctx, listID, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Item)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetItems provides a mock function with given fields: ctx, listID, filter, page
+func (_m *ItemRepository) GetItems(ctx context.Context, listID int64, filter *models.ItemFilter, page *models.PageReq) ([]*models.Item, string, error) {
+	ret := _m.Called(ctx, listID, filter, page)
 
 	var r0 []*models.Item
-	if rf, ok := ret.Get(0).(func(int64) []*models.Item); ok {
-		r0 = rf(listID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.ItemFilter, *models.PageReq) []*models.Item); ok {
+		r0 = rf(ctx, listID, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Item)
 		}
 	}
 
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.ItemFilter, *models.PageReq) string); ok {
+		r1 = rf(ctx, listID, filter, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *models.ItemFilter, *models.PageReq) error); ok {
+		r2 = rf(ctx, listID, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUserItems provides a mock function with given fields: ctx, userID, onlyAssigned
+func (_m *ItemRepository) GetUserItems(ctx context.Context, userID int64, onlyAssigned bool) ([]*models.Item, error) {
+	ret := _m.Called(ctx, userID, onlyAssigned)
+
+	var r0 []*models.Item
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) []*models.Item); ok {
+		r0 = rf(ctx, userID, onlyAssigned)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Item)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool) error); ok {
+		r1 = rf(ctx, userID, onlyAssigned)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, before
+func (_m *ItemRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(listID)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -93,13 +194,27 @@ func (_m *ItemRepository) GetItems(listID int64) ([]*models.Item, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: listID, itemID, item
-func (_m *ItemRepository) Update(listID int64, itemID int64, item *models.UpdateItemReq) error {
-	ret := _m.Called(listID, itemID, item)
+// Restore provides a mock function with given fields: ctx, listID, itemID, userID
+func (_m *ItemRepository) Restore(ctx context.Context, listID int64, itemID int64, userID int64) error {
+	ret := _m.Called(ctx, listID, itemID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, listID, itemID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, listID, itemID, userID, item
+func (_m *ItemRepository) Update(ctx context.Context, listID int64, itemID int64, userID int64, item *models.UpdateItemReq) error {
+	ret := _m.Called(ctx, listID, itemID, userID, item)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64, *models.UpdateItemReq) error); ok {
-		r0 = rf(listID, itemID, item)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, *models.UpdateItemReq) error); ok {
+		r0 = rf(ctx, listID, itemID, userID, item)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/VladimirStepanov/todo-app/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,20 +14,20 @@ type ItemService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: title, description, listID
-func (_m *ItemService) Create(title string, description string, listID int64) (int64, error) {
-	ret := _m.Called(title, description, listID)
+// Create provides a mock function with given fields: ctx, listID, userID, item
+func (_m *ItemService) Create(ctx context.Context, listID int64, userID int64, item *models.CreateItemReq) (int64, error) {
+	ret := _m.Called(ctx, listID, userID, item)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string, int64) int64); ok {
-		r0 = rf(title, description, listID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *models.CreateItemReq) int64); ok {
+		r0 = rf(ctx, listID, userID, item)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
-		r1 = rf(title, description, listID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, *models.CreateItemReq) error); ok {
+		r1 = rf(ctx, listID, userID, item)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +35,13 @@ func (_m *ItemService) Create(title string, description string, listID int64) (i
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: listID, itemID
-func (_m *ItemService) Delete(listID int64, itemID int64) error {
-	ret := _m.Called(listID, itemID)
+// Delete provides a mock function with given fields: ctx, listID, itemID, userID, version
+func (_m *ItemService) Delete(ctx context.Context, listID int64, itemID int64, userID int64, version *int64) error {
+	ret := _m.Called(ctx, listID, itemID, userID, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
-		r0 = rf(listID, itemID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, *int64) error); ok {
+		r0 = rf(ctx, listID, itemID, userID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +49,13 @@ func (_m *ItemService) Delete(listID int64, itemID int64) error {
 	return r0
 }
 
-// Done provides a mock function with given fields: listID, itemID
-func (_m *ItemService) Done(listID int64, itemID int64) error {
-	ret := _m.Called(listID, itemID)
+// Done provides a mock function with given fields: ctx, listID, itemID, userID, version
+func (_m *ItemService) Done(ctx context.Context, listID int64, itemID int64, userID int64, version *int64) error {
+	ret := _m.Called(ctx, listID, itemID, userID, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
-		r0 = rf(listID, itemID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, *int64) error); ok {
+		r0 = rf(ctx, listID, itemID, userID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -61,13 +63,13 @@ func (_m *ItemService) Done(listID int64, itemID int64) error {
 	return r0
 }
 
-// GetItemByID provides a mock function with given fields: listID, itemID
-func (_m *ItemService) GetItemByID(listID int64, itemID int64) (*models.Item, error) {
-	ret := _m.Called(listID, itemID)
+// GetItemByID provides a mock function with given fields: ctx, listID, itemID
+func (_m *ItemService) GetItemByID(ctx context.Context, listID int64, itemID int64) (*models.Item, error) {
+	ret := _m.Called(ctx, listID, itemID)
 
 	var r0 *models.Item
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.Item); ok {
-		r0 = rf(listID, itemID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Item); ok {
+		r0 = rf(ctx, listID, itemID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Item)
@@ -75,8 +77,8 @@ func (_m *ItemService) GetItemByID(listID int64, itemID int64) (*models.Item, er
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(listID, itemID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, itemID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,13 +86,43 @@ func (_m *ItemService) GetItemByID(listID int64, itemID int64) (*models.Item, er
 	return r0, r1
 }
 
-// GetItems provides a mock function with given fields: listID
-func (_m *ItemService) GetItems(listID int64) ([]*models.Item, error) {
-	ret := _m.Called(listID)
+// GetItems provides a mock function with given fields: ctx, listID, filter, page
+func (_m *ItemService) GetItems(ctx context.Context, listID int64, filter *models.ItemFilter, page *models.PageReq) ([]*models.Item, string, error) {
+	ret := _m.Called(ctx, listID, filter, page)
+
+	var r0 []*models.Item
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.ItemFilter, *models.PageReq) []*models.Item); ok {
+		r0 = rf(ctx, listID, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Item)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.ItemFilter, *models.PageReq) string); ok {
+		r1 = rf(ctx, listID, filter, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *models.ItemFilter, *models.PageReq) error); ok {
+		r2 = rf(ctx, listID, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUserItems provides a mock function with given fields: ctx, userID, onlyAssigned
+func (_m *ItemService) GetUserItems(ctx context.Context, userID int64, onlyAssigned bool) ([]*models.Item, error) {
+	ret := _m.Called(ctx, userID, onlyAssigned)
 
 	var r0 []*models.Item
-	if rf, ok := ret.Get(0).(func(int64) []*models.Item); ok {
-		r0 = rf(listID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) []*models.Item); ok {
+		r0 = rf(ctx, userID, onlyAssigned)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Item)
@@ -98,8 +130,8 @@ func (_m *ItemService) GetItems(listID int64) ([]*models.Item, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(listID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool) error); ok {
+		r1 = rf(ctx, userID, onlyAssigned)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -107,13 +139,13 @@ func (_m *ItemService) GetItems(listID int64) ([]*models.Item, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: listID, itemID, item
-func (_m *ItemService) Update(listID int64, itemID int64, item *models.UpdateItemReq) error {
-	ret := _m.Called(listID, itemID, item)
+// Update provides a mock function with given fields: ctx, listID, itemID, userID, item
+func (_m *ItemService) Update(ctx context.Context, listID int64, itemID int64, userID int64, item *models.UpdateItemReq) error {
+	ret := _m.Called(ctx, listID, itemID, userID, item)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64, *models.UpdateItemReq) error); ok {
-		r0 = rf(listID, itemID, item)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, *models.UpdateItemReq) error); ok {
+		r0 = rf(ctx, listID, itemID, userID, item)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,8 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/VladimirStepanov/todo-app/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ListRepository is an autogenerated mock type for the ListRepository type
@@ -12,20 +16,20 @@ type ListRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: title, description, userID
-func (_m *ListRepository) Create(title string, description string, userID int64) (int64, error) {
-	ret := _m.Called(title, description, userID)
+// Create provides a mock function with given fields: ctx, title, description, userID
+func (_m *ListRepository) Create(ctx context.Context, title string, description string, userID int64) (int64, error) {
+	ret := _m.Called(ctx, title, description, userID)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string, int64) int64); ok {
-		r0 = rf(title, description, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) int64); ok {
+		r0 = rf(ctx, title, description, userID)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
-		r1 = rf(title, description, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, title, description, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +37,13 @@ func (_m *ListRepository) Create(title string, description string, userID int64)
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: listID
-func (_m *ListRepository) Delete(listID int64) error {
-	ret := _m.Called(listID)
+// Delete provides a mock function with given fields: ctx, listID, version
+func (_m *ListRepository) Delete(ctx context.Context, listID int64, version *int64) error {
+	ret := _m.Called(ctx, listID, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(listID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64) error); ok {
+		r0 = rf(ctx, listID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +51,13 @@ func (_m *ListRepository) Delete(listID int64) error {
 	return r0
 }
 
-// EditRole provides a mock function with given fields: listID, userID, role
-func (_m *ListRepository) EditRole(listID int64, userID int64, role bool) error {
-	ret := _m.Called(listID, userID, role)
+// EditRole provides a mock function with given fields: ctx, listID, userID, role
+func (_m *ListRepository) EditRole(ctx context.Context, listID int64, userID int64, role bool) error {
+	ret := _m.Called(ctx, listID, userID, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64, bool) error); ok {
-		r0 = rf(listID, userID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bool) error); ok {
+		r0 = rf(ctx, listID, userID, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -61,13 +65,36 @@ func (_m *ListRepository) EditRole(listID int64, userID int64, role bool) error
 	return r0
 }
 
-// GetListByID provides a mock function with given fields: listID, userID
-func (_m *ListRepository) GetListByID(listID int64, userID int64) (*models.List, error) {
-	ret := _m.Called(listID, userID)
+// GetDeletedLists provides a mock function with given fields: ctx, userID
+func (_m *ListRepository) GetDeletedLists(ctx context.Context, userID int64) ([]*models.List, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.List
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*models.List); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.List)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetListByID provides a mock function with given fields: ctx, listID, userID
+func (_m *ListRepository) GetListByID(ctx context.Context, listID int64, userID int64) (*models.List, error) {
+	ret := _m.Called(ctx, listID, userID)
 
 	var r0 *models.List
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.List); ok {
-		r0 = rf(listID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.List); ok {
+		r0 = rf(ctx, listID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.List)
@@ -75,8 +102,8 @@ func (_m *ListRepository) GetListByID(listID int64, userID int64) (*models.List,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(listID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,22 +111,147 @@ func (_m *ListRepository) GetListByID(listID int64, userID int64) (*models.List,
 	return r0, r1
 }
 
-// GetUserLists provides a mock function with given fields: userID
-func (_m *ListRepository) GetUserLists(userID int64) ([]*models.List, error) {
-	ret := _m.Called(userID)
+// GetListForUpdate provides a mock function with given fields: ctx, listID, userID
+func (_m *ListRepository) GetListForUpdate(ctx context.Context, listID int64, userID int64) (*models.List, error) {
+	ret := _m.Called(ctx, listID, userID)
+
+	var r0 *models.List
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.List); ok {
+		r0 = rf(ctx, listID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.List)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMember provides a mock function with given fields: ctx, listID, userID
+func (_m *ListRepository) GetMember(ctx context.Context, listID int64, userID int64) (*models.User, error) {
+	ret := _m.Called(ctx, listID, userID)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.User); ok {
+		r0 = rf(ctx, listID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembersByEmails provides a mock function with given fields: ctx, listID, emails
+func (_m *ListRepository) GetMembersByEmails(ctx context.Context, listID int64, emails []string) ([]*models.User, error) {
+	ret := _m.Called(ctx, listID, emails)
+
+	var r0 []*models.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) []*models.User); ok {
+		r0 = rf(ctx, listID, emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []string) error); ok {
+		r1 = rf(ctx, listID, emails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserLists provides a mock function with given fields: ctx, userID, filter, page
+func (_m *ListRepository) GetUserLists(ctx context.Context, userID int64, filter *models.ListFilter, page *models.PageReq) ([]*models.List, string, error) {
+	ret := _m.Called(ctx, userID, filter, page)
 
 	var r0 []*models.List
-	if rf, ok := ret.Get(0).(func(int64) []*models.List); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.ListFilter, *models.PageReq) []*models.List); ok {
+		r0 = rf(ctx, userID, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.List)
 		}
 	}
 
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.ListFilter, *models.PageReq) string); ok {
+		r1 = rf(ctx, userID, filter, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *models.ListFilter, *models.PageReq) error); ok {
+		r2 = rf(ctx, userID, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IsDeletedListAdmin provides a mock function with given fields: ctx, listID, userID
+func (_m *ListRepository) IsDeletedListAdmin(ctx context.Context, listID int64, userID int64) error {
+	ret := _m.Called(ctx, listID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, listID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsListAdmin provides a mock function with given fields: ctx, ListID, userID
+func (_m *ListRepository) IsListAdmin(ctx context.Context, ListID int64, userID int64) error {
+	ret := _m.Called(ctx, ListID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, ListID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsListArchived provides a mock function with given fields: ctx, listID
+func (_m *ListRepository) IsListArchived(ctx context.Context, listID int64) (bool, error) {
+	ret := _m.Called(ctx, listID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, listID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -107,13 +259,62 @@ func (_m *ListRepository) GetUserLists(userID int64) ([]*models.List, error) {
 	return r0, r1
 }
 
-// IsListAdmin provides a mock function with given fields: ListID, userID
-func (_m *ListRepository) IsListAdmin(ListID int64, userID int64) error {
-	ret := _m.Called(ListID, userID)
+// Purge provides a mock function with given fields: ctx, before
+func (_m *ListRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, listID, userID
+func (_m *ListRepository) RemoveMember(ctx context.Context, listID int64, userID int64) error {
+	ret := _m.Called(ctx, listID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, listID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: ctx, listID, userID
+func (_m *ListRepository) Restore(ctx context.Context, listID int64, userID int64) error {
+	ret := _m.Called(ctx, listID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, listID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetArchived provides a mock function with given fields: ctx, listID, userID, archived
+func (_m *ListRepository) SetArchived(ctx context.Context, listID int64, userID int64, archived bool) error {
+	ret := _m.Called(ctx, listID, userID, archived)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
-		r0 = rf(ListID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bool) error); ok {
+		r0 = rf(ctx, listID, userID, archived)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -121,13 +322,36 @@ func (_m *ListRepository) IsListAdmin(ListID int64, userID int64) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: listID, list
-func (_m *ListRepository) Update(listID int64, list *models.UpdateListReq) error {
-	ret := _m.Called(listID, list)
+// UnassignMember provides a mock function with given fields: ctx, listID, userID, updatedBy
+func (_m *ListRepository) UnassignMember(ctx context.Context, listID int64, userID int64, updatedBy int64) ([]int64, error) {
+	ret := _m.Called(ctx, listID, userID, updatedBy)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) []int64); ok {
+		r0 = rf(ctx, listID, userID, updatedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, listID, userID, updatedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, listID, userID, list
+func (_m *ListRepository) Update(ctx context.Context, listID int64, userID int64, list *models.UpdateListReq) error {
+	ret := _m.Called(ctx, listID, userID, list)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, *models.UpdateListReq) error); ok {
-		r0 = rf(listID, list)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *models.UpdateListReq) error); ok {
+		r0 = rf(ctx, listID, userID, list)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/VladimirStepanov/todo-app/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,20 +14,20 @@ type ListService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: title, description, userID
-func (_m *ListService) Create(title string, description string, userID int64) (int64, error) {
-	ret := _m.Called(title, description, userID)
+// Create provides a mock function with given fields: ctx, title, description, userID
+func (_m *ListService) Create(ctx context.Context, title string, description string, userID int64) (int64, error) {
+	ret := _m.Called(ctx, title, description, userID)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string, int64) int64); ok {
-		r0 = rf(title, description, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) int64); ok {
+		r0 = rf(ctx, title, description, userID)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
-		r1 = rf(title, description, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, title, description, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,13 +35,13 @@ func (_m *ListService) Create(title string, description string, userID int64) (i
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: listID
-func (_m *ListService) Delete(listID int64) error {
-	ret := _m.Called(listID)
+// Delete provides a mock function with given fields: ctx, listID, userID, version
+func (_m *ListService) Delete(ctx context.Context, listID int64, userID int64, version *int64) error {
+	ret := _m.Called(ctx, listID, userID, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(listID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *int64) error); ok {
+		r0 = rf(ctx, listID, userID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +49,13 @@ func (_m *ListService) Delete(listID int64) error {
 	return r0
 }
 
-// EditRole provides a mock function with given fields: listID, userID, role
-func (_m *ListService) EditRole(listID int64, userID int64, role bool) error {
-	ret := _m.Called(listID, userID, role)
+// EditRole provides a mock function with given fields: ctx, listID, adminID, userID, role
+func (_m *ListService) EditRole(ctx context.Context, listID int64, adminID int64, userID int64, role bool) error {
+	ret := _m.Called(ctx, listID, adminID, userID, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64, bool) error); ok {
-		r0 = rf(listID, userID, role)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, bool) error); ok {
+		r0 = rf(ctx, listID, adminID, userID, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -61,13 +63,43 @@ func (_m *ListService) EditRole(listID int64, userID int64, role bool) error {
 	return r0
 }
 
-// GetListByID provides a mock function with given fields: listID, userID
-func (_m *ListService) GetListByID(listID int64, userID int64) (*models.List, error) {
-	ret := _m.Called(listID, userID)
+// GetActivity provides a mock function with given fields: ctx, listID, page
+func (_m *ListService) GetActivity(ctx context.Context, listID int64, page *models.PageReq) ([]*models.Activity, string, error) {
+	ret := _m.Called(ctx, listID, page)
+
+	var r0 []*models.Activity
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PageReq) []*models.Activity); ok {
+		r0 = rf(ctx, listID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Activity)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.PageReq) string); ok {
+		r1 = rf(ctx, listID, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *models.PageReq) error); ok {
+		r2 = rf(ctx, listID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetListByID provides a mock function with given fields: ctx, listID, userID
+func (_m *ListService) GetListByID(ctx context.Context, listID int64, userID int64) (*models.List, error) {
+	ret := _m.Called(ctx, listID, userID)
 
 	var r0 *models.List
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.List); ok {
-		r0 = rf(listID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.List); ok {
+		r0 = rf(ctx, listID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.List)
@@ -75,8 +107,8 @@ func (_m *ListService) GetListByID(listID int64, userID int64) (*models.List, er
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(listID, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,22 +116,64 @@ func (_m *ListService) GetListByID(listID int64, userID int64) (*models.List, er
 	return r0, r1
 }
 
-// GetUserLists provides a mock function with given fields: userID
-func (_m *ListService) GetUserLists(userID int64) ([]*models.List, error) {
-	ret := _m.Called(userID)
+// GetUserLists provides a mock function with given fields: ctx, userID, filter, page
+func (_m *ListService) GetUserLists(ctx context.Context, userID int64, filter *models.ListFilter, page *models.PageReq) ([]*models.List, string, error) {
+	ret := _m.Called(ctx, userID, filter, page)
 
 	var r0 []*models.List
-	if rf, ok := ret.Get(0).(func(int64) []*models.List); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.ListFilter, *models.PageReq) []*models.List); ok {
+		r0 = rf(ctx, userID, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.List)
 		}
 	}
 
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.ListFilter, *models.PageReq) string); ok {
+		r1 = rf(ctx, userID, filter, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *models.ListFilter, *models.PageReq) error); ok {
+		r2 = rf(ctx, userID, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IsListAdmin provides a mock function with given fields: ctx, ListID, userID
+func (_m *ListService) IsListAdmin(ctx context.Context, ListID int64, userID int64) error {
+	ret := _m.Called(ctx, ListID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, ListID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsListArchived provides a mock function with given fields: ctx, listID
+func (_m *ListService) IsListArchived(ctx context.Context, listID int64) (bool, error) {
+	ret := _m.Called(ctx, listID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, listID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -107,13 +181,27 @@ func (_m *ListService) GetUserLists(userID int64) ([]*models.List, error) {
 	return r0, r1
 }
 
-// IsListAdmin provides a mock function with given fields: ListID, userID
-func (_m *ListService) IsListAdmin(ListID int64, userID int64) error {
-	ret := _m.Called(ListID, userID)
+// RemoveMember provides a mock function with given fields: ctx, listID, adminID, userID
+func (_m *ListService) RemoveMember(ctx context.Context, listID int64, adminID int64, userID int64) error {
+	ret := _m.Called(ctx, listID, adminID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, listID, adminID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetArchived provides a mock function with given fields: ctx, listID, userID, archived
+func (_m *ListService) SetArchived(ctx context.Context, listID int64, userID int64, archived bool) error {
+	ret := _m.Called(ctx, listID, userID, archived)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
-		r0 = rf(ListID, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bool) error); ok {
+		r0 = rf(ctx, listID, userID, archived)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -121,13 +209,13 @@ func (_m *ListService) IsListAdmin(ListID int64, userID int64) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: listID, list
-func (_m *ListService) Update(listID int64, list *models.UpdateListReq) error {
-	ret := _m.Called(listID, list)
+// Update provides a mock function with given fields: ctx, listID, userID, list
+func (_m *ListService) Update(ctx context.Context, listID int64, userID int64, list *models.UpdateListReq) error {
+	ret := _m.Called(ctx, listID, userID, list)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, *models.UpdateListReq) error); ok {
-		r0 = rf(listID, list)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *models.UpdateListReq) error); ok {
+		r0 = rf(ctx, listID, userID, list)
 	} else {
 		r0 = ret.Error(0)
 	}
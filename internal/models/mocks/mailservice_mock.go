@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/VladimirStepanov/todo-app/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,13 +14,41 @@ type MailService struct {
 	mock.Mock
 }
 
-// SendConfirmationsEmail provides a mock function with given fields: user
-func (_m *MailService) SendConfirmationsEmail(user *models.User) error {
-	ret := _m.Called(user)
+// SendAssignEmail provides a mock function with given fields: ctx, user, item
+func (_m *MailService) SendAssignEmail(ctx context.Context, user *models.User, item *models.Item) error {
+	ret := _m.Called(ctx, user, item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.Item) error); ok {
+		r0 = rf(ctx, user, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendConfirmationsEmail provides a mock function with given fields: ctx, user
+func (_m *MailService) SendConfirmationsEmail(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendMentionEmail provides a mock function with given fields: ctx, user, listID, itemID, body
+func (_m *MailService) SendMentionEmail(ctx context.Context, user *models.User, listID int64, itemID int64, body string) error {
+	ret := _m.Called(ctx, user, listID, itemID, body)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, int64, int64, string) error); ok {
+		r0 = rf(ctx, user, listID, itemID, body)
 	} else {
 		r0 = ret.Error(0)
 	}
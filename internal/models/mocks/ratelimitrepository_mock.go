@@ -0,0 +1,40 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RateLimitRepository is an autogenerated mock type for the RateLimitRepository type
+type RateLimitRepository struct {
+	mock.Mock
+}
+
+// Allow provides a mock function with given fields: ctx, key, limit, now, member
+func (_m *RateLimitRepository) Allow(ctx context.Context, key string, limit models.RateLimit, now time.Time, member string) (*models.RateLimitResult, error) {
+	ret := _m.Called(ctx, key, limit, now, member)
+
+	var r0 *models.RateLimitResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.RateLimit, time.Time, string) *models.RateLimitResult); ok {
+		r0 = rf(ctx, key, limit, now, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RateLimitResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.RateLimit, time.Time, string) error); ok {
+		r1 = rf(ctx, key, limit, now, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,38 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RateLimitService is an autogenerated mock type for the RateLimitService type
+type RateLimitService struct {
+	mock.Mock
+}
+
+// Allow provides a mock function with given fields: ctx, group, subject
+func (_m *RateLimitService) Allow(ctx context.Context, group string, subject string) (*models.RateLimitResult, error) {
+	ret := _m.Called(ctx, group, subject)
+
+	var r0 *models.RateLimitResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.RateLimitResult); ok {
+		r0 = rf(ctx, group, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RateLimitResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, group, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,38 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchRepository is an autogenerated mock type for the SearchRepository type
+type SearchRepository struct {
+	mock.Mock
+}
+
+// Search provides a mock function with given fields: ctx, userID, query, lang, limit
+func (_m *SearchRepository) Search(ctx context.Context, userID int64, query string, lang string, limit int) ([]*models.SearchResult, error) {
+	ret := _m.Called(ctx, userID, query, lang, limit)
+
+	var r0 []*models.SearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, int) []*models.SearchResult); ok {
+		r0 = rf(ctx, userID, query, lang, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string, int) error); ok {
+		r1 = rf(ctx, userID, query, lang, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,38 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchService is an autogenerated mock type for the SearchService type
+type SearchService struct {
+	mock.Mock
+}
+
+// Search provides a mock function with given fields: ctx, userID, query, lang
+func (_m *SearchService) Search(ctx context.Context, userID int64, query string, lang string) ([]*models.SearchResult, error) {
+	ret := _m.Called(ctx, userID, query, lang)
+
+	var r0 []*models.SearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) []*models.SearchResult); ok {
+		r0 = rf(ctx, userID, query, lang)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string) error); ok {
+		r1 = rf(ctx, userID, query, lang)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SyncRepository is an autogenerated mock type for the SyncRepository type
+type SyncRepository struct {
+	mock.Mock
+}
+
+// GetChanges provides a mock function with given fields: ctx, userID, since
+func (_m *SyncRepository) GetChanges(ctx context.Context, userID int64, since int64) (*models.SyncChanges, int64, error) {
+	ret := _m.Called(ctx, userID, since)
+
+	var r0 *models.SyncChanges
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.SyncChanges); ok {
+		r0 = rf(ctx, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SyncChanges)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) int64); ok {
+		r1 = rf(ctx, userID, since)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64) error); ok {
+		r2 = rf(ctx, userID, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMutation provides a mock function with given fields: ctx, userID, clientID
+func (_m *SyncRepository) GetMutation(ctx context.Context, userID int64, clientID string) (*models.SyncResult, error) {
+	ret := _m.Called(ctx, userID, clientID)
+
+	var r0 *models.SyncResult
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *models.SyncResult); ok {
+		r0 = rf(ctx, userID, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SyncResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveMutation provides a mock function with given fields: ctx, userID, result
+func (_m *SyncRepository) SaveMutation(ctx context.Context, userID int64, result *models.SyncResult) error {
+	ret := _m.Called(ctx, userID, result)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.SyncResult) error); ok {
+		r0 = rf(ctx, userID, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,61 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SyncService is an autogenerated mock type for the SyncService type
+type SyncService struct {
+	mock.Mock
+}
+
+// Apply provides a mock function with given fields: ctx, userID, mutations
+func (_m *SyncService) Apply(ctx context.Context, userID int64, mutations []*models.SyncMutation) ([]*models.SyncResult, error) {
+	ret := _m.Called(ctx, userID, mutations)
+
+	var r0 []*models.SyncResult
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []*models.SyncMutation) []*models.SyncResult); ok {
+		r0 = rf(ctx, userID, mutations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SyncResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []*models.SyncMutation) error); ok {
+		r1 = rf(ctx, userID, mutations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChanges provides a mock function with given fields: ctx, userID, since
+func (_m *SyncService) GetChanges(ctx context.Context, userID int64, since string) (*models.SyncChanges, error) {
+	ret := _m.Called(ctx, userID, since)
+
+	var r0 *models.SyncChanges
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *models.SyncChanges); ok {
+		r0 = rf(ctx, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SyncChanges)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
 	time "time"
@@ -13,20 +15,20 @@ type TokenRepository struct {
 	mock.Mock
 }
 
-// Count provides a mock function with given fields: pattern
-func (_m *TokenRepository) Count(pattern string) (int, error) {
-	ret := _m.Called(pattern)
+// Count provides a mock function with given fields: ctx, pattern
+func (_m *TokenRepository) Count(ctx context.Context, pattern string) (int, error) {
+	ret := _m.Called(ctx, pattern)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(string) int); ok {
-		r0 = rf(pattern)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, pattern)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(pattern)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -34,19 +36,20 @@ func (_m *TokenRepository) Count(pattern string) (int, error) {
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: keys
-func (_m *TokenRepository) Delete(keys ...string) error {
+// Delete provides a mock function with given fields: ctx, keys
+func (_m *TokenRepository) Delete(ctx context.Context, keys ...string) error {
 	_va := make([]interface{}, len(keys))
 	for _i := range keys {
 		_va[_i] = keys[_i]
 	}
 	var _ca []interface{}
+	_ca = append(_ca, ctx)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(...string) error); ok {
-		r0 = rf(keys...)
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, keys...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -54,20 +57,20 @@ func (_m *TokenRepository) Delete(keys ...string) error {
 	return r0
 }
 
-// Get provides a mock function with given fields: key
-func (_m *TokenRepository) Get(key string) (bool, error) {
-	ret := _m.Called(key)
+// Get provides a mock function with given fields: ctx, key
+func (_m *TokenRepository) Get(ctx context.Context, key string) (bool, error) {
+	ret := _m.Called(ctx, key)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(key)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, key)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(key)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,13 +78,13 @@ func (_m *TokenRepository) Get(key string) (bool, error) {
 	return r0, r1
 }
 
-// SetTokens provides a mock function with given fields: accessKey, accessExp, refreshKey, refreshExp
-func (_m *TokenRepository) SetTokens(accessKey string, accessExp time.Duration, refreshKey string, refreshExp time.Duration) error {
-	ret := _m.Called(accessKey, accessExp, refreshKey, refreshExp)
+// SetTokens provides a mock function with given fields: ctx, accessKey, accessExp, refreshKey, refreshExp
+func (_m *TokenRepository) SetTokens(ctx context.Context, accessKey string, accessExp time.Duration, refreshKey string, refreshExp time.Duration) error {
+	ret := _m.Called(ctx, accessKey, accessExp, refreshKey, refreshExp)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, time.Duration, string, time.Duration) error); ok {
-		r0 = rf(accessKey, accessExp, refreshKey, refreshExp)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, string, time.Duration) error); ok {
+		r0 = rf(ctx, accessKey, accessExp, refreshKey, refreshExp)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,8 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/VladimirStepanov/todo-app/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // TokenService is an autogenerated mock type for the TokenService type
@@ -12,13 +16,55 @@ type TokenService struct {
 	mock.Mock
 }
 
-// Logout provides a mock function with given fields: userID, userUUID
-func (_m *TokenService) Logout(userID int64, userUUID string) error {
-	ret := _m.Called(userID, userUUID)
+// ActiveSessions provides a mock function with given fields: ctx
+func (_m *TokenService) ActiveSessions(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpiresAt provides a mock function with given fields: token
+func (_m *TokenService) ExpiresAt(token string) (time.Time, error) {
+	ret := _m.Called(token)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, userID, userUUID
+func (_m *TokenService) Logout(ctx context.Context, userID int64, userUUID string) error {
+	ret := _m.Called(ctx, userID, userUUID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
-		r0 = rf(userID, userUUID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, userUUID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -26,13 +72,13 @@ func (_m *TokenService) Logout(userID int64, userUUID string) error {
 	return r0
 }
 
-// NewTokenPair provides a mock function with given fields: userID
-func (_m *TokenService) NewTokenPair(userID int64) (*models.TokenDetails, error) {
-	ret := _m.Called(userID)
+// NewTokenPair provides a mock function with given fields: ctx, userID
+func (_m *TokenService) NewTokenPair(ctx context.Context, userID int64) (*models.TokenDetails, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 *models.TokenDetails
-	if rf, ok := ret.Get(0).(func(int64) *models.TokenDetails); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.TokenDetails); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TokenDetails)
@@ -40,8 +86,8 @@ func (_m *TokenService) NewTokenPair(userID int64) (*models.TokenDetails, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -49,13 +95,13 @@ func (_m *TokenService) NewTokenPair(userID int64) (*models.TokenDetails, error)
 	return r0, r1
 }
 
-// Refresh provides a mock function with given fields: refreshToken
-func (_m *TokenService) Refresh(refreshToken string) (*models.TokenDetails, error) {
-	ret := _m.Called(refreshToken)
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *TokenService) Refresh(ctx context.Context, refreshToken string) (*models.TokenDetails, error) {
+	ret := _m.Called(ctx, refreshToken)
 
 	var r0 *models.TokenDetails
-	if rf, ok := ret.Get(0).(func(string) *models.TokenDetails); ok {
-		r0 = rf(refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TokenDetails); ok {
+		r0 = rf(ctx, refreshToken)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TokenDetails)
@@ -63,8 +109,8 @@ func (_m *TokenService) Refresh(refreshToken string) (*models.TokenDetails, erro
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(refreshToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,27 +118,27 @@ func (_m *TokenService) Refresh(refreshToken string) (*models.TokenDetails, erro
 	return r0, r1
 }
 
-// Verify provides a mock function with given fields: token
-func (_m *TokenService) Verify(token string) (int64, string, error) {
-	ret := _m.Called(token)
+// Verify provides a mock function with given fields: ctx, token
+func (_m *TokenService) Verify(ctx context.Context, token string) (int64, string, error) {
+	ret := _m.Called(ctx, token)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 string
-	if rf, ok := ret.Get(1).(func(string) string); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, token)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(string) error); ok {
-		r2 = rf(token)
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, token)
 	} else {
 		r2 = ret.Error(2)
 	}
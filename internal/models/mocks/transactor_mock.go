@@ -0,0 +1,33 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Transactor is an autogenerated mock type for the Transactor type
+type Transactor struct {
+	mock.Mock
+}
+
+// AfterCommit provides a mock function with given fields: ctx, fn
+func (_m *Transactor) AfterCommit(ctx context.Context, fn func()) {
+	_m.Called(ctx, fn)
+}
+
+// InTx provides a mock function with given fields: ctx, fn
+func (_m *Transactor) InTx(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TrashService is an autogenerated mock type for the TrashService type
+type TrashService struct {
+	mock.Mock
+}
+
+// GetTrash provides a mock function with given fields: ctx, userID
+func (_m *TrashService) GetTrash(ctx context.Context, userID int64) (*models.Trash, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *models.Trash
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Trash); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Trash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsDeletedListAdmin provides a mock function with given fields: ctx, listID, userID
+func (_m *TrashService) IsDeletedListAdmin(ctx context.Context, listID int64, userID int64) error {
+	ret := _m.Called(ctx, listID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, listID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Purge provides a mock function with given fields: ctx
+func (_m *TrashService) Purge(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreItem provides a mock function with given fields: ctx, listID, itemID, userID
+func (_m *TrashService) RestoreItem(ctx context.Context, listID int64, itemID int64, userID int64) error {
+	ret := _m.Called(ctx, listID, itemID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, listID, itemID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreList provides a mock function with given fields: ctx, listID, userID
+func (_m *TrashService) RestoreList(ctx context.Context, listID int64, userID int64) error {
+	ret := _m.Called(ctx, listID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, listID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
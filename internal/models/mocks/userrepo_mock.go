@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/VladimirStepanov/todo-app/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,13 +14,13 @@ type UserRepository struct {
 	mock.Mock
 }
 
-// ConfirmEmail provides a mock function with given fields: Link
-func (_m *UserRepository) ConfirmEmail(Link string) error {
-	ret := _m.Called(Link)
+// ConfirmEmail provides a mock function with given fields: ctx, Link
+func (_m *UserRepository) ConfirmEmail(ctx context.Context, Link string) error {
+	ret := _m.Called(ctx, Link)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(Link)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, Link)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -26,13 +28,13 @@ func (_m *UserRepository) ConfirmEmail(Link string) error {
 	return r0
 }
 
-// Create provides a mock function with given fields: user
-func (_m *UserRepository) Create(user *models.User) (*models.User, error) {
-	ret := _m.Called(user)
+// Create provides a mock function with given fields: ctx, user
+func (_m *UserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	ret := _m.Called(ctx, user)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(*models.User) *models.User); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) *models.User); ok {
+		r0 = rf(ctx, user)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -40,8 +42,8 @@ func (_m *UserRepository) Create(user *models.User) (*models.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.User) error); ok {
-		r1 = rf(user)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User) error); ok {
+		r1 = rf(ctx, user)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -49,13 +51,13 @@ func (_m *UserRepository) Create(user *models.User) (*models.User, error) {
 	return r0, r1
 }
 
-// FindUserByEmail provides a mock function with given fields: Email
-func (_m *UserRepository) FindUserByEmail(Email string) (*models.User, error) {
-	ret := _m.Called(Email)
+// FindUserByEmail provides a mock function with given fields: ctx, Email
+func (_m *UserRepository) FindUserByEmail(ctx context.Context, Email string) (*models.User, error) {
+	ret := _m.Called(ctx, Email)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
-		r0 = rf(Email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, Email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -63,8 +65,8 @@ func (_m *UserRepository) FindUserByEmail(Email string) (*models.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(Email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, Email)
 	} else {
 		r1 = ret.Error(1)
 	}
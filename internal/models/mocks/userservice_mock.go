@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/VladimirStepanov/todo-app/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,13 +14,13 @@ type UserService struct {
 	mock.Mock
 }
 
-// ConfirmEmail provides a mock function with given fields: Link
-func (_m *UserService) ConfirmEmail(Link string) error {
-	ret := _m.Called(Link)
+// ConfirmEmail provides a mock function with given fields: ctx, Link
+func (_m *UserService) ConfirmEmail(ctx context.Context, Link string) error {
+	ret := _m.Called(ctx, Link)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(Link)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, Link)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -26,13 +28,13 @@ func (_m *UserService) ConfirmEmail(Link string) error {
 	return r0
 }
 
-// Create provides a mock function with given fields: Email, Password
-func (_m *UserService) Create(Email string, Password string) (*models.User, error) {
-	ret := _m.Called(Email, Password)
+// Create provides a mock function with given fields: ctx, Email, Password
+func (_m *UserService) Create(ctx context.Context, Email string, Password string) (*models.User, error) {
+	ret := _m.Called(ctx, Email, Password)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(string, string) *models.User); ok {
-		r0 = rf(Email, Password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.User); ok {
+		r0 = rf(ctx, Email, Password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -40,8 +42,8 @@ func (_m *UserService) Create(Email string, Password string) (*models.User, erro
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(Email, Password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, Email, Password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -49,13 +51,13 @@ func (_m *UserService) Create(Email string, Password string) (*models.User, erro
 	return r0, r1
 }
 
-// SignIn provides a mock function with given fields: Email, Password
-func (_m *UserService) SignIn(Email string, Password string) (*models.User, error) {
-	ret := _m.Called(Email, Password)
+// SignIn provides a mock function with given fields: ctx, Email, Password
+func (_m *UserService) SignIn(ctx context.Context, Email string, Password string) (*models.User, error) {
+	ret := _m.Called(ctx, Email, Password)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(string, string) *models.User); ok {
-		r0 = rf(Email, Password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.User); ok {
+		r0 = rf(ctx, Email, Password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -63,8 +65,8 @@ func (_m *UserService) SignIn(Email string, Password string) (*models.User, erro
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(Email, Password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, Email, Password)
 	} else {
 		r1 = ret.Error(1)
 	}
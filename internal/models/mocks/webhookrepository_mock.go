@@ -0,0 +1,179 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+// ClaimDeliveries provides a mock function with given fields: ctx, limit, lease
+func (_m *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookJob, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	var r0 []*models.WebhookJob
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []*models.WebhookJob); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, webhook
+func (_m *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) (int64, error) {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) int64); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Webhook) error); ok {
+		r1 = rf(ctx, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, listID, webhookID
+func (_m *WebhookRepository) Delete(ctx context.Context, listID int64, webhookID int64) error {
+	ret := _m.Called(ctx, listID, webhookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, listID, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, webhookID, page
+func (_m *WebhookRepository) GetDeliveries(ctx context.Context, webhookID int64, page *models.PageReq) ([]*models.WebhookDelivery, string, error) {
+	ret := _m.Called(ctx, webhookID, page)
+
+	var r0 []*models.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *models.PageReq) []*models.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDelivery)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *models.PageReq) string); ok {
+		r1 = rf(ctx, webhookID, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *models.PageReq) error); ok {
+		r2 = rf(ctx, webhookID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetWebhookByID provides a mock function with given fields: ctx, listID, webhookID
+func (_m *WebhookRepository) GetWebhookByID(ctx context.Context, listID int64, webhookID int64) (*models.Webhook, error) {
+	ret := _m.Called(ctx, listID, webhookID)
+
+	var r0 *models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Webhook); ok {
+		r0 = rf(ctx, listID, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, listID, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: ctx, listID
+func (_m *WebhookRepository) GetWebhooks(ctx context.Context, listID int64) ([]*models.Webhook, error) {
+	ret := _m.Called(ctx, listID)
+
+	var r0 []*models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*models.Webhook); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, listID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveResult provides a mock function with given fields: ctx, result, maxFailures
+func (_m *WebhookRepository) SaveResult(ctx context.Context, result *models.DeliveryResult, maxFailures int) error {
+	ret := _m.Called(ctx, result, maxFailures)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeliveryResult, int) error); ok {
+		r0 = rf(ctx, result, maxFailures)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, listID, webhookID, req
+func (_m *WebhookRepository) Update(ctx context.Context, listID int64, webhookID int64, req *models.UpdateWebhookReq) error {
+	ret := _m.Called(ctx, listID, webhookID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *models.UpdateWebhookReq) error); ok {
+		r0 = rf(ctx, listID, webhookID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
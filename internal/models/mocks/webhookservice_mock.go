@@ -0,0 +1,131 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/VladimirStepanov/todo-app/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, listID, userID, req
+func (_m *WebhookService) Create(ctx context.Context, listID int64, userID int64, req *models.CreateWebhookReq) (int64, error) {
+	ret := _m.Called(ctx, listID, userID, req)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *models.CreateWebhookReq) int64); ok {
+		r0 = rf(ctx, listID, userID, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, *models.CreateWebhookReq) error); ok {
+		r1 = rf(ctx, listID, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, listID, webhookID
+func (_m *WebhookService) Delete(ctx context.Context, listID int64, webhookID int64) error {
+	ret := _m.Called(ctx, listID, webhookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, listID, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Deliver provides a mock function with given fields: ctx
+func (_m *WebhookService) Deliver(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, listID, webhookID, page
+func (_m *WebhookService) GetDeliveries(ctx context.Context, listID int64, webhookID int64, page *models.PageReq) ([]*models.WebhookDelivery, string, error) {
+	ret := _m.Called(ctx, listID, webhookID, page)
+
+	var r0 []*models.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *models.PageReq) []*models.WebhookDelivery); ok {
+		r0 = rf(ctx, listID, webhookID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDelivery)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, *models.PageReq) string); ok {
+		r1 = rf(ctx, listID, webhookID, page)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64, *models.PageReq) error); ok {
+		r2 = rf(ctx, listID, webhookID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetWebhooks provides a mock function with given fields: ctx, listID
+func (_m *WebhookService) GetWebhooks(ctx context.Context, listID int64) ([]*models.Webhook, error) {
+	ret := _m.Called(ctx, listID)
+
+	var r0 []*models.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*models.Webhook); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, listID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, listID, webhookID, req
+func (_m *WebhookService) Update(ctx context.Context, listID int64, webhookID int64, req *models.UpdateWebhookReq) error {
+	ret := _m.Called(ctx, listID, webhookID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, *models.UpdateWebhookReq) error); ok {
+		r0 = rf(ctx, listID, webhookID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,20 @@
+package models
+
+const (
+	SortCreatedAt = "created_at"
+	SortTitle     = "title"
+	SortDueAt     = "due_at"
+	SortPriority  = "priority"
+
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// PageReq describes one page of keyset pagination.
+// Cursor is an opaque value returned with previous page
+type PageReq struct {
+	Limit  int
+	Cursor string
+	Sort   string
+	Desc   bool
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// route groups with separate rate limits
+const (
+	RateLimitAuth = "auth"
+	RateLimitAPI  = "api"
+)
+
+// RateLimit allows Requests per sliding Window
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Window    time.Duration
+	// time until the oldest request in window expires
+	Reset time.Duration
+}
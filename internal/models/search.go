@@ -0,0 +1,15 @@
+package models
+
+const (
+	SearchTypeList = "list"
+	SearchTypeItem = "item"
+)
+
+type SearchResult struct {
+	Type    string  `json:"type" db:"type"`
+	ListID  int64   `json:"list_id" db:"list_id"`
+	ItemID  *int64  `json:"item_id,omitempty" db:"item_id"`
+	Title   string  `json:"title" db:"title"`
+	Snippet string  `json:"snippet" db:"snippet"`
+	Rank    float64 `json:"rank" db:"rank"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+const (
+	SyncApplied  = "applied"
+	SyncConflict = "conflict"
+	SyncRejected = "rejected"
+)
+
+// SyncChanges are lists and items visible to user which changed after sync token.
+// Lists and items in trash, purged items and lists which user lost access to are deleted
+type SyncChanges struct {
+	Lists          []*List      `json:"lists"`
+	Items          []*Item      `json:"items"`
+	Members        []*UsersList `json:"members"`
+	DeletedLists   []int64      `json:"deleted_lists"`
+	DeletedItems   []int64      `json:"deleted_items"`
+	RemovedMembers []*UsersList `json:"removed_members"`
+	// pass as since parameter to get the following changes
+	Token string `json:"token"`
+}
+
+// SyncMutation is a change made by offline client. Op is one of list.create, list.update,
+// list.delete, item.create, item.update, item.done and item.delete
+type SyncMutation struct {
+	// client generated id, mutation with the same id is applied once
+	ClientID string `json:"client_id" binding:"required,max=64"`
+	Op       string `json:"op" binding:"required"`
+	ListID   int64  `json:"list_id"`
+	// client id of list.create mutation, used instead of list_id for lists created offline
+	ListClientID string `json:"list_client_id" binding:"max=64"`
+	ItemID       int64  `json:"item_id"`
+	// client id of item.create mutation, used instead of item_id for items created offline
+	ItemClientID string `json:"item_client_id" binding:"max=64"`
+	// version which client changed, mutation conflicts if server version differs
+	Version     *int64     `json:"version"`
+	Title       *string    `json:"title" binding:"omitempty,max=255"`
+	Description *string    `json:"description"`
+	AssigneeID  *int64     `json:"assignee_id"`
+	DueAt       *time.Time `json:"due_at"`
+	Priority    *int       `json:"priority"`
+}
+
+type SyncReq struct {
+	Mutations []*SyncMutation `json:"mutations" binding:"required,min=1,max=100,dive"`
+}
+
+// SyncResult is an outcome of mutation, conflict contains current server list or item
+type SyncResult struct {
+	ClientID string `json:"client_id"`
+	Status   string `json:"status"`
+	ListID   int64  `json:"list_id,omitempty"`
+	ItemID   int64  `json:"item_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+	List     *List  `json:"list,omitempty"`
+	Item     *Item  `json:"item,omitempty"`
+}
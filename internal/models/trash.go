@@ -0,0 +1,7 @@
+package models
+
+// Trash contains soft deleted lists and items which can be restored
+type Trash struct {
+	Lists []*List `json:"lists"`
+	Items []*Item `json:"items"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+const (
+	DeliveryPending = "pending"
+	DeliverySuccess = "success"
+	DeliveryFailed  = "failed"
+)
+
+type Webhook struct {
+	ID     int64  `json:"id" db:"id"`
+	ListID int64  `json:"list_id" db:"list_id"`
+	URL    string `json:"url" db:"url"`
+	Secret string `json:"-" db:"secret"`
+	// activity actions to deliver, empty means all actions
+	Events    []string  `json:"events" db:"-"`
+	Active    bool      `json:"active" db:"active"`
+	Failures  int       `json:"failures" db:"failures"`
+	CreatedBy *int64    `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type CreateWebhookReq struct {
+	URL    string `json:"url" binding:"required"`
+	Secret string `json:"secret" binding:"required"`
+	// activity actions to deliver, empty means all actions
+	Events []string `json:"events"`
+}
+
+type UpdateWebhookReq struct {
+	URL    *string   `json:"url"`
+	Secret *string   `json:"secret"`
+	Events *[]string `json:"events"`
+	// true enables disabled webhook and resets its failures
+	Active *bool `json:"active"`
+}
+
+type WebhookDelivery struct {
+	ID            int64      `json:"id" db:"id"`
+	WebhookID     int64      `json:"webhook_id" db:"webhook_id"`
+	ActivityID    int64      `json:"activity_id" db:"activity_id"`
+	Event         string     `json:"event" db:"event"`
+	Status        string     `json:"status" db:"status"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	ResponseCode  *int       `json:"response_code" db:"response_code"`
+	Error         *string    `json:"error" db:"error"`
+	NextAttemptAt *time.Time `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookJob is a pending delivery claimed by worker with data to send it
+type WebhookJob struct {
+	DeliveryID int64
+	WebhookID  int64
+	Attempts   int
+	URL        string
+	Secret     string
+	Activity   *Activity
+}
+
+// DeliveryResult is an outcome of delivery attempt, NextAttemptAt is nil if there are no more attempts
+type DeliveryResult struct {
+	DeliveryID    int64
+	WebhookID     int64
+	ResponseCode  *int
+	Error         string
+	NextAttemptAt *time.Time
+}
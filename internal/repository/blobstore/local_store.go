@@ -0,0 +1,86 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+// LocalStore keeps blobs as files under Root directory
+type LocalStore struct {
+	Root string
+}
+
+func NewLocalStore(root string) (models.BlobStore, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+
+	return &LocalStore{
+		Root: root,
+	}, nil
+}
+
+func (ls *LocalStore) path(key string) string {
+	// keys never leave Root, even if they contain ".."
+	return filepath.Join(ls.Root, filepath.FromSlash(filepath.Clean("/"+key)))
+}
+
+func (ls *LocalStore) Put(ctx context.Context, key, contentType string, r io.Reader, size int64) error {
+	path := ls.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, r)
+	if e := file.Close(); err == nil {
+		err = e
+	}
+
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	return nil
+}
+
+func (ls *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(ls.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = models.ErrNoBlob
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (ls *LocalStore) Delete(ctx context.Context, key string) error {
+	path := ls.path(key)
+
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// remove empty parent directories left after the blob
+	for dir := filepath.Dir(path); strings.HasPrefix(dir, filepath.Clean(ls.Root)+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(root)
+	require.NoError(t, err)
+
+	data := []byte("hello world")
+
+	t.Run("Put and get", func(t *testing.T) {
+		err := store.Put(context.Background(), "1/blob", "text/plain", bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+
+		r, err := store.Get(context.Background(), "1/blob")
+		require.NoError(t, err)
+		defer r.Close()
+
+		res, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, data, res)
+	})
+
+	t.Run("Put existing key", func(t *testing.T) {
+		err := store.Put(context.Background(), "1/blob", "text/plain", bytes.NewReader(data), int64(len(data)))
+		require.Error(t, err)
+	})
+
+	t.Run("Key can't leave root", func(t *testing.T) {
+		err := store.Put(context.Background(), "../../outside", "text/plain", bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+
+		_, err = os.Stat(filepath.Join(root, "outside"))
+		require.NoError(t, err)
+		require.NoError(t, store.Delete(context.Background(), "../../outside"))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(context.Background(), "1/blob"))
+
+		_, err := store.Get(context.Background(), "1/blob")
+		require.Equal(t, models.ErrNoBlob, err)
+
+		_, err = os.Stat(filepath.Join(root, "1"))
+		require.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Delete unknown key", func(t *testing.T) {
+		require.NoError(t, store.Delete(context.Background(), "2/unknown"))
+	})
+}
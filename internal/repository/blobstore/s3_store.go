@@ -0,0 +1,81 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Store keeps blobs in a bucket of any S3-compatible storage (AWS S3, MinIO)
+type S3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3Client(endpoint, accessKey, secretKey string, useSSL bool) (*minio.Client, error) {
+	return minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+}
+
+// NewS3Store returns S3Store and creates bucket if it doesn't exist
+func NewS3Store(client *minio.Client, bucket string) (models.BlobStore, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &S3Store{
+		client: client,
+		bucket: bucket,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key, contentType string, r io.Reader, size int64) error {
+	_, err := s.client.PutObject(
+		ctx, s.bucket, key, r, size,
+		minio.PutObjectOptions{ContentType: contentType},
+	)
+
+	return err
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(
+		ctx, s.bucket, key, minio.GetObjectOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// GetObject is lazy, Stat makes the request and reports missing keys
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			err = models.ErrNoBlob
+		}
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(
+		ctx, s.bucket, key, minio.RemoveObjectOptions{},
+	)
+}
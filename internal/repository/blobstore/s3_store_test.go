@@ -0,0 +1,45 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+// TestS3Store runs against a local MinIO, see docker/docker-compose-it-tests.yml
+func TestS3Store(t *testing.T) {
+	endpoint := os.Getenv("S3_ENDPOINT")
+	if testing.Short() || endpoint == "" {
+		t.Skip("S3_ENDPOINT is not set")
+	}
+
+	client, err := NewS3Client(
+		endpoint, os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"), false,
+	)
+	require.NoError(t, err)
+
+	store, err := NewS3Store(client, "todo-test")
+	require.NoError(t, err)
+
+	data := []byte("hello world")
+
+	err = store.Put(context.Background(), "1/blob", "text/plain", bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	r, err := store.Get(context.Background(), "1/blob")
+	require.NoError(t, err)
+	res, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, data, res)
+
+	require.NoError(t, store.Delete(context.Background(), "1/blob"))
+
+	_, err = store.Get(context.Background(), "1/blob")
+	require.Equal(t, models.ErrNoBlob, err)
+}
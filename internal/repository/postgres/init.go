@@ -2,16 +2,23 @@ package postgres
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 )
 
-func NewDB(pgHost, pgPort, pgUser, pgPassword, pgDB, pgSSL string) (*sqlx.DB, error) {
+// NewDB connects to postgres. Non zero queryTimeout is set as statement_timeout
+// of connections, so server cancels queries which run longer
+func NewDB(pgHost, pgPort, pgUser, pgPassword, pgDB, pgSSL string, queryTimeout time.Duration) (*sqlx.DB, error) {
 	pgConnString := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		pgHost, pgPort, pgUser, pgPassword, pgDB, pgSSL,
 	)
 
+	if queryTimeout > 0 {
+		pgConnString += fmt.Sprintf(" statement_timeout=%d", queryTimeout.Milliseconds())
+	}
+
 	return sqlx.Connect("postgres", pgConnString)
 }
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+// sortColumn is a not null sort expression and type to cast cursor value to
+type sortColumn struct {
+	expr string
+	cast string
+}
+
+// cursor points to the last row of a page
+type cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+func encodeCursor(c *cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, models.ErrBadCursor
+	}
+
+	c := &cursor{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, models.ErrBadCursor
+	}
+	return c, nil
+}
+
+// keyset builds parts of query for keyset pagination ordered by sort column and id
+type keyset struct {
+	page   *models.PageReq
+	column sortColumn
+	idExpr string
+	cursor *cursor
+}
+
+func newKeyset(page *models.PageReq, columns map[string]sortColumn, idExpr string) (*keyset, error) {
+	column, ok := columns[page.Sort]
+	if !ok {
+		return nil, models.ErrBadSort
+	}
+
+	ks := &keyset{page: page, column: column, idExpr: idExpr}
+
+	if page.Cursor != "" {
+		c, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Sort != page.Sort || c.Desc != page.Desc {
+			return nil, models.ErrBadCursor
+		}
+		ks.cursor = c
+	}
+
+	return ks, nil
+}
+
+// SortKey returns select expression of sort value
+func (ks *keyset) SortKey() string {
+	return fmt.Sprintf("(%s)::text AS sort_key", ks.column.expr)
+}
+
+// Where returns condition for rows after cursor and appends its arguments to args
+func (ks *keyset) Where(args []interface{}) (string, []interface{}) {
+	if ks.cursor == nil {
+		return "", args
+	}
+
+	op := ">"
+	if ks.page.Desc {
+		op = "<"
+	}
+
+	cond := fmt.Sprintf(
+		" AND (%s, %s) %s ($%d::%s, $%d)",
+		ks.column.expr, ks.idExpr, op, len(args)+1, ks.column.cast, len(args)+2,
+	)
+
+	return cond, append(args, ks.cursor.Value, ks.cursor.ID)
+}
+
+// OrderLimit returns order by and limit clauses, one extra row is requested to find next page
+func (ks *keyset) OrderLimit(args []interface{}) (string, []interface{}) {
+	dir := "ASC"
+	if ks.page.Desc {
+		dir = "DESC"
+	}
+
+	clause := fmt.Sprintf(
+		" ORDER BY %s %s, %s %s LIMIT $%d",
+		ks.column.expr, dir, ks.idExpr, dir, len(args)+1,
+	)
+
+	return clause, append(args, ks.page.Limit+1)
+}
+
+// NextCursor returns cursor to next page or empty string if there are no more rows
+func (ks *keyset) NextCursor(rows int, lastKey string, lastID int64) string {
+	if rows <= ks.page.Limit {
+		return ""
+	}
+
+	return encodeCursor(&cursor{
+		Sort:  ks.page.Sort,
+		Desc:  ks.page.Desc,
+		Value: lastKey,
+		ID:    lastID,
+	})
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+const activityColumns = "a.id, a.list_id, a.item_id, a.user_id, a.member_id, a.action, a.changes, a.created_at"
+
+var activitySortColumns = map[string]sortColumn{
+	models.SortCreatedAt: {"a.created_at", "timestamptz"},
+}
+
+type activityRow struct {
+	models.Activity
+	SortKey string `db:"sort_key"`
+}
+
+type PostgresActivityRepository struct {
+	DB *sqlx.DB
+}
+
+func NewPostgresActivityRepository(db *sqlx.DB) models.ActivityRepository {
+	return &PostgresActivityRepository{
+		DB: db,
+	}
+}
+
+// Create saves activity and sets its id and creation time.
+// Deliveries to matching webhooks of the list are queued by the same statement
+func (ar *PostgresActivityRepository) Create(ctx context.Context, activity *models.Activity) error {
+	ctx, span := startSpan(ctx, "PostgresActivityRepository.Create")
+	defer span.End()
+
+	return conn(ctx, ar.DB).QueryRowContext(ctx,
+		`WITH a AS (
+			INSERT INTO activity(list_id, item_id, user_id, member_id, action, changes)
+			VALUES($1, $2, $3, $4, $5, $6) RETURNING id, list_id, action, created_at
+		), d AS (
+			INSERT INTO webhook_deliveries(webhook_id, activity_id, event)
+			SELECT w.id, a.id, a.action FROM webhooks w INNER JOIN a ON w.list_id = a.list_id
+			WHERE w.active AND (cardinality(w.events) = 0 OR a.action = ANY(w.events))
+		)
+		SELECT id, created_at FROM a`,
+		activity.ListID, activity.ItemID, activity.UserID, activity.MemberID,
+		activity.Action, activity.Changes,
+	).Scan(&activity.ID, &activity.CreatedAt)
+}
+
+// GetActivity returns page of list activity and cursor to the next page
+func (ar *PostgresActivityRepository) GetActivity(ctx context.Context, listID int64, page *models.PageReq) ([]*models.Activity, string, error) {
+	ctx, span := startSpan(ctx, "PostgresActivityRepository.GetActivity")
+	defer span.End()
+
+	ks, err := newKeyset(page, activitySortColumns, "a.id")
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := "SELECT " + activityColumns + ", " + ks.SortKey() + " FROM activity a WHERE a.list_id=$1"
+	args := []interface{}{listID}
+
+	cond, args := ks.Where(args)
+	orderLimit, args := ks.OrderLimit(args)
+
+	rows := []*activityRow{}
+	err = conn(ctx, ar.DB).SelectContext(ctx, &rows, query+cond+orderLimit, args...)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	res := []*models.Activity{}
+	for i := 0; i < len(rows) && i < page.Limit; i++ {
+		res = append(res, &rows[i].Activity)
+	}
+
+	next := ""
+	if len(res) > 0 {
+		last := rows[len(res)-1]
+		next = ks.NextCursor(len(rows), last.SortKey, last.ID)
+	}
+
+	return res, next, nil
+}
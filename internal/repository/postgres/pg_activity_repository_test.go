@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityCreate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ar := NewPostgresActivityRepository(db)
+
+	userID := int64(2)
+	itemID := int64(3)
+	changes := models.Changes{}
+	changes.Add("title", "old", "new")
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		activity *models.Activity
+		setMock  func(m sqlmock.Sqlmock, e error)
+		retErr   error
+		expErr   error
+		expID    int64
+	}{
+		{
+			name:     "QueryRow return error",
+			activity: &models.Activity{ListID: 1, UserID: &userID, Action: models.ActionListCreate},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("INSERT INTO activity").
+					WithArgs(1, nil, userID, nil, models.ActionListCreate, nil).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success create with changes",
+			activity: &models.Activity{
+				ListID: 1, ItemID: &itemID, UserID: &userID,
+				Action: models.ActionItemUpdate, Changes: changes,
+			},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt)
+				m.ExpectQuery("INSERT INTO activity").
+					WithArgs(
+						1, itemID, userID, nil, models.ActionItemUpdate,
+						[]byte(`{"title":{"before":"old","after":"new"}}`),
+					).
+					WillReturnRows(rows)
+			},
+			expID: 7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			err := ar.Create(context.Background(), tc.activity)
+			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				require.Equal(t, tc.expID, tc.activity.ID)
+				require.Equal(t, createdAt, tc.activity.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestGetActivity(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ar := NewPostgresActivityRepository(db)
+
+	columns := []string{"id", "list_id", "action", "changes", "sort_key"}
+	expRes := []*models.Activity{
+		{
+			ID: 2, ListID: 1, Action: models.ActionItemUpdate,
+			Changes: models.Changes{"done": {Before: false, After: true}},
+		},
+		{ID: 1, ListID: 1, Action: models.ActionListCreate},
+	}
+
+	tests := []struct {
+		name    string
+		page    *models.PageReq
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  []*models.Activity
+		expNext string
+	}{
+		{
+			name:    "Unsupported sort",
+			page:    &models.PageReq{Limit: 10, Sort: models.SortTitle},
+			setMock: func(m sqlmock.Sqlmock, e error) {},
+			expErr:  models.ErrBadSort,
+		},
+		{
+			name: "Return unknown error",
+			page: &models.PageReq{Limit: 10, Sort: models.SortCreatedAt},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM activity").
+					WithArgs(1, 11).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success get page with next cursor",
+			page: &models.PageReq{Limit: 1, Sort: models.SortCreatedAt, Desc: true},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 1, models.ActionItemUpdate, []byte(`{"done":{"before":false,"after":true}}`), "t2").
+					AddRow(1, 1, models.ActionListCreate, nil, "t1")
+				m.ExpectQuery(regexp.QuoteMeta(
+					"WHERE a.list_id=$1 ORDER BY a.created_at DESC, a.id DESC LIMIT $2",
+				)).
+					WithArgs(1, 2).
+					WillReturnRows(rows)
+			},
+			expRes:  expRes[:1],
+			expNext: encodeCursor(&cursor{Sort: models.SortCreatedAt, Desc: true, Value: "t2", ID: 2}),
+		},
+		{
+			name: "Success get last page",
+			page: &models.PageReq{Limit: 10, Sort: models.SortCreatedAt, Desc: true},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 1, models.ActionItemUpdate, []byte(`{"done":{"before":false,"after":true}}`), "t2").
+					AddRow(1, 1, models.ActionListCreate, nil, "t1")
+				m.ExpectQuery("SELECT (.+) FROM activity").
+					WithArgs(1, 11).
+					WillReturnRows(rows)
+			},
+			expRes: expRes,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, next, err := ar.GetActivity(context.Background(), 1, tc.page)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+			require.Equal(t, tc.expNext, next)
+		})
+	}
+}
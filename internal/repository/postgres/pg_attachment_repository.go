@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type PostgresAttachmentRepository struct {
+	DB *sqlx.DB
+}
+
+func NewPostgresAttachmentRepository(db *sqlx.DB) models.AttachmentRepository {
+	return &PostgresAttachmentRepository{
+		DB: db,
+	}
+}
+
+func (ar *PostgresAttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) (int64, error) {
+	ctx, span := startSpan(ctx, "PostgresAttachmentRepository.Create")
+	defer span.End()
+
+	var attachmentID int64
+
+	err := ar.DB.QueryRowContext(ctx,
+		`INSERT INTO attachments(item_id, user_id, file_name, content_type, size, storage_key)
+		 VALUES($1, $2, $3, $4, $5, $6) RETURNING id`,
+		attachment.ItemID, attachment.UserID, attachment.FileName,
+		attachment.ContentType, attachment.Size, attachment.StorageKey,
+	).Scan(&attachmentID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return attachmentID, nil
+}
+
+func (ar *PostgresAttachmentRepository) GetAttachments(ctx context.Context, itemID int64) ([]*models.Attachment, error) {
+	ctx, span := startSpan(ctx, "PostgresAttachmentRepository.GetAttachments")
+	defer span.End()
+
+	res := []*models.Attachment{}
+
+	err := ar.DB.SelectContext(ctx,
+		&res,
+		`SELECT id, item_id, user_id, file_name, content_type, size, storage_key
+		 FROM attachments WHERE item_id=$1 ORDER BY id`,
+		itemID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ar *PostgresAttachmentRepository) GetAttachmentByID(ctx context.Context, itemID, attachmentID int64) (*models.Attachment, error) {
+	ctx, span := startSpan(ctx, "PostgresAttachmentRepository.GetAttachmentByID")
+	defer span.End()
+
+	res := &models.Attachment{}
+
+	err := ar.DB.GetContext(ctx,
+		res,
+		`SELECT id, item_id, user_id, file_name, content_type, size, storage_key
+		 FROM attachments WHERE item_id=$1 AND id=$2`,
+		itemID, attachmentID,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = models.ErrNoAttachment
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ar *PostgresAttachmentRepository) Delete(ctx context.Context, attachmentID int64) error {
+	ctx, span := startSpan(ctx, "PostgresAttachmentRepository.Delete")
+	defer span.End()
+
+	res, err := ar.DB.ExecContext(ctx, "DELETE FROM attachments WHERE id=$1", attachmentID)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return models.ErrNoAttachment
+	}
+
+	return nil
+}
+
+// GetDeletedKeys returns storage keys queued by the attachments delete trigger
+func (ar *PostgresAttachmentRepository) GetDeletedKeys(ctx context.Context, limit int) ([]string, error) {
+	ctx, span := startSpan(ctx, "PostgresAttachmentRepository.GetDeletedKeys")
+	defer span.End()
+
+	res := []string{}
+
+	err := ar.DB.SelectContext(ctx, &res, "SELECT storage_key FROM deleted_blobs LIMIT $1", limit)
+
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ar *PostgresAttachmentRepository) RemoveDeletedKeys(ctx context.Context, keys []string) error {
+	ctx, span := startSpan(ctx, "PostgresAttachmentRepository.RemoveDeletedKeys")
+	defer span.End()
+
+	_, err := ar.DB.ExecContext(ctx,
+		"DELETE FROM deleted_blobs WHERE storage_key = ANY($1)", pq.Array(keys),
+	)
+
+	return err
+}
@@ -0,0 +1,311 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAttachment = &models.Attachment{
+		ID:          1,
+		ItemID:      testItem.ID,
+		UserID:      1,
+		FileName:    "image.png",
+		ContentType: "image/png",
+		Size:        100,
+		StorageKey:  "1/key",
+	}
+	attachmentColumns = []string{
+		"id", "item_id", "user_id", "file_name", "content_type", "size", "storage_key",
+	}
+)
+
+func TestAttachmentCreate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ar := NewPostgresAttachmentRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expID   int64
+	}{
+		{
+			name: "QueryRow return error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("INSERT INTO attachments").
+					WithArgs(
+						testAttachment.ItemID, testAttachment.UserID, testAttachment.FileName,
+						testAttachment.ContentType, testAttachment.Size, testAttachment.StorageKey,
+					).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+			expID:  0,
+		},
+		{
+			name: "Success create",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(5)
+				m.ExpectQuery("INSERT INTO attachments").
+					WithArgs(
+						testAttachment.ItemID, testAttachment.UserID, testAttachment.FileName,
+						testAttachment.ContentType, testAttachment.Size, testAttachment.StorageKey,
+					).
+					WillReturnRows(rows)
+			},
+			retErr: nil,
+			expErr: nil,
+			expID:  5,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			id, err := ar.Create(context.Background(), testAttachment)
+			require.Equal(t, tc.expID, id)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestGetAttachments(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ar := NewPostgresAttachmentRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  []*models.Attachment
+	}{
+		{
+			name: "Return unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM attachments").
+					WithArgs(testItem.ID).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+			expRes: nil,
+		},
+		{
+			name: "Success get attachments",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows(attachmentColumns).AddRow(
+					testAttachment.ID, testAttachment.ItemID, testAttachment.UserID,
+					testAttachment.FileName, testAttachment.ContentType,
+					testAttachment.Size, testAttachment.StorageKey,
+				)
+				m.ExpectQuery("SELECT (.+) FROM attachments").
+					WithArgs(testItem.ID).
+					WillReturnRows(rows)
+			},
+			retErr: nil,
+			expErr: nil,
+			expRes: []*models.Attachment{testAttachment},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := ar.GetAttachments(context.Background(), testItem.ID)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestGetAttachmentByID(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ar := NewPostgresAttachmentRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  *models.Attachment
+	}{
+		{
+			name: "Attachment not found",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM attachments").
+					WithArgs(testItem.ID, testAttachment.ID).
+					WillReturnError(e)
+			},
+			retErr: sql.ErrNoRows,
+			expErr: models.ErrNoAttachment,
+			expRes: nil,
+		},
+		{
+			name: "Success get",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows(attachmentColumns).AddRow(
+					testAttachment.ID, testAttachment.ItemID, testAttachment.UserID,
+					testAttachment.FileName, testAttachment.ContentType,
+					testAttachment.Size, testAttachment.StorageKey,
+				)
+				m.ExpectQuery("SELECT (.+) FROM attachments").
+					WithArgs(testItem.ID, testAttachment.ID).
+					WillReturnRows(rows)
+			},
+			retErr: nil,
+			expErr: nil,
+			expRes: testAttachment,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := ar.GetAttachmentByID(context.Background(), testItem.ID, testAttachment.ID)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ar := NewPostgresAttachmentRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name: "Delete unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM attachments").
+					WithArgs(1).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Delete return ErrNoAttachment",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM attachments").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			retErr: nil,
+			expErr: models.ErrNoAttachment,
+		},
+		{
+			name: "Success delete",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM attachments").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			retErr: nil,
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+
+			err := ar.Delete(context.Background(), 1)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDeletedKeys(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ar := NewPostgresAttachmentRepository(db)
+	keys := []string{"1/a", "2/b"}
+
+	t.Run("Get deleted keys", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"storage_key"}).AddRow(keys[0]).AddRow(keys[1])
+		mock.ExpectQuery("SELECT storage_key FROM deleted_blobs").
+			WithArgs(10).
+			WillReturnRows(rows)
+
+		res, err := ar.GetDeletedKeys(context.Background(), 10)
+		require.NoError(t, err)
+		require.Equal(t, keys, res)
+	})
+
+	t.Run("Get deleted keys return error", func(t *testing.T) {
+		mock.ExpectQuery("SELECT storage_key FROM deleted_blobs").
+			WithArgs(10).
+			WillReturnError(ErrUnknown)
+
+		_, err := ar.GetDeletedKeys(context.Background(), 10)
+		require.Equal(t, ErrUnknown, err)
+	})
+
+	t.Run("Remove deleted keys", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM deleted_blobs").
+			WithArgs(pq.Array(keys)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		require.NoError(t, ar.RemoveDeletedKeys(context.Background(), keys))
+	})
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type PostgresCommentRepository struct {
+	DB *sqlx.DB
+}
+
+func NewPostgresCommentRepository(db *sqlx.DB) models.CommentRepository {
+	return &PostgresCommentRepository{
+		DB: db,
+	}
+}
+
+func (cr *PostgresCommentRepository) Create(ctx context.Context, itemID, userID int64, body string) (int64, error) {
+	ctx, span := startSpan(ctx, "PostgresCommentRepository.Create")
+	defer span.End()
+
+	var commentID int64
+
+	err := cr.DB.QueryRowContext(ctx,
+		`INSERT INTO comments(item_id, user_id, body) VALUES($1, $2, $3) RETURNING id`,
+		itemID, userID, body,
+	).Scan(&commentID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return commentID, nil
+}
+
+func (cr *PostgresCommentRepository) GetComments(ctx context.Context, itemID int64) ([]*models.Comment, error) {
+	ctx, span := startSpan(ctx, "PostgresCommentRepository.GetComments")
+	defer span.End()
+
+	res := []*models.Comment{}
+
+	err := cr.DB.SelectContext(ctx,
+		&res,
+		`SELECT c.id, c.item_id, c.user_id, u.email AS author, c.body
+		 FROM comments c INNER JOIN users u ON c.user_id = u.id
+		 WHERE c.item_id=$1 ORDER BY c.id`,
+		itemID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (cr *PostgresCommentRepository) GetCommentByID(ctx context.Context, itemID, commentID int64) (*models.Comment, error) {
+	ctx, span := startSpan(ctx, "PostgresCommentRepository.GetCommentByID")
+	defer span.End()
+
+	res := &models.Comment{}
+
+	err := cr.DB.GetContext(ctx,
+		res,
+		`SELECT c.id, c.item_id, c.user_id, u.email AS author, c.body
+		 FROM comments c INNER JOIN users u ON c.user_id = u.id
+		 WHERE c.item_id=$1 AND c.id=$2`,
+		itemID, commentID,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = models.ErrNoComment
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (cr *PostgresCommentRepository) Update(ctx context.Context, commentID int64, body string) error {
+	ctx, span := startSpan(ctx, "PostgresCommentRepository.Update")
+	defer span.End()
+
+	res, err := cr.DB.ExecContext(ctx, "UPDATE comments SET body=$1 WHERE id=$2", body, commentID)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return models.ErrNoComment
+	}
+
+	return nil
+}
+
+func (cr *PostgresCommentRepository) Delete(ctx context.Context, commentID int64) error {
+	ctx, span := startSpan(ctx, "PostgresCommentRepository.Delete")
+	defer span.End()
+
+	res, err := cr.DB.ExecContext(ctx, "DELETE FROM comments WHERE id=$1", commentID)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return models.ErrNoComment
+	}
+
+	return nil
+}
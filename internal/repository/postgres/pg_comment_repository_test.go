@@ -0,0 +1,330 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testComment = &models.Comment{
+		ID:     1,
+		ItemID: testItem.ID,
+		UserID: 1,
+		Author: "author@mail.ru",
+		Body:   "comment body",
+	}
+)
+
+func TestCommentCreate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	cr := NewPostgresCommentRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expID   int64
+	}{
+		{
+			name: "QueryRow return error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("INSERT INTO comments").
+					WithArgs(1, 1, "body").
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+			expID:  0,
+		},
+		{
+			name: "Success create",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(7)
+				m.ExpectQuery("INSERT INTO comments").
+					WithArgs(1, 1, "body").
+					WillReturnRows(rows)
+			},
+			retErr: nil,
+			expErr: nil,
+			expID:  7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			id, err := cr.Create(context.Background(), 1, 1, "body")
+			require.Equal(t, tc.expID, id)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	cr := NewPostgresCommentRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  []*models.Comment
+	}{
+		{
+			name: "Return unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM comments").
+					WithArgs(testItem.ID).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+			expRes: nil,
+		},
+		{
+			name: "Success get comments",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows(
+					[]string{"id", "item_id", "user_id", "author", "body"},
+				).AddRow(
+					testComment.ID, testComment.ItemID, testComment.UserID,
+					testComment.Author, testComment.Body,
+				)
+				m.ExpectQuery("SELECT (.+) FROM comments").
+					WithArgs(testItem.ID).
+					WillReturnRows(rows)
+			},
+			retErr: nil,
+			expErr: nil,
+			expRes: []*models.Comment{testComment},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := cr.GetComments(context.Background(), testItem.ID)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestGetCommentByID(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	cr := NewPostgresCommentRepository(db)
+
+	tests := []struct {
+		name       string
+		setMock    func(m sqlmock.Sqlmock, e error)
+		retErr     error
+		expErr     error
+		expComment *models.Comment
+	}{
+		{
+			name: "Return unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM comments").
+					WithArgs(testItem.ID, testComment.ID).
+					WillReturnError(e)
+			},
+			retErr:     ErrUnknown,
+			expErr:     ErrUnknown,
+			expComment: nil,
+		},
+		{
+			name: "Comment not found",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM comments").
+					WithArgs(testItem.ID, testComment.ID).
+					WillReturnError(e)
+			},
+			retErr:     sql.ErrNoRows,
+			expErr:     models.ErrNoComment,
+			expComment: nil,
+		},
+		{
+			name: "Success get",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows(
+					[]string{"id", "item_id", "user_id", "author", "body"},
+				).AddRow(
+					testComment.ID, testComment.ItemID, testComment.UserID,
+					testComment.Author, testComment.Body,
+				)
+				m.ExpectQuery("SELECT (.+) FROM comments").
+					WithArgs(testItem.ID, testComment.ID).
+					WillReturnRows(rows)
+			},
+			retErr:     nil,
+			expErr:     nil,
+			expComment: testComment,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := cr.GetCommentByID(context.Background(), testItem.ID, testComment.ID)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expComment, res)
+		})
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	cr := NewPostgresCommentRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name: "Update unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE comments").
+					WithArgs("body", 1).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Update return ErrNoComment",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE comments").
+					WithArgs("body", 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			retErr: nil,
+			expErr: models.ErrNoComment,
+		},
+		{
+			name: "Success update",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE comments").
+					WithArgs("body", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			retErr: nil,
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+
+			err := cr.Update(context.Background(), 1, "body")
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	cr := NewPostgresCommentRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name: "Delete unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM comments").
+					WithArgs(1).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Delete return ErrNoComment",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM comments").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			retErr: nil,
+			expErr: models.ErrNoComment,
+		},
+		{
+			name: "Success delete",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM comments").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			retErr: nil,
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+
+			err := cr.Delete(context.Background(), 1)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type PostgresHealthChecker struct {
+	DB *sqlx.DB
+}
+
+func NewPostgresHealthChecker(db *sqlx.DB) models.HealthChecker {
+	return &PostgresHealthChecker{DB: db}
+}
+
+func (hc *PostgresHealthChecker) Ping(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "PostgresHealthChecker.Ping")
+	defer span.End()
+
+	return hc.DB.PingContext(ctx)
+}
@@ -1,14 +1,44 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/VladimirStepanov/todo-app/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+const itemColumns = `i.id, i.list_id, i.title, i.description, i.done, i.assignee_id,
+	i.due_at, i.priority, i.created_at, i.updated_at, i.created_by, i.updated_by, i.version, i.deleted_at,
+	(SELECT COUNT(*) FROM comments c WHERE c.item_id = i.id) AS comments_count`
+
+const selectItems = "SELECT " + itemColumns + " FROM items i"
+
+var itemSortColumns = map[string]sortColumn{
+	models.SortCreatedAt: {"i.created_at", "timestamptz"},
+	models.SortTitle:     {"i.title", "text"},
+	models.SortDueAt:     {"coalesce(i.due_at, 'infinity'::timestamptz)", "timestamptz"},
+	models.SortPriority:  {"i.priority", "smallint"},
+}
+
+type itemRow struct {
+	models.Item
+	SortKey string `db:"sort_key"`
+}
+
+// isAssigneeViolation reports if err is caused by assignee who is not a list member
+func isAssigneeViolation(err error) bool {
+	if pgErr, ok := err.(*pq.Error); ok {
+		return pgErr.Code.Name() == "foreign_key_violation" &&
+			pgErr.Constraint == "fk_items_assignee_member"
+	}
+	return false
+}
+
 type PostgresItemRepository struct {
 	DB *sqlx.DB
 }
@@ -19,25 +49,86 @@ func NewPostgresItemRepository(db *sqlx.DB) models.ItemRepository {
 	}
 }
 
-func (ir *PostgresItemRepository) Create(title, description string, listID int64) (int64, error) {
+func (ir *PostgresItemRepository) Create(ctx context.Context, listID, userID int64, item *models.CreateItemReq) (int64, error) {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.Create")
+	defer span.End()
+
 	var itemID int64
 
-	err := ir.DB.QueryRow(
-		`INSERT INTO items(list_id, title, description) VALUES($1, $2, $3) RETURNING id`,
-		listID, title, description,
+	err := conn(ctx, ir.DB).QueryRowContext(ctx,
+		`INSERT INTO items(list_id, title, description, assignee_id, due_at, priority, created_by, updated_by)
+		 VALUES($1, $2, $3, $4, $5, $6, $7, $7) RETURNING id`,
+		listID, item.Title, item.Description, item.AssigneeID, item.DueAt, item.Priority, userID,
 	).Scan(&itemID)
 
 	if err != nil {
+		if isAssigneeViolation(err) {
+			err = models.ErrNotListMember
+		}
 		return 0, err
 	}
 
 	return itemID, nil
 }
 
-func (ir *PostgresItemRepository) GetItems(listID int64) ([]*models.Item, error) {
+// GetItems returns page of list items and cursor to the next page
+func (ir *PostgresItemRepository) GetItems(ctx context.Context, listID int64, filter *models.ItemFilter, page *models.PageReq) ([]*models.Item, string, error) {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.GetItems")
+	defer span.End()
+
+	ks, err := newKeyset(page, itemSortColumns, "i.id")
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := "SELECT " + itemColumns + ", " + ks.SortKey() +
+		" FROM items i WHERE i.list_id=$1 AND i.deleted_at IS NULL"
+	args := []interface{}{listID}
+
+	if filter.Done != nil {
+		args = append(args, *filter.Done)
+		query += fmt.Sprintf(" AND i.done=$%d", len(args))
+	}
+
+	cond, args := ks.Where(args)
+	orderLimit, args := ks.OrderLimit(args)
+
+	rows := []*itemRow{}
+	err = conn(ctx, ir.DB).SelectContext(ctx, &rows, query+cond+orderLimit, args...)
+
+	if err != nil {
+		return nil, "", err
+	}
+
 	res := []*models.Item{}
+	for i := 0; i < len(rows) && i < page.Limit; i++ {
+		res = append(res, &rows[i].Item)
+	}
 
-	err := ir.DB.Select(&res, `SELECT * FROM items WHERE list_id=$1`, listID)
+	next := ""
+	if len(res) > 0 {
+		last := rows[len(res)-1]
+		next = ks.NextCursor(len(rows), last.SortKey, last.ID)
+	}
+
+	return res, next, nil
+}
+
+// GetUserItems returns items from all user lists, only assigned to user if onlyAssigned
+func (ir *PostgresItemRepository) GetUserItems(ctx context.Context, userID int64, onlyAssigned bool) ([]*models.Item, error) {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.GetUserItems")
+	defer span.End()
+
+	res := []*models.Item{}
+
+	query := selectItems + ` INNER JOIN users_lists ul ON i.list_id = ul.list_id
+		INNER JOIN lists l ON i.list_id = l.id
+		WHERE ul.user_id=$1 AND i.deleted_at IS NULL AND l.deleted_at IS NULL`
+	if onlyAssigned {
+		query += " AND i.assignee_id=$1"
+	}
+
+	err := conn(ctx, ir.DB).SelectContext(ctx, &res, query+" ORDER BY i.id", userID)
 
 	if err != nil {
 		return nil, err
@@ -45,10 +136,24 @@ func (ir *PostgresItemRepository) GetItems(listID int64) ([]*models.Item, error)
 	return res, nil
 }
 
-func (ir *PostgresItemRepository) GetItemByID(listID, itemID int64) (*models.Item, error) {
+func (ir *PostgresItemRepository) GetItemByID(ctx context.Context, listID, itemID int64) (*models.Item, error) {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.GetItemByID")
+	defer span.End()
+
+	return ir.getItem(ctx, listID, itemID, "")
+}
+
+func (ir *PostgresItemRepository) GetItemForUpdate(ctx context.Context, listID, itemID int64) (*models.Item, error) {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.GetItemForUpdate")
+	defer span.End()
+
+	return ir.getItem(ctx, listID, itemID, " FOR UPDATE OF i")
+}
+
+func (ir *PostgresItemRepository) getItem(ctx context.Context, listID, itemID int64, lock string) (*models.Item, error) {
 	res := &models.Item{}
 
-	err := ir.DB.Get(res, "SELECT * FROM items WHERE list_id=$1 AND id=$2", listID, itemID)
+	err := conn(ctx, ir.DB).GetContext(ctx, res, selectItems+" WHERE i.list_id=$1 AND i.id=$2 AND i.deleted_at IS NULL"+lock, listID, itemID)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -59,7 +164,10 @@ func (ir *PostgresItemRepository) GetItemByID(listID, itemID int64) (*models.Ite
 	return res, nil
 }
 
-func (ir *PostgresItemRepository) Update(listID, itemID int64, item *models.UpdateItemReq) error {
+func (ir *PostgresItemRepository) Update(ctx context.Context, listID, itemID, userID int64, item *models.UpdateItemReq) error {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.Update")
+	defer span.End()
+
 	updObj := Updater{
 		args:    []interface{}{},
 		queries: []string{},
@@ -78,16 +186,46 @@ func (ir *PostgresItemRepository) Update(listID, itemID int64, item *models.Upda
 		updObj.addUpdateItem("done", *item.Done)
 	}
 
+	if item.DueAt != nil {
+		if item.DueAt.IsZero() {
+			updObj.addUpdateItem("due_at", nil)
+		} else {
+			updObj.addUpdateItem("due_at", *item.DueAt)
+		}
+	}
+
+	if item.Priority != nil {
+		updObj.addUpdateItem("priority", *item.Priority)
+	}
+
+	if item.AssigneeID != nil {
+		if *item.AssigneeID == 0 {
+			updObj.addUpdateItem("assignee_id", nil)
+		} else {
+			updObj.addUpdateItem("assignee_id", *item.AssigneeID)
+		}
+	}
+
+	updObj.addUpdateItem("updated_by", userID)
+	updObj.queries = append(updObj.queries, "version=version+1")
+
 	query := fmt.Sprintf(
-		"UPDATE items SET %s WHERE id=$%d AND list_id=$%d",
+		"UPDATE items SET %s WHERE id=$%d AND list_id=$%d AND deleted_at IS NULL",
 		strings.Join(updObj.queries, ","),
 		updObj.index, updObj.index+1,
 	)
 
 	updObj.args = append(updObj.args, itemID, listID)
-	res, err := ir.DB.Exec(query, updObj.args...)
+	if item.Version != nil {
+		query += fmt.Sprintf(" AND version=$%d", updObj.index+2)
+		updObj.args = append(updObj.args, *item.Version)
+	}
+	res, err := conn(ctx, ir.DB).ExecContext(ctx, query, updObj.args...)
 
 	if err != nil {
+		if isAssigneeViolation(err) {
+			err = models.ErrNotListMember
+		}
 		return err
 	}
 
@@ -98,14 +236,98 @@ func (ir *PostgresItemRepository) Update(listID, itemID int64, item *models.Upda
 	}
 
 	if ra == 0 {
+		return ir.notAffectedErr(ctx, listID, itemID, item.Version)
+	}
+
+	return nil
+}
+
+// notAffectedErr returns ErrVersionMismatch if item exists but has another version, ErrNoItem otherwise
+func (ir *PostgresItemRepository) notAffectedErr(ctx context.Context, listID, itemID int64, version *int64) error {
+	if version == nil {
 		return models.ErrNoItem
 	}
 
+	var exists bool
+	err := conn(ctx, ir.DB).GetContext(ctx,
+		&exists,
+		"SELECT EXISTS(SELECT 1 FROM items WHERE id=$1 AND list_id=$2 AND deleted_at IS NULL)",
+		itemID, listID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return models.ErrVersionMismatch
+	}
+	return models.ErrNoItem
+}
+
+// Delete moves item to trash
+func (ir *PostgresItemRepository) Delete(ctx context.Context, listID, itemID int64, version *int64) error {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.Delete")
+	defer span.End()
+
+	query := `UPDATE items SET deleted_at=now(), version=version+1
+		WHERE id=$1 AND list_id=$2 AND deleted_at IS NULL`
+	args := []interface{}{itemID, listID}
+	if version != nil {
+		query += " AND version=$3"
+		args = append(args, *version)
+	}
+
+	res, err := conn(ctx, ir.DB).ExecContext(ctx, query, args...)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return ir.notAffectedErr(ctx, listID, itemID, version)
+	}
+
 	return nil
 }
 
-func (ir *PostgresItemRepository) Delete(listID, itemID int64) error {
-	res, err := ir.DB.Exec("DELETE FROM items WHERE id=$1 AND list_id=$2", itemID, listID)
+// GetDeletedItems returns items in trash from not deleted lists of user
+func (ir *PostgresItemRepository) GetDeletedItems(ctx context.Context, userID int64) ([]*models.Item, error) {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.GetDeletedItems")
+	defer span.End()
+
+	res := []*models.Item{}
+
+	err := conn(ctx, ir.DB).SelectContext(ctx,
+		&res,
+		selectItems+` INNER JOIN users_lists ul ON i.list_id = ul.list_id
+		INNER JOIN lists l ON i.list_id = l.id
+		WHERE ul.user_id=$1 AND i.deleted_at IS NOT NULL AND l.deleted_at IS NULL
+		ORDER BY i.deleted_at DESC, i.id`,
+		userID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Restore moves item from trash
+func (ir *PostgresItemRepository) Restore(ctx context.Context, listID, itemID, userID int64) error {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.Restore")
+	defer span.End()
+
+	res, err := conn(ctx, ir.DB).ExecContext(ctx,
+		`UPDATE items SET deleted_at=NULL, version=version+1, updated_by=$3
+		 WHERE id=$1 AND list_id=$2 AND deleted_at IS NOT NULL`,
+		itemID, listID, userID,
+	)
 
 	if err != nil {
 		return err
@@ -123,3 +345,17 @@ func (ir *PostgresItemRepository) Delete(listID, itemID int64) error {
 
 	return nil
 }
+
+// Purge permanently removes items deleted before the time
+func (ir *PostgresItemRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, "PostgresItemRepository.Purge")
+	defer span.End()
+
+	res, err := conn(ctx, ir.DB).ExecContext(ctx, "DELETE FROM items WHERE deleted_at < $1", before)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
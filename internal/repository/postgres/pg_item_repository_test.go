@@ -1,13 +1,16 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/VladimirStepanov/todo-app/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -46,19 +49,30 @@ func TestItemCreate(t *testing.T) {
 			name: "QueryRow return error",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				m.ExpectQuery("INSERT INTO items").
-					WithArgs(1, "title", "description").
+					WithArgs(1, "title", "description", nil, nil, 0, 2).
 					WillReturnError(e)
 			},
 			retErr: ErrUnknown,
 			expErr: ErrUnknown,
 			expID:  0,
 		},
+		{
+			name: "Assignee is not a list member",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("INSERT INTO items").
+					WithArgs(1, "title", "description", nil, nil, 0, 2).
+					WillReturnError(e)
+			},
+			retErr: &pq.Error{Code: "23503", Constraint: "fk_items_assignee_member"},
+			expErr: models.ErrNotListMember,
+			expID:  0,
+		},
 		{
 			name: "Success create",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(retID)
 				m.ExpectQuery("INSERT INTO items").
-					WithArgs(1, "title", "description").
+					WithArgs(1, "title", "description", nil, nil, 0, 2).
 					WillReturnRows(rows)
 			},
 			retErr: nil,
@@ -70,7 +84,7 @@ func TestItemCreate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
-			id, err := ir.Create("title", "description", 1)
+			id, err := ir.Create(context.Background(), 1, 2, &models.CreateItemReq{Title: "title", Description: "description"})
 			require.Equal(t, tc.expID, id)
 			require.Equal(t, tc.expErr, err)
 		})
@@ -100,7 +114,7 @@ func TestGetItem(t *testing.T) {
 		{
 			name: "Return unknown error",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectQuery(regexp.QuoteMeta("SELECT * FROM items")).
+				m.ExpectQuery(regexp.QuoteMeta("SELECT i.id, i.list_id, i.title, i.description, i.done")).
 					WithArgs(testList.ID, 1).
 					WillReturnError(e)
 			},
@@ -111,7 +125,7 @@ func TestGetItem(t *testing.T) {
 		{
 			name: "Item not found",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectQuery(regexp.QuoteMeta("SELECT * FROM items")).
+				m.ExpectQuery(regexp.QuoteMeta("SELECT i.id, i.list_id, i.title, i.description, i.done")).
 					WithArgs(testList.ID, 1).
 					WillReturnError(e)
 			},
@@ -123,9 +137,9 @@ func TestGetItem(t *testing.T) {
 			name: "Success get",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				rows := sqlmock.NewRows(
-					[]string{"id", "list_id", "title", "description", "done"},
-				).AddRow(testItem.ID, testItem.ID, testItem.Title, testItem.Description, testItem.Done)
-				m.ExpectQuery(regexp.QuoteMeta("SELECT * FROM items")).
+					[]string{"id", "list_id", "title", "description", "done", "comments_count"},
+				).AddRow(testItem.ID, testItem.ID, testItem.Title, testItem.Description, testItem.Done, testItem.CommentsCount)
+				m.ExpectQuery(regexp.QuoteMeta("SELECT i.id, i.list_id, i.title, i.description, i.done")).
 					WithArgs(testList.ID, testItem.ID).
 					WillReturnRows(rows)
 			},
@@ -139,7 +153,7 @@ func TestGetItem(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
 
-			retItem, err := ir.GetItemByID(testList.ID, 1)
+			retItem, err := ir.GetItemByID(context.Background(), testList.ID, 1)
 			require.Equal(t, tc.expErr, err)
 
 			if err == nil {
@@ -149,6 +163,32 @@ func TestGetItem(t *testing.T) {
 	}
 }
 
+func TestGetItemForUpdate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ir := NewPostgresItemRepository(db)
+
+	rows := sqlmock.NewRows(
+		[]string{"id", "list_id", "title", "description", "done"},
+	).AddRow(testItem.ID, testItem.ListID, testItem.Title, testItem.Description, testItem.Done)
+	mock.ExpectQuery("SELECT (.+) FROM items i WHERE (.+) FOR UPDATE OF i").
+		WithArgs(testList.ID, 1).
+		WillReturnRows(rows)
+
+	retItem, err := ir.GetItemForUpdate(context.Background(), testList.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, testItem, retItem)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDeleteItem(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 
@@ -162,16 +202,19 @@ func TestDeleteItem(t *testing.T) {
 
 	ir := NewPostgresItemRepository(db)
 
+	version := int64(3)
+
 	tests := []struct {
 		name    string
 		setMock func(m sqlmock.Sqlmock, e error)
+		version *int64
 		retErr  error
 		expErr  error
 	}{
 		{
 			name: "Delete unknown error",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectExec("DELETE FROM items").
+				m.ExpectExec("UPDATE items SET deleted_at=now\\(\\)").
 					WithArgs(1, 1).
 					WillReturnError(e)
 			},
@@ -181,17 +224,56 @@ func TestDeleteItem(t *testing.T) {
 		{
 			name: "Delete return ErrNoItem",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectExec("DELETE FROM items").
+				m.ExpectExec("UPDATE items SET deleted_at=now\\(\\)").
 					WithArgs(1, 1).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			retErr: nil,
 			expErr: models.ErrNoItem,
 		},
+		{
+			name: "Delete return ErrVersionMismatch",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET deleted_at=now\\(\\)(.+) AND version=\\$3").
+					WithArgs(1, 1, version).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery("SELECT EXISTS").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			version: &version,
+			retErr:  nil,
+			expErr:  models.ErrVersionMismatch,
+		},
+		{
+			name: "Delete with version return ErrNoItem",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET deleted_at=now\\(\\)(.+) AND version=\\$3").
+					WithArgs(1, 1, version).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery("SELECT EXISTS").
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			version: &version,
+			retErr:  nil,
+			expErr:  models.ErrNoItem,
+		},
+		{
+			name: "Success delete with version",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET deleted_at=now\\(\\)(.+) AND version=\\$3").
+					WithArgs(1, 1, version).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			version: &version,
+			retErr:  nil,
+			expErr:  nil,
+		},
 		{
 			name: "Success delete",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectExec("DELETE FROM items").
+				m.ExpectExec("UPDATE items SET deleted_at=now\\(\\)").
 					WithArgs(1, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
@@ -204,7 +286,7 @@ func TestDeleteItem(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
 
-			err := ir.Delete(1, 1)
+			err := ir.Delete(context.Background(), 1, 1, tc.version)
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -242,7 +324,7 @@ func TestUpdateItem(t *testing.T) {
 			name: "Update unknown error",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				m.ExpectExec("UPDATE items").
-					WithArgs(*req.Title, *req.Description, *req.Done, 1, 1).
+					WithArgs(*req.Title, *req.Description, *req.Done, 2, 1, 1).
 					WillReturnError(e)
 			},
 			retErr: ErrUnknown,
@@ -252,7 +334,7 @@ func TestUpdateItem(t *testing.T) {
 			name: "Update return ErrNoItem",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				m.ExpectExec("UPDATE items").
-					WithArgs(*req.Title, *req.Description, *req.Done, 1, 1).
+					WithArgs(*req.Title, *req.Description, *req.Done, 2, 1, 1).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			retErr: nil,
@@ -262,7 +344,7 @@ func TestUpdateItem(t *testing.T) {
 			name: "Success update",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				m.ExpectExec("UPDATE items").
-					WithArgs(*req.Title, *req.Description, *req.Done, 1, 1).
+					WithArgs(*req.Title, *req.Description, *req.Done, 2, 1, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			retErr: nil,
@@ -273,7 +355,67 @@ func TestUpdateItem(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
-			err := ir.Update(1, 1, req)
+			err := ir.Update(context.Background(), 1, 1, 2, req)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestUpdateItemAssignee(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ir := NewPostgresItemRepository(db)
+
+	tests := []struct {
+		name       string
+		assigneeID int64
+		setMock    func(m sqlmock.Sqlmock, e error)
+		retErr     error
+		expErr     error
+	}{
+		{
+			name:       "Assignee is not a list member",
+			assigneeID: 5,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET assignee_id").
+					WithArgs(5, 2, 1, 1).
+					WillReturnError(e)
+			},
+			retErr: &pq.Error{Code: "23503", Constraint: "fk_items_assignee_member"},
+			expErr: models.ErrNotListMember,
+		},
+		{
+			name:       "Success assign",
+			assigneeID: 5,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET assignee_id").
+					WithArgs(5, 2, 1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:       "Success unassign",
+			assigneeID: 0,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET assignee_id").
+					WithArgs(nil, 2, 1, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			err := ir.Update(context.Background(), 1, 1, 2, &models.UpdateItemReq{AssigneeID: &tc.assigneeID})
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -305,17 +447,149 @@ func TestGetItems(t *testing.T) {
 		},
 	}
 
+	done := true
+	dueCursor := encodeCursor(&cursor{Sort: models.SortDueAt, Desc: true, Value: "infinity", ID: 5})
+
 	tests := []struct {
 		name    string
+		filter  *models.ItemFilter
+		page    *models.PageReq
 		setMock func(m sqlmock.Sqlmock, e error)
 		retErr  error
 		expErr  error
 		expRes  []*models.Item
+		expNext string
 	}{
 		{
-			name: "Return unknown error",
+			name:    "Unsupported sort",
+			filter:  &models.ItemFilter{},
+			page:    &models.PageReq{Limit: 10, Sort: "id"},
+			setMock: func(m sqlmock.Sqlmock, e error) {},
+			expErr:  models.ErrBadSort,
+		},
+		{
+			name:    "Bad cursor",
+			filter:  &models.ItemFilter{},
+			page:    &models.PageReq{Limit: 10, Sort: models.SortTitle, Cursor: "!!!"},
+			setMock: func(m sqlmock.Sqlmock, e error) {},
+			expErr:  models.ErrBadCursor,
+		},
+		{
+			name:    "Cursor of other sort",
+			filter:  &models.ItemFilter{},
+			page:    &models.PageReq{Limit: 10, Sort: models.SortTitle, Cursor: dueCursor},
+			setMock: func(m sqlmock.Sqlmock, e error) {},
+			expErr:  models.ErrBadCursor,
+		},
+		{
+			name:   "Return unknown error",
+			filter: &models.ItemFilter{},
+			page:   &models.PageReq{Limit: 10, Sort: models.SortCreatedAt},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery(regexp.QuoteMeta("SELECT i.id, i.list_id, i.title, i.description, i.done")).
+					WithArgs(1, 11).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name:   "Success get last page",
+			filter: &models.ItemFilter{},
+			page:   &models.PageReq{Limit: 10, Sort: models.SortCreatedAt},
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectQuery(regexp.QuoteMeta("SELECT * FROM items")).
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "sort_key"})
+				for _, r := range expItems {
+					rows.AddRow(r.ID, r.Title, r.Description, "2021-01-01 00:00:00+00")
+				}
+				m.ExpectQuery(regexp.QuoteMeta("WHERE i.list_id=$1 AND i.deleted_at IS NULL ORDER BY i.created_at ASC, i.id ASC LIMIT $2")).
+					WithArgs(1, 11).
+					WillReturnRows(rows)
+			},
+			expRes: expItems,
+		},
+		{
+			name:   "Success get page with next cursor",
+			filter: &models.ItemFilter{},
+			page:   &models.PageReq{Limit: 1, Sort: models.SortTitle},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "sort_key"})
+				for _, r := range expItems {
+					rows.AddRow(r.ID, r.Title, r.Description, r.Title)
+				}
+				m.ExpectQuery(regexp.QuoteMeta("ORDER BY i.title ASC, i.id ASC LIMIT $2")).
+					WithArgs(1, 2).
+					WillReturnRows(rows)
+			},
+			expRes:  expItems[:1],
+			expNext: encodeCursor(&cursor{Sort: models.SortTitle, Value: "title#1", ID: 1}),
+		},
+		{
+			name:   "Success get page after cursor with done filter",
+			filter: &models.ItemFilter{Done: &done},
+			page:   &models.PageReq{Limit: 10, Sort: models.SortDueAt, Desc: true, Cursor: dueCursor},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "sort_key"}).
+					AddRow(expItems[1].ID, expItems[1].Title, expItems[1].Description, "infinity")
+				m.ExpectQuery(regexp.QuoteMeta(
+					"WHERE i.list_id=$1 AND i.deleted_at IS NULL AND i.done=$2 AND "+
+						"(coalesce(i.due_at, 'infinity'::timestamptz), i.id) < ($3::timestamptz, $4) "+
+						"ORDER BY coalesce(i.due_at, 'infinity'::timestamptz) DESC, i.id DESC LIMIT $5",
+				)).
+					WithArgs(1, true, "infinity", 5, 11).
+					WillReturnRows(rows)
+			},
+			expRes: expItems[1:],
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, next, err := ir.GetItems(context.Background(), 1, tc.filter, tc.page)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+			require.Equal(t, tc.expNext, next)
+		})
+	}
+}
+
+func TestGetUserItems(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ir := NewPostgresItemRepository(db)
+
+	expItems := []*models.Item{
+		{
+			ID:          1,
+			ListID:      1,
+			Title:       "title#1",
+			Description: "description#1",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		onlyAssigned bool
+		query        string
+		setMock      func(m sqlmock.Sqlmock, query string, e error)
+		retErr       error
+		expErr       error
+		expRes       []*models.Item
+	}{
+		{
+			name:  "Return unknown error",
+			query: "WHERE ul.user_id=$1 AND i.deleted_at IS NULL AND l.deleted_at IS NULL ORDER BY",
+			setMock: func(m sqlmock.Sqlmock, query string, e error) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
 					WithArgs(1).
 					WillReturnError(e)
 			},
@@ -324,28 +598,137 @@ func TestGetItems(t *testing.T) {
 			expRes: nil,
 		},
 		{
-			name: "Success get items",
-			setMock: func(m sqlmock.Sqlmock, e error) {
-				rows := sqlmock.NewRows([]string{"id", "title", "description"})
+			name:  "Success get all items",
+			query: "WHERE ul.user_id=$1 AND i.deleted_at IS NULL AND l.deleted_at IS NULL ORDER BY",
+			setMock: func(m sqlmock.Sqlmock, query string, e error) {
+				rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description"})
+				for _, r := range expItems {
+					rows.AddRow(r.ID, r.ListID, r.Title, r.Description)
+				}
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			expRes: expItems,
+		},
+		{
+			name:         "Success get assigned items",
+			onlyAssigned: true,
+			query:        "AND l.deleted_at IS NULL AND i.assignee_id=$1 ORDER BY",
+			setMock: func(m sqlmock.Sqlmock, query string, e error) {
+				rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description"})
 				for _, r := range expItems {
-					rows.AddRow(r.ID, r.Title, r.Description)
+					rows.AddRow(r.ID, r.ListID, r.Title, r.Description)
 				}
-				m.ExpectQuery(regexp.QuoteMeta("SELECT * FROM items")).
+				m.ExpectQuery(regexp.QuoteMeta(query)).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
+			expRes: expItems,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.query, tc.retErr)
+			res, err := ir.GetUserItems(context.Background(), 1, tc.onlyAssigned)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestRestoreItem(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ir := NewPostgresItemRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name: "Restore unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET deleted_at=NULL").
+					WithArgs(3, 1, 2).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Restore return ErrNoItem",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET deleted_at=NULL").
+					WithArgs(3, 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			retErr: nil,
+			expErr: models.ErrNoItem,
+		},
+		{
+			name: "Success restore",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE items SET deleted_at=NULL").
+					WithArgs(3, 1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
 			retErr: nil,
 			expErr: nil,
-			expRes: expItems,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
-			res, err := ir.GetItems(1)
+
+			err := ir.Restore(context.Background(), 1, 3, 2)
 			require.Equal(t, tc.expErr, err)
-			require.Equal(t, tc.expRes, res)
 		})
 	}
 }
+
+func TestPurgeItems(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	ir := NewPostgresItemRepository(db)
+	before := time.Now()
+
+	t.Run("Purge return error", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM items WHERE deleted_at <").
+			WithArgs(before).
+			WillReturnError(ErrUnknown)
+
+		_, err := ir.Purge(context.Background(), before)
+		require.Equal(t, ErrUnknown, err)
+	})
+
+	t.Run("Success purge", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM items WHERE deleted_at <").
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		n, err := ir.Purge(context.Background(), before)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), n)
+	})
+}
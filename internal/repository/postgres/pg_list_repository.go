@@ -1,15 +1,29 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/VladimirStepanov/todo-app/internal/models"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
 
+const listColumns = "id, title, description, created_at, updated_at, created_by, updated_by, version, deleted_at, archived_at"
+
+var listSortColumns = map[string]sortColumn{
+	models.SortCreatedAt: {"l.created_at", "timestamptz"},
+	models.SortTitle:     {"l.title", "text"},
+}
+
+type listRow struct {
+	models.List
+	SortKey string `db:"sort_key"`
+}
+
 type PostgresListRepository struct {
 	DB *sqlx.DB
 }
@@ -20,53 +34,61 @@ func NewPostgresListRepository(db *sqlx.DB) models.ListRepository {
 	}
 }
 
-func (ls *PostgresListRepository) Create(title, description string, userID int64) (int64, error) {
-	tx, err := ls.DB.Beginx()
-	if err != nil {
-		return 0, err
-	}
+func (ls *PostgresListRepository) Create(ctx context.Context, title, description string, userID int64) (int64, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.Create")
+	defer span.End()
 
 	var listID int64
 
-	err = tx.QueryRow(
-		"INSERT INTO lists(title, description) VALUES($1, $2) RETURNING id",
-		title, description,
-	).Scan(&listID)
+	err := withTx(ctx, ls.DB, func(ctx context.Context, tx querier) error {
+		err := tx.QueryRowContext(ctx,
+			`INSERT INTO lists(title, description, created_by, updated_by)
+			 VALUES($1, $2, $3, $3) RETURNING id`,
+			title, description, userID,
+		).Scan(&listID)
 
-	if err != nil {
-		if e := tx.Rollback(); e != nil {
-			err = e
+		if err != nil {
+			return err
 		}
-		return 0, err
-	}
 
-	_, err = tx.Exec(
-		"INSERT INTO users_lists(user_id, list_id, is_admin) VALUES($1, $2, $3)",
-		userID, listID, true,
-	)
-
-	if err != nil {
-		if e := tx.Rollback(); e != nil {
-			err = e
-		}
-		return 0, err
-	}
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO users_lists(user_id, list_id, is_admin) VALUES($1, $2, $3)",
+			userID, listID, true,
+		)
+		return err
+	})
 
-	err = tx.Commit()
 	if err != nil {
 		return 0, err
 	}
 	return listID, nil
 }
 
-func (ls *PostgresListRepository) IsListAdmin(ListID, userID int64) error {
+func (ls *PostgresListRepository) IsListAdmin(ctx context.Context, ListID, userID int64) error {
+	ctx, span := startSpan(ctx, "PostgresListRepository.IsListAdmin")
+	defer span.End()
+
+	return ls.isAdmin(ctx, ListID, userID, "l.deleted_at IS NULL")
+}
+
+// IsDeletedListAdmin is IsListAdmin for list in trash
+func (ls *PostgresListRepository) IsDeletedListAdmin(ctx context.Context, listID, userID int64) error {
+	ctx, span := startSpan(ctx, "PostgresListRepository.IsDeletedListAdmin")
+	defer span.End()
+
+	return ls.isAdmin(ctx, listID, userID, "l.deleted_at IS NOT NULL")
+}
+
+// isAdmin checks role of user in list which matches deleted condition
+func (ls *PostgresListRepository) isAdmin(ctx context.Context, listID, userID int64, deleted string) error {
 	us := &models.UsersList{}
 
-	err := ls.DB.Get(
+	err := conn(ctx, ls.DB).GetContext(ctx,
 		us,
-		`SELECT user_id, list_id, is_admin 
-		 FROM users_lists WHERE user_id=$1 AND list_id=$2`,
-		userID, ListID,
+		`SELECT ul.user_id, ul.list_id, ul.is_admin
+		 FROM users_lists ul INNER JOIN lists l on l.id = ul.list_id
+		 WHERE ul.user_id=$1 AND ul.list_id=$2 AND `+deleted,
+		userID, listID,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -81,68 +103,66 @@ func (ls *PostgresListRepository) IsListAdmin(ListID, userID int64) error {
 	return nil
 }
 
-func (ls *PostgresListRepository) EditRole(listID, userID int64, role bool) error {
-	tx, err := ls.DB.Beginx()
-	if err != nil {
-		return err
-	}
+func (ls *PostgresListRepository) EditRole(ctx context.Context, listID, userID int64, role bool) error {
+	ctx, span := startSpan(ctx, "PostgresListRepository.EditRole")
+	defer span.End()
 
-	rows, err := tx.Exec(
-		`UPDATE users_lists
-		 SET is_admin=$1
-		 WHERE user_id=$2 AND list_id=$3`,
-		role, userID, listID,
-	)
+	return withTx(ctx, ls.DB, func(ctx context.Context, tx querier) error {
+		rows, err := tx.ExecContext(ctx,
+			`UPDATE users_lists
+			 SET is_admin=$1
+			 WHERE user_id=$2 AND list_id=$3`,
+			role, userID, listID,
+		)
 
-	if err != nil {
-		if e := tx.Rollback(); e != nil {
-			return e
+		if err != nil {
+			return err
 		}
-		return err
-	}
 
-	ra, err := rows.RowsAffected()
-	if err != nil {
-		if e := tx.Rollback(); e != nil {
-			return e
+		ra, err := rows.RowsAffected()
+		if err != nil {
+			return err
 		}
-		return err
-	}
 
-	if ra == 0 {
-		_, err = tx.Exec(
-			`INSERT INTO users_lists (user_id, list_id, is_admin)
-			 VALUES($1, $2, $3)`, userID, listID, role,
-		)
+		if ra == 0 {
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO users_lists (user_id, list_id, is_admin)
+				 VALUES($1, $2, $3)`, userID, listID, role,
+			)
 
-		if err != nil {
 			if pgErr, ok := err.(*pq.Error); ok {
 				if pgErr.Code.Name() == "foreign_key_violation" {
 					err = models.ErrUserNotFound
 				}
 			}
-			if e := tx.Rollback(); e != nil {
-				return e
-			}
 			return err
 		}
-	}
+		return nil
+	})
+}
 
-	err = tx.Commit()
-	if err != nil {
-		return err
-	}
-	return nil
+func (ls *PostgresListRepository) GetListByID(ctx context.Context, listID, userID int64) (*models.List, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.GetListByID")
+	defer span.End()
+
+	return ls.getList(ctx, listID, userID, "")
+}
+
+func (ls *PostgresListRepository) GetListForUpdate(ctx context.Context, listID, userID int64) (*models.List, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.GetListForUpdate")
+	defer span.End()
+
+	return ls.getList(ctx, listID, userID, " FOR UPDATE OF l")
 }
 
-func (ls *PostgresListRepository) GetListByID(listID, userID int64) (*models.List, error) {
+func (ls *PostgresListRepository) getList(ctx context.Context, listID, userID int64, lock string) (*models.List, error) {
 	res := &models.List{}
 
-	err := ls.DB.Get(
+	err := conn(ctx, ls.DB).GetContext(ctx,
 		res,
-		`SELECT id, title, description 
+		`SELECT `+listColumns+`
 		 FROM lists l INNER JOIN users_lists ul on l.id = ul.list_id 
-		 WHERE ul.user_id=$1 AND ul.list_id = $2;`,
+		 WHERE ul.user_id=$1 AND ul.list_id = $2 AND l.deleted_at IS NULL`+lock,
 		userID, listID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -154,43 +174,190 @@ func (ls *PostgresListRepository) GetListByID(listID, userID int64) (*models.Lis
 	return res, nil
 }
 
-func (ls *PostgresListRepository) GetUserLists(userID int64) ([]*models.List, error) {
+// GetUserLists returns page of user lists and cursor to the next page
+func (ls *PostgresListRepository) GetUserLists(ctx context.Context, userID int64, filter *models.ListFilter, page *models.PageReq) ([]*models.List, string, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.GetUserLists")
+	defer span.End()
+
+	ks, err := newKeyset(page, listSortColumns, "l.id")
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := `SELECT ` + listColumns + `, ` + ks.SortKey() + `
+		FROM lists l INNER JOIN users_lists ul on l.id = ul.list_id
+		WHERE ul.user_id=$1 AND l.deleted_at IS NULL`
+	args := []interface{}{userID}
+
+	if filter.Archived {
+		query += " AND l.archived_at IS NOT NULL"
+	} else {
+		query += " AND l.archived_at IS NULL"
+	}
+
+	cond, args := ks.Where(args)
+	orderLimit, args := ks.OrderLimit(args)
+
+	rows := []*listRow{}
+	err = conn(ctx, ls.DB).SelectContext(ctx, &rows, query+cond+orderLimit, args...)
+
+	if err != nil {
+		return nil, "", err
+	}
+
 	res := []*models.List{}
-	err := ls.DB.Select(
+	for i := 0; i < len(rows) && i < page.Limit; i++ {
+		res = append(res, &rows[i].List)
+	}
+
+	next := ""
+	if len(res) > 0 {
+		last := rows[len(res)-1]
+		next = ks.NextCursor(len(rows), last.SortKey, last.ID)
+	}
+
+	return res, next, nil
+}
+
+// notAffectedErr returns ErrVersionMismatch if list exists but has another version, ErrNoList otherwise
+func (ls *PostgresListRepository) notAffectedErr(ctx context.Context, listID int64, version *int64) error {
+	if version == nil {
+		return models.ErrNoList
+	}
+
+	var exists bool
+	err := conn(ctx, ls.DB).GetContext(ctx,
+		&exists, "SELECT EXISTS(SELECT 1 FROM lists WHERE id=$1 AND deleted_at IS NULL)", listID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return models.ErrVersionMismatch
+	}
+	return models.ErrNoList
+}
+
+// Delete moves list to trash, its items are hidden together with list
+func (ls *PostgresListRepository) Delete(ctx context.Context, listID int64, version *int64) error {
+	ctx, span := startSpan(ctx, "PostgresListRepository.Delete")
+	defer span.End()
+
+	query := `UPDATE lists SET deleted_at=now(), version=version+1
+		WHERE id=$1 AND deleted_at IS NULL`
+	args := []interface{}{listID}
+	if version != nil {
+		query += " AND version=$2"
+		args = append(args, *version)
+	}
+
+	res, err := conn(ctx, ls.DB).ExecContext(ctx, query, args...)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return ls.notAffectedErr(ctx, listID, version)
+	}
+
+	return nil
+}
+
+func (ls *PostgresListRepository) GetMembersByEmails(ctx context.Context, listID int64, emails []string) ([]*models.User, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.GetMembersByEmails")
+	defer span.End()
+
+	res := []*models.User{}
+	err := conn(ctx, ls.DB).SelectContext(ctx,
 		&res,
-		`SELECT id, title, description
-		FROM lists l INNER JOIN users_lists ul on l.id = ul.list_id
-		WHERE ul.user_id=$1`,
-		userID,
+		`SELECT u.id, u.email
+		FROM users u INNER JOIN users_lists ul on u.id = ul.user_id
+		WHERE ul.list_id=$1 AND LOWER(u.email) = ANY($2)`,
+		listID, pq.Array(emails),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ls *PostgresListRepository) GetMember(ctx context.Context, listID, userID int64) (*models.User, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.GetMember")
+	defer span.End()
+
+	res := &models.User{}
+	err := conn(ctx, ls.DB).GetContext(ctx,
+		res,
+		`SELECT u.id, u.email
+		FROM users u INNER JOIN users_lists ul on u.id = ul.user_id
+		WHERE ul.list_id=$1 AND ul.user_id=$2`,
+		listID, userID,
 	)
 
 	if err != nil {
+		if err == sql.ErrNoRows {
+			err = models.ErrNotListMember
+		}
 		return nil, err
 	}
 
 	return res, nil
 }
 
-func (ls *PostgresListRepository) Delete(listID int64) error {
-	res, err := ls.DB.Exec("DELETE FROM lists WHERE id=$1", listID)
+// RemoveMember removes user from list and unassigns items assigned to user
+func (ls *PostgresListRepository) RemoveMember(ctx context.Context, listID, userID int64) error {
+	ctx, span := startSpan(ctx, "PostgresListRepository.RemoveMember")
+	defer span.End()
+
+	res, err := conn(ctx, ls.DB).ExecContext(ctx,
+		"DELETE FROM users_lists WHERE list_id=$1 AND user_id=$2",
+		listID, userID,
+	)
 
 	if err != nil {
 		return err
 	}
 
 	ra, err := res.RowsAffected()
-
 	if err != nil {
 		return err
 	}
 
 	if ra == 0 {
-		return models.ErrNoList
+		return models.ErrNotListMember
 	}
-
 	return nil
 }
 
+// UnassignMember removes member from assignees of list items and returns ids of the items
+func (ls *PostgresListRepository) UnassignMember(ctx context.Context, listID, userID, updatedBy int64) ([]int64, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.UnassignMember")
+	defer span.End()
+
+	itemIDs := []int64{}
+	err := conn(ctx, ls.DB).SelectContext(ctx,
+		&itemIDs,
+		`UPDATE items SET assignee_id=NULL, version=version+1, updated_by=$3
+		 WHERE list_id=$1 AND assignee_id=$2
+		 RETURNING id`,
+		listID, userID, updatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return itemIDs, nil
+}
+
 type Updater struct {
 	args    []interface{}
 	queries []string
@@ -203,7 +370,10 @@ func (u *Updater) addUpdateItem(field string, arg interface{}) {
 	u.index++
 }
 
-func (ls *PostgresListRepository) Update(listID int64, list *models.UpdateListReq) error {
+func (ls *PostgresListRepository) Update(ctx context.Context, listID, userID int64, list *models.UpdateListReq) error {
+	ctx, span := startSpan(ctx, "PostgresListRepository.Update")
+	defer span.End()
+
 	updObj := Updater{
 		args:    []interface{}{},
 		queries: []string{},
@@ -218,14 +388,73 @@ func (ls *PostgresListRepository) Update(listID int64, list *models.UpdateListRe
 		updObj.addUpdateItem("description", *list.Description)
 	}
 
+	updObj.addUpdateItem("updated_by", userID)
+	updObj.queries = append(updObj.queries, "version=version+1")
+
 	query := fmt.Sprintf(
-		"UPDATE lists SET %s WHERE id=$%d",
+		"UPDATE lists SET %s WHERE id=$%d AND deleted_at IS NULL",
 		strings.Join(updObj.queries, ","),
 		updObj.index,
 	)
 
 	updObj.args = append(updObj.args, listID)
-	res, err := ls.DB.Exec(query, updObj.args...)
+	if list.Version != nil {
+		query += fmt.Sprintf(" AND version=$%d", updObj.index+1)
+		updObj.args = append(updObj.args, *list.Version)
+	}
+	res, err := conn(ctx, ls.DB).ExecContext(ctx, query, updObj.args...)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return ls.notAffectedErr(ctx, listID, list.Version)
+	}
+
+	return nil
+}
+
+// GetDeletedLists returns lists in trash which user can restore
+func (ls *PostgresListRepository) GetDeletedLists(ctx context.Context, userID int64) ([]*models.List, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.GetDeletedLists")
+	defer span.End()
+
+	res := []*models.List{}
+
+	err := conn(ctx, ls.DB).SelectContext(ctx,
+		&res,
+		`SELECT `+listColumns+`
+		 FROM lists l INNER JOIN users_lists ul on l.id = ul.list_id
+		 WHERE ul.user_id=$1 AND ul.is_admin AND l.deleted_at IS NOT NULL
+		 ORDER BY l.deleted_at DESC, l.id`,
+		userID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Restore moves list from trash, only list admin can restore it
+func (ls *PostgresListRepository) Restore(ctx context.Context, listID, userID int64) error {
+	ctx, span := startSpan(ctx, "PostgresListRepository.Restore")
+	defer span.End()
+
+	res, err := conn(ctx, ls.DB).ExecContext(ctx,
+		`UPDATE lists l SET deleted_at=NULL, version=l.version+1, updated_by=$2
+		 FROM users_lists ul
+		 WHERE l.id=$1 AND l.deleted_at IS NOT NULL
+		 AND ul.list_id=l.id AND ul.user_id=$2 AND ul.is_admin`,
+		listID, userID,
+	)
 
 	if err != nil {
 		return err
@@ -243,3 +472,71 @@ func (ls *PostgresListRepository) Update(listID int64, list *models.UpdateListRe
 
 	return nil
 }
+
+// Purge permanently removes lists deleted before the time
+func (ls *PostgresListRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.Purge")
+	defer span.End()
+
+	res, err := conn(ctx, ls.DB).ExecContext(ctx, "DELETE FROM lists WHERE deleted_at < $1", before)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// SetArchived archives or unarchives list
+func (ls *PostgresListRepository) SetArchived(ctx context.Context, listID, userID int64, archived bool) error {
+	ctx, span := startSpan(ctx, "PostgresListRepository.SetArchived")
+	defer span.End()
+
+	value := "NULL"
+	if archived {
+		value = "now()"
+	}
+
+	res, err := conn(ctx, ls.DB).ExecContext(ctx,
+		`UPDATE lists SET archived_at=`+value+`, version=version+1, updated_by=$2
+		 WHERE id=$1 AND deleted_at IS NULL`,
+		listID, userID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return models.ErrNoList
+	}
+
+	return nil
+}
+
+func (ls *PostgresListRepository) IsListArchived(ctx context.Context, listID int64) (bool, error) {
+	ctx, span := startSpan(ctx, "PostgresListRepository.IsListArchived")
+	defer span.End()
+
+	var archived bool
+
+	err := conn(ctx, ls.DB).GetContext(ctx,
+		&archived,
+		"SELECT archived_at IS NOT NULL FROM lists WHERE id=$1 AND deleted_at IS NULL",
+		listID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = models.ErrNoList
+		}
+		return false, err
+	}
+
+	return archived, nil
+}
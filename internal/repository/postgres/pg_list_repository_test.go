@@ -1,9 +1,12 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/VladimirStepanov/todo-app/internal/models"
@@ -55,7 +58,7 @@ func TestCreate(t *testing.T) {
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				m.ExpectBegin()
 				m.ExpectQuery("INSERT INTO lists").
-					WithArgs("title", "description").
+					WithArgs("title", "description", 1).
 					WillReturnError(e)
 				m.ExpectRollback()
 			},
@@ -69,7 +72,7 @@ func TestCreate(t *testing.T) {
 				m.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 				m.ExpectQuery("INSERT INTO lists").
-					WithArgs("title", "description").
+					WithArgs("title", "description", 1).
 					WillReturnRows(rows)
 
 				m.ExpectExec("INSERT INTO users_lists").
@@ -87,7 +90,7 @@ func TestCreate(t *testing.T) {
 				m.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 				m.ExpectQuery("INSERT INTO lists").
-					WithArgs("title", "description").
+					WithArgs("title", "description", 1).
 					WillReturnRows(rows)
 				m.ExpectExec("INSERT INTO users_lists").
 					WithArgs(1, 1, true).
@@ -104,7 +107,7 @@ func TestCreate(t *testing.T) {
 				m.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 				m.ExpectQuery("INSERT INTO lists").
-					WithArgs("title", "description").
+					WithArgs("title", "description", 1).
 					WillReturnRows(rows)
 				m.ExpectExec("INSERT INTO users_lists").
 					WithArgs(1, 1, true).
@@ -120,7 +123,7 @@ func TestCreate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
-			id, err := lr.Create("title", "description", 1)
+			id, err := lr.Create(context.Background(), "title", "description", 1)
 			require.Equal(t, tc.expID, id)
 			require.Equal(t, tc.expErr, err)
 		})
@@ -150,7 +153,7 @@ func TestGetListByID(t *testing.T) {
 		{
 			name: "Return unknown error",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectQuery("SELECT id, title, description FROM lists").
+				m.ExpectQuery("SELECT id, title, description, (.+) FROM lists").
 					WithArgs(testList.ID, 1).
 					WillReturnError(e)
 			},
@@ -161,7 +164,7 @@ func TestGetListByID(t *testing.T) {
 		{
 			name: "List not found",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectQuery("SELECT id, title, description FROM lists").
+				m.ExpectQuery("SELECT id, title, description, (.+) FROM lists").
 					WithArgs(testList.ID, 1).
 					WillReturnError(e)
 			},
@@ -175,7 +178,7 @@ func TestGetListByID(t *testing.T) {
 				rows := sqlmock.NewRows(
 					[]string{"id", "title", "description"},
 				).AddRow(testList.ID, testList.Title, testList.Description)
-				m.ExpectQuery("SELECT id, title, description FROM lists").
+				m.ExpectQuery("SELECT id, title, description, (.+) FROM lists").
 					WithArgs(testList.ID, 1).
 					WillReturnRows(rows)
 			},
@@ -189,7 +192,7 @@ func TestGetListByID(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
 
-			retList, err := lr.GetListByID(testList.ID, 1)
+			retList, err := lr.GetListByID(context.Background(), testList.ID, 1)
 			require.Equal(t, tc.expErr, err)
 
 			if err == nil {
@@ -199,6 +202,32 @@ func TestGetListByID(t *testing.T) {
 	}
 }
 
+func TestGetListForUpdate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	rows := sqlmock.NewRows(
+		[]string{"id", "title", "description"},
+	).AddRow(testList.ID, testList.Title, testList.Description)
+	mock.ExpectQuery("SELECT id, title, description, (.+) FROM lists (.+) FOR UPDATE OF l").
+		WithArgs(1, testList.ID).
+		WillReturnRows(rows)
+
+	retList, err := lr.GetListForUpdate(context.Background(), testList.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, testList, retList)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestIsListAdmin(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 
@@ -221,7 +250,7 @@ func TestIsListAdmin(t *testing.T) {
 		{
 			name: "Return unknown error",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectQuery("SELECT user_id, list_id, is_admin FROM users_lists").
+				m.ExpectQuery("SELECT ul.user_id, ul.list_id, ul.is_admin FROM users_lists ul").
 					WithArgs(testList.ID, 1).
 					WillReturnError(e)
 			},
@@ -231,7 +260,7 @@ func TestIsListAdmin(t *testing.T) {
 		{
 			name: "List not found",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectQuery("SELECT user_id, list_id, is_admin FROM users_lists").
+				m.ExpectQuery("SELECT ul.user_id, ul.list_id, ul.is_admin FROM users_lists ul").
 					WithArgs(testList.ID, 1).
 					WillReturnError(e)
 			},
@@ -244,7 +273,7 @@ func TestIsListAdmin(t *testing.T) {
 				rows := sqlmock.NewRows(
 					[]string{"list_id", "user_id", "is_admin"},
 				).AddRow(1, 1, false)
-				m.ExpectQuery("SELECT user_id, list_id, is_admin FROM users_lists").
+				m.ExpectQuery("SELECT ul.user_id, ul.list_id, ul.is_admin FROM users_lists ul").
 					WithArgs(testList.ID, 1).
 					WillReturnRows(rows)
 			},
@@ -257,7 +286,7 @@ func TestIsListAdmin(t *testing.T) {
 				rows := sqlmock.NewRows(
 					[]string{"list_id", "user_id", "is_admin"},
 				).AddRow(1, 1, true)
-				m.ExpectQuery("SELECT user_id, list_id, is_admin FROM users_lists").
+				m.ExpectQuery("SELECT ul.user_id, ul.list_id, ul.is_admin FROM users_lists ul").
 					WithArgs(testList.ID, 1).
 					WillReturnRows(rows)
 			},
@@ -270,8 +299,61 @@ func TestIsListAdmin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
 
-			err := lr.IsListAdmin(testList.ID, 1)
+			err := lr.IsListAdmin(context.Background(), testList.ID, 1)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestIsDeletedListAdmin(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	tests := []struct {
+		name    string
+		isAdmin bool
+		retErr  error
+		expErr  error
+	}{
+		{
+			name:   "List not in trash",
+			retErr: sql.ErrNoRows,
+			expErr: models.ErrNoList,
+		},
+		{
+			name:    "Access error",
+			isAdmin: false,
+			expErr:  models.ErrNoListAccess,
+		},
+		{
+			name:    "Success check",
+			isAdmin: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := mock.ExpectQuery(regexp.QuoteMeta("AND l.deleted_at IS NOT NULL")).WithArgs(1, testList.ID)
+			if tc.retErr != nil {
+				q.WillReturnError(tc.retErr)
+			} else {
+				q.WillReturnRows(sqlmock.NewRows(
+					[]string{"list_id", "user_id", "is_admin"},
+				).AddRow(testList.ID, 1, tc.isAdmin))
+			}
+
+			err := lr.IsDeletedListAdmin(context.Background(), testList.ID, 1)
 			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -373,7 +455,7 @@ func TestEditRole(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
 
-			err := lr.EditRole(testList.ID, 1, true)
+			err := lr.EditRole(context.Background(), testList.ID, 1, true)
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -401,7 +483,7 @@ func TestDeleteList(t *testing.T) {
 		{
 			name: "Delete unknown error",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectExec("DELETE FROM lists").
+				m.ExpectExec("UPDATE lists SET deleted_at=now\\(\\)").
 					WithArgs(1).
 					WillReturnError(e)
 			},
@@ -411,7 +493,7 @@ func TestDeleteList(t *testing.T) {
 		{
 			name: "Delete return ErrNoList",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectExec("DELETE FROM lists").
+				m.ExpectExec("UPDATE lists SET deleted_at=now\\(\\)").
 					WithArgs(1).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -421,7 +503,7 @@ func TestDeleteList(t *testing.T) {
 		{
 			name: "Success delete",
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectExec("DELETE FROM lists").
+				m.ExpectExec("UPDATE lists SET deleted_at=now\\(\\)").
 					WithArgs(1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
@@ -434,7 +516,7 @@ func TestDeleteList(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
 
-			err := lr.Delete(1)
+			err := lr.Delete(context.Background(), 1, nil)
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -470,7 +552,7 @@ func TestUpdateList(t *testing.T) {
 			name: "Update unknown error",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				m.ExpectExec("UPDATE lists").
-					WithArgs(*req.Title, *req.Description, 1).
+					WithArgs(*req.Title, *req.Description, 2, 1).
 					WillReturnError(e)
 			},
 			retErr: ErrUnknown,
@@ -480,7 +562,7 @@ func TestUpdateList(t *testing.T) {
 			name: "Update return ErrNoList",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				m.ExpectExec("UPDATE lists").
-					WithArgs(*req.Title, *req.Description, 1).
+					WithArgs(*req.Title, *req.Description, 2, 1).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			retErr: nil,
@@ -490,7 +572,7 @@ func TestUpdateList(t *testing.T) {
 			name: "Success update",
 			setMock: func(m sqlmock.Sqlmock, e error) {
 				m.ExpectExec("UPDATE lists").
-					WithArgs(*req.Title, *req.Description, 1).
+					WithArgs(*req.Title, *req.Description, 2, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			retErr: nil,
@@ -501,8 +583,75 @@ func TestUpdateList(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
-			err := lr.Update(1, req)
+			err := lr.Update(context.Background(), 1, 2, req)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestUpdateListVersion(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	version := int64(3)
+	req := &models.UpdateListReq{Title: new(string), Version: &version}
+	*req.Title = "hello"
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock)
+		expErr  error
+	}{
+		{
+			name: "Update return ErrVersionMismatch",
+			setMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE lists SET (.+),version=version\\+1 WHERE id=\\$3 AND deleted_at IS NULL AND version=\\$4").
+					WithArgs(*req.Title, 2, 1, version).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery("SELECT EXISTS").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expErr: models.ErrVersionMismatch,
+		},
+		{
+			name: "Update return ErrNoList",
+			setMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE lists").
+					WithArgs(*req.Title, 2, 1, version).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectQuery("SELECT EXISTS").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expErr: models.ErrNoList,
+		},
+		{
+			name: "Success update",
+			setMock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE lists").
+					WithArgs(*req.Title, 2, 1, version).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock)
+			err := lr.Update(context.Background(), 1, 2, req)
 			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -533,18 +682,30 @@ func TestGetUserLists(t *testing.T) {
 		},
 	}
 
+	titleCursor := encodeCursor(&cursor{Sort: models.SortTitle, Desc: true, Value: "title#3", ID: 3})
+
 	tests := []struct {
 		name    string
+		filter  models.ListFilter
+		page    *models.PageReq
 		setMock func(m sqlmock.Sqlmock, e error)
 		retErr  error
 		expErr  error
 		expRes  []*models.List
+		expNext string
 	}{
+		{
+			name:    "Unsupported sort",
+			page:    &models.PageReq{Limit: 10, Sort: models.SortPriority},
+			setMock: func(m sqlmock.Sqlmock, e error) {},
+			expErr:  models.ErrBadSort,
+		},
 		{
 			name: "Return unknown error",
+			page: &models.PageReq{Limit: 10, Sort: models.SortCreatedAt},
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				m.ExpectQuery("SELECT id, title, description FROM lists").
-					WithArgs(1).
+				m.ExpectQuery("SELECT id, title, description, (.+) FROM lists").
+					WithArgs(1, 11).
 					WillReturnError(e)
 			},
 			retErr: ErrUnknown,
@@ -553,25 +714,515 @@ func TestGetUserLists(t *testing.T) {
 		},
 		{
 			name: "Success get user lists",
+			page: &models.PageReq{Limit: 10, Sort: models.SortCreatedAt},
 			setMock: func(m sqlmock.Sqlmock, e error) {
-				rows := sqlmock.NewRows([]string{"id", "title", "description"})
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "sort_key"})
 				for _, r := range expLists {
-					rows.AddRow(r.ID, r.Title, r.Description)
+					rows.AddRow(r.ID, r.Title, r.Description, "2021-01-01 00:00:00+00")
 				}
-				m.ExpectQuery("SELECT id, title, description FROM lists").
-					WithArgs(1).
+				m.ExpectQuery(regexp.QuoteMeta(
+					"AND l.archived_at IS NULL ORDER BY l.created_at ASC, l.id ASC LIMIT $2",
+				)).
+					WithArgs(1, 11).
 					WillReturnRows(rows)
 			},
 			retErr: nil,
 			expErr: nil,
 			expRes: expLists,
 		},
+		{
+			name:   "Success get archived lists",
+			filter: models.ListFilter{Archived: true},
+			page:   &models.PageReq{Limit: 10, Sort: models.SortCreatedAt},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "sort_key"})
+				for _, r := range expLists {
+					rows.AddRow(r.ID, r.Title, r.Description, "2021-01-01 00:00:00+00")
+				}
+				m.ExpectQuery(regexp.QuoteMeta("AND l.archived_at IS NOT NULL ORDER BY")).
+					WithArgs(1, 11).
+					WillReturnRows(rows)
+			},
+			expRes: expLists,
+		},
+		{
+			name: "Success get page after cursor with next cursor",
+			page: &models.PageReq{Limit: 1, Sort: models.SortTitle, Desc: true, Cursor: titleCursor},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "sort_key"})
+				for i := len(expLists) - 1; i >= 0; i-- {
+					r := expLists[i]
+					rows.AddRow(r.ID, r.Title, r.Description, r.Title)
+				}
+				m.ExpectQuery(regexp.QuoteMeta(
+					"AND (l.title, l.id) < ($2::text, $3) ORDER BY l.title DESC, l.id DESC LIMIT $4",
+				)).
+					WithArgs(1, "title#3", 3, 2).
+					WillReturnRows(rows)
+			},
+			expRes:  expLists[1:],
+			expNext: encodeCursor(&cursor{Sort: models.SortTitle, Desc: true, Value: "title#2", ID: 2}),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, next, err := lr.GetUserLists(context.Background(), 1, &tc.filter, tc.page)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+			require.Equal(t, tc.expNext, next)
+		})
+	}
+}
+
+func TestGetMembersByEmails(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	emails := []string{"first@mail.ru", "second@mail.ru"}
+	expUsers := []*models.User{
+		{ID: 1, Email: "first@mail.ru"},
+		{ID: 2, Email: "second@mail.ru"},
+	}
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  []*models.User
+	}{
+		{
+			name: "Return unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT u.id, u.email FROM users").
+					WithArgs(1, pq.Array(emails)).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+			expRes: nil,
+		},
+		{
+			name: "Success get members",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id", "email"})
+				for _, u := range expUsers {
+					rows.AddRow(u.ID, u.Email)
+				}
+				m.ExpectQuery("SELECT u.id, u.email FROM users").
+					WithArgs(1, pq.Array(emails)).
+					WillReturnRows(rows)
+			},
+			retErr: nil,
+			expErr: nil,
+			expRes: expUsers,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := lr.GetMembersByEmails(context.Background(), 1, emails)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestGetMember(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	expUser := &models.User{ID: 2, Email: "second@mail.ru"}
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  *models.User
+	}{
+		{
+			name: "User is not a member",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT u.id, u.email FROM users").
+					WithArgs(1, expUser.ID).
+					WillReturnError(e)
+			},
+			retErr: sql.ErrNoRows,
+			expErr: models.ErrNotListMember,
+			expRes: nil,
+		},
+		{
+			name: "Success get member",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"id", "email"}).AddRow(expUser.ID, expUser.Email)
+				m.ExpectQuery("SELECT u.id, u.email FROM users").
+					WithArgs(1, expUser.ID).
+					WillReturnRows(rows)
+			},
+			retErr: nil,
+			expErr: nil,
+			expRes: expUser,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := lr.GetMember(context.Background(), 1, expUser.ID)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name: "Delete return error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM users_lists").
+					WithArgs(1, 2).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "User is not a member",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM users_lists").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expErr: models.ErrNotListMember,
+		},
+		{
+			name: "Success remove",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM users_lists").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			err := lr.RemoveMember(context.Background(), 1, 2)
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUnassignMember(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	tests := []struct {
+		name   string
+		rows   *sqlmock.Rows
+		retErr error
+		expIDs []int64
+		expErr error
+	}{
+		{
+			name:   "Update return error",
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name:   "No assigned items",
+			rows:   sqlmock.NewRows([]string{"id"}),
+			expIDs: []int64{},
+		},
+		{
+			name:   "Success unassign",
+			rows:   sqlmock.NewRows([]string{"id"}).AddRow(7).AddRow(8),
+			expIDs: []int64{7, 8},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := mock.ExpectQuery(regexp.QuoteMeta("UPDATE items SET assignee_id=NULL, version=version+1, updated_by=$3")).
+				WithArgs(1, 2, 3)
+			if tc.retErr != nil {
+				q.WillReturnError(tc.retErr)
+			} else {
+				q.WillReturnRows(tc.rows)
+			}
+
+			ids, err := lr.UnassignMember(context.Background(), 1, 2, 3)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expIDs, ids)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRestoreList(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name: "Restore unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE lists l SET deleted_at=NULL").
+					WithArgs(1, 2).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Restore return ErrNoList",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE lists l SET deleted_at=NULL").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			retErr: nil,
+			expErr: models.ErrNoList,
+		},
+		{
+			name: "Success restore",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE lists l SET deleted_at=NULL").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			retErr: nil,
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+
+			err := lr.Restore(context.Background(), 1, 2)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestPurgeLists(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+	before := time.Now()
+
+	t.Run("Purge return error", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM lists WHERE deleted_at <").
+			WithArgs(before).
+			WillReturnError(ErrUnknown)
+
+		_, err := lr.Purge(context.Background(), before)
+		require.Equal(t, ErrUnknown, err)
+	})
+
+	t.Run("Success purge", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM lists WHERE deleted_at <").
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		n, err := lr.Purge(context.Background(), before)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), n)
+	})
+}
+
+func TestSetArchived(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	tests := []struct {
+		name     string
+		archived bool
+		setMock  func(m sqlmock.Sqlmock, e error)
+		retErr   error
+		expErr   error
+	}{
+		{
+			name:     "Archive unknown error",
+			archived: true,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE lists SET archived_at=now\\(\\)").
+					WithArgs(1, 2).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name:     "Archive return ErrNoList",
+			archived: true,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE lists SET archived_at=now\\(\\)").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expErr: models.ErrNoList,
+		},
+		{
+			name:     "Success archive",
+			archived: true,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE lists SET archived_at=now\\(\\)").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:     "Success unarchive",
+			archived: false,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("UPDATE lists SET archived_at=NULL").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+
+			err := lr.SetArchived(context.Background(), 1, 2, tc.archived)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestIsListArchived(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	lr := NewPostgresListRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  bool
+	}{
+		{
+			name: "List not found",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT archived_at IS NOT NULL FROM lists").
+					WithArgs(1).
+					WillReturnError(e)
+			},
+			retErr: sql.ErrNoRows,
+			expErr: models.ErrNoList,
+		},
+		{
+			name: "List is archived",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows([]string{"archived"}).AddRow(true)
+				m.ExpectQuery("SELECT archived_at IS NOT NULL FROM lists").
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			expRes: true,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
-			res, err := lr.GetUserLists(1)
+
+			res, err := lr.IsListArchived(context.Background(), 1)
 			require.Equal(t, tc.expErr, err)
 			require.Equal(t, tc.expRes, res)
 		})
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// options of ts_headline for result snippets
+const headlineOptions = "StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5"
+
+// escapeHTML returns sql expression which escapes html of text expression,
+// user text is escaped before ts_headline, so only its <b> markers are html
+func escapeHTML(expr string) string {
+	for _, r := range [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&#34;"}, {"'", "&#39;"}} {
+		expr = "replace(" + expr + ", '" + strings.ReplaceAll(r[0], "'", "''") + "', '" + r[1] + "')"
+	}
+	return expr
+}
+
+type PostgresSearchRepository struct {
+	DB *sqlx.DB
+}
+
+func NewPostgresSearchRepository(db *sqlx.DB) models.SearchRepository {
+	return &PostgresSearchRepository{
+		DB: db,
+	}
+}
+
+// Search returns lists and items of user matched by query, ordered by rank.
+// lang is a postgres text search configuration name
+func (sr *PostgresSearchRepository) Search(ctx context.Context, userID int64, query, lang string, limit int) ([]*models.SearchResult, error) {
+	ctx, span := startSpan(ctx, "PostgresSearchRepository.Search")
+	defer span.End()
+
+	res := []*models.SearchResult{}
+
+	err := sr.DB.SelectContext(ctx,
+		&res,
+		`SELECT type, list_id, item_id, title, snippet, rank FROM (
+			SELECT 'list' AS type, l.id AS list_id, NULL::integer AS item_id, l.title,
+				ts_headline($2::regconfig, `+escapeHTML("l.title || ' ' || l.description")+`, q, $5) AS snippet,
+				ts_rank(l.search_vector, q) AS rank
+			FROM lists l
+			INNER JOIN users_lists ul ON l.id = ul.list_id,
+			websearch_to_tsquery($2::regconfig, $3) q
+			WHERE ul.user_id=$1 AND l.deleted_at IS NULL AND l.search_vector @@ q
+			UNION ALL
+			SELECT 'item' AS type, i.list_id, i.id AS item_id, i.title,
+				ts_headline($2::regconfig, `+escapeHTML("i.title || ' ' || i.description")+`, q, $5) AS snippet,
+				ts_rank(i.search_vector, q) AS rank
+			FROM items i
+			INNER JOIN users_lists ul ON i.list_id = ul.list_id
+			INNER JOIN lists l ON i.list_id = l.id,
+			websearch_to_tsquery($2::regconfig, $3) q
+			WHERE ul.user_id=$1 AND i.deleted_at IS NULL AND l.deleted_at IS NULL
+			AND i.search_vector @@ q
+		) r ORDER BY rank DESC, list_id, item_id NULLS FIRST LIMIT $4`,
+		userID, lang, query, limit, headlineOptions,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
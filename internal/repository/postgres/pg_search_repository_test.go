@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	sr := NewPostgresSearchRepository(db)
+
+	itemID := testItem.ID
+	expRes := []*models.SearchResult{
+		{
+			Type:    models.SearchTypeList,
+			ListID:  testList.ID,
+			Title:   "Shopping",
+			Snippet: "<b>Shopping</b> list",
+			Rank:    0.6,
+		},
+		{
+			Type:    models.SearchTypeItem,
+			ListID:  testList.ID,
+			ItemID:  &itemID,
+			Title:   "Milk",
+			Snippet: "Milk from <b>shop</b>",
+			Rank:    0.2,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  []*models.SearchResult
+	}{
+		{
+			name: "Return unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) websearch_to_tsquery").
+					WithArgs(1, "english", "shop", 50, headlineOptions).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+			expRes: nil,
+		},
+		{
+			name: "Success search",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows(
+					[]string{"type", "list_id", "item_id", "title", "snippet", "rank"},
+				)
+				for _, r := range expRes {
+					rows.AddRow(r.Type, r.ListID, r.ItemID, r.Title, r.Snippet, r.Rank)
+				}
+				m.ExpectQuery("SELECT (.+) websearch_to_tsquery").
+					WithArgs(1, "english", "shop", 50, headlineOptions).
+					WillReturnRows(rows)
+			},
+			retErr: nil,
+			expErr: nil,
+			expRes: expRes,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := sr.Search(context.Background(), 1, "shop", "english", 50)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestEscapeHTML(t *testing.T) {
+	require.Equal(t,
+		`replace(replace(replace(replace(replace(l.title, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`,
+		escapeHTML("l.title"),
+	)
+}
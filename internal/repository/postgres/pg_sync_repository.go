@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type tombstoneRow struct {
+	ListID int64  `db:"list_id"`
+	ItemID *int64 `db:"item_id"`
+	UserID *int64 `db:"user_id"`
+}
+
+type PostgresSyncRepository struct {
+	DB *sqlx.DB
+}
+
+func NewPostgresSyncRepository(db *sqlx.DB) models.SyncRepository {
+	return &PostgresSyncRepository{
+		DB: db,
+	}
+}
+
+// GetChanges reads all changes from one snapshot. Membership change is taken into account,
+// so new member gets all rows of the list regardless of their own transaction.
+// Token is the oldest transaction in progress at the snapshot: its rows and rows of later
+// transactions are invisible or may be, so next call reads them again
+func (sr *PostgresSyncRepository) GetChanges(ctx context.Context, userID, since int64) (*models.SyncChanges, int64, error) {
+	ctx, span := startSpan(ctx, "PostgresSyncRepository.GetChanges")
+	defer span.End()
+
+	tx, err := sr.DB.BeginTxx(
+		ctx,
+		&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true},
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, token, err := getChanges(ctx, tx, userID, since)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, 0, fmt.Errorf("%w (rollback: %v)", err, e)
+		}
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, err
+	}
+
+	return res, token, nil
+}
+
+func getChanges(ctx context.Context, tx *sqlx.Tx, userID, since int64) (*models.SyncChanges, int64, error) {
+	res := &models.SyncChanges{
+		Lists:          []*models.List{},
+		Items:          []*models.Item{},
+		Members:        []*models.UsersList{},
+		DeletedLists:   []int64{},
+		DeletedItems:   []int64{},
+		RemovedMembers: []*models.UsersList{},
+	}
+
+	// first query takes snapshot of the transaction
+	var token int64
+	err := tx.GetContext(ctx, &token, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lists := []*models.List{}
+	err = tx.SelectContext(ctx,
+		&lists,
+		`SELECT `+listColumns+`
+		 FROM lists l INNER JOIN users_lists ul ON l.id = ul.list_id
+		 WHERE ul.user_id=$1 AND greatest(l.change_xid, ul.change_xid) >= $2::text::xid8`,
+		userID, since,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, row := range lists {
+		if row.DeletedAt != nil {
+			res.DeletedLists = append(res.DeletedLists, row.ID)
+		} else {
+			res.Lists = append(res.Lists, row)
+		}
+	}
+
+	err = tx.SelectContext(ctx,
+		&res.Members,
+		`SELECT m.user_id, m.list_id, m.is_admin
+		 FROM users_lists m INNER JOIN users_lists ul ON m.list_id = ul.list_id
+		 INNER JOIN lists l ON m.list_id = l.id
+		 WHERE ul.user_id=$1 AND l.deleted_at IS NULL
+		 AND greatest(m.change_xid, ul.change_xid) >= $2::text::xid8`,
+		userID, since,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := []*models.Item{}
+	err = tx.SelectContext(ctx,
+		&items,
+		`SELECT `+itemColumns+`
+		 FROM items i INNER JOIN users_lists ul ON i.list_id = ul.list_id
+		 INNER JOIN lists l ON i.list_id = l.id
+		 WHERE ul.user_id=$1 AND l.deleted_at IS NULL
+		 AND greatest(i.change_xid, ul.change_xid) >= $2::text::xid8`,
+		userID, since,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, row := range items {
+		if row.DeletedAt != nil {
+			res.DeletedItems = append(res.DeletedItems, row.ID)
+		} else {
+			res.Items = append(res.Items, row)
+		}
+	}
+
+	// client without token has nothing to delete
+	if since == 0 {
+		return res, token, nil
+	}
+
+	tombstones := []*tombstoneRow{}
+	err = tx.SelectContext(ctx,
+		&tombstones,
+		`SELECT t.list_id, t.item_id, t.user_id FROM sync_tombstones t
+		 WHERE t.change_xid >= $2::text::xid8
+		 AND (t.user_id=$1 OR t.list_id IN (SELECT list_id FROM users_lists WHERE user_id=$1))
+		 AND NOT EXISTS (
+			SELECT 1 FROM users_lists m WHERE m.user_id = t.user_id AND m.list_id = t.list_id
+		 )`,
+		userID, since,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, row := range tombstones {
+		switch {
+		case row.ItemID != nil:
+			res.DeletedItems = append(res.DeletedItems, *row.ItemID)
+		case *row.UserID == userID:
+			res.DeletedLists = append(res.DeletedLists, row.ListID)
+		default:
+			res.RemovedMembers = append(
+				res.RemovedMembers, &models.UsersList{ListID: row.ListID, UserID: *row.UserID},
+			)
+		}
+	}
+
+	return res, token, nil
+}
+
+func (sr *PostgresSyncRepository) GetMutation(ctx context.Context, userID int64, clientID string) (*models.SyncResult, error) {
+	ctx, span := startSpan(ctx, "PostgresSyncRepository.GetMutation")
+	defer span.End()
+
+	var listID int64
+	var itemID sql.NullInt64
+
+	err := conn(ctx, sr.DB).QueryRowContext(ctx,
+		"SELECT list_id, item_id FROM sync_mutations WHERE user_id=$1 AND client_id=$2",
+		userID, clientID,
+	).Scan(&listID, &itemID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = models.ErrNoSyncMutation
+		}
+		return nil, err
+	}
+
+	return &models.SyncResult{
+		ClientID: clientID,
+		Status:   models.SyncApplied,
+		ListID:   listID,
+		ItemID:   itemID.Int64,
+	}, nil
+}
+
+// SaveMutation saves mutation in transaction of its change. Insert waits for transaction
+// which saves the same mutation, so concurrent retry of client is detected
+func (sr *PostgresSyncRepository) SaveMutation(ctx context.Context, userID int64, result *models.SyncResult) error {
+	ctx, span := startSpan(ctx, "PostgresSyncRepository.SaveMutation")
+	defer span.End()
+
+	itemID := sql.NullInt64{Int64: result.ItemID, Valid: result.ItemID != 0}
+
+	res, err := conn(ctx, sr.DB).ExecContext(ctx,
+		`INSERT INTO sync_mutations(user_id, client_id, list_id, item_id)
+		 VALUES($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+		userID, result.ClientID, result.ListID, itemID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return models.ErrSyncMutationApplied
+	}
+
+	return nil
+}
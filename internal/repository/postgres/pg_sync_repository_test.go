@@ -0,0 +1,277 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSyncChanges(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	sr := NewPostgresSyncRepository(db)
+
+	deletedAt := time.Now()
+	tokenRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"pg_snapshot_xmin"}).AddRow(25)
+	}
+	listRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "title", "description", "deleted_at"}).
+			AddRow(testList.ID, testList.Title, testList.Description, nil).
+			AddRow(2, "deleted", "list", deletedAt)
+	}
+	memberRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"user_id", "list_id", "is_admin"}).
+			AddRow(1, testList.ID, true)
+	}
+	itemRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "list_id", "title", "description", "deleted_at"}).
+			AddRow(testItem.ID, testItem.ListID, testItem.Title, testItem.Description, nil).
+			AddRow(2, testItem.ListID, "deleted", "item", deletedAt)
+	}
+
+	tests := []struct {
+		name     string
+		since    int64
+		setMock  func(m sqlmock.Sqlmock, e error)
+		retErr   error
+		expErr   error
+		expRes   *models.SyncChanges
+		expToken int64
+	}{
+		{
+			name:  "Snapshot query return error",
+			since: 10,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectBegin()
+				m.ExpectQuery("SELECT pg_snapshot_xmin").WillReturnError(e)
+				m.ExpectRollback()
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name:  "Lists query return error",
+			since: 10,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectBegin()
+				m.ExpectQuery("SELECT pg_snapshot_xmin").WillReturnRows(tokenRows())
+				m.ExpectQuery("SELECT (.+) FROM lists l").WithArgs(1, 10).WillReturnError(e)
+				m.ExpectRollback()
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name:  "Full sync skips tombstones",
+			since: 0,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectBegin()
+				m.ExpectQuery("SELECT pg_snapshot_xmin").WillReturnRows(tokenRows())
+				m.ExpectQuery("SELECT (.+) FROM lists l").WithArgs(1, 0).WillReturnRows(listRows())
+				m.ExpectQuery("SELECT (.+) FROM users_lists m").WithArgs(1, 0).WillReturnRows(memberRows())
+				m.ExpectQuery("SELECT (.+) FROM items i").WithArgs(1, 0).WillReturnRows(itemRows())
+				m.ExpectCommit()
+			},
+			expRes: &models.SyncChanges{
+				Lists:          []*models.List{testList},
+				Items:          []*models.Item{testItem},
+				Members:        []*models.UsersList{{UserID: 1, ListID: testList.ID, IsAdmin: true}},
+				DeletedLists:   []int64{2},
+				DeletedItems:   []int64{2},
+				RemovedMembers: []*models.UsersList{},
+			},
+			expToken: 25,
+		},
+		{
+			name:  "Success sync with tombstones",
+			since: 10,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				tombstones := sqlmock.NewRows([]string{"list_id", "item_id", "user_id"}).
+					AddRow(testList.ID, 3, nil).
+					AddRow(4, nil, 1).
+					AddRow(testList.ID, nil, 2)
+
+				m.ExpectBegin()
+				m.ExpectQuery("SELECT pg_snapshot_xmin").WillReturnRows(tokenRows())
+				m.ExpectQuery("SELECT (.+) FROM lists l").WithArgs(1, 10).WillReturnRows(listRows())
+				m.ExpectQuery("SELECT (.+) FROM users_lists m").WithArgs(1, 10).WillReturnRows(memberRows())
+				m.ExpectQuery("SELECT (.+) FROM items i").WithArgs(1, 10).WillReturnRows(itemRows())
+				m.ExpectQuery("SELECT (.+) FROM sync_tombstones t").WithArgs(1, 10).WillReturnRows(tombstones)
+				m.ExpectCommit()
+			},
+			expRes: &models.SyncChanges{
+				Lists:          []*models.List{testList},
+				Items:          []*models.Item{testItem},
+				Members:        []*models.UsersList{{UserID: 1, ListID: testList.ID, IsAdmin: true}},
+				DeletedLists:   []int64{2, 4},
+				DeletedItems:   []int64{2, 3},
+				RemovedMembers: []*models.UsersList{{UserID: 2, ListID: testList.ID}},
+			},
+			expToken: 25,
+		},
+		{
+			name:  "No changes",
+			since: 25,
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectBegin()
+				m.ExpectQuery("SELECT pg_snapshot_xmin").WillReturnRows(tokenRows())
+				m.ExpectQuery("SELECT (.+) FROM lists l").WithArgs(1, 25).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				m.ExpectQuery("SELECT (.+) FROM users_lists m").WithArgs(1, 25).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+				m.ExpectQuery("SELECT (.+) FROM items i").WithArgs(1, 25).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				m.ExpectQuery("SELECT (.+) FROM sync_tombstones t").WithArgs(1, 25).
+					WillReturnRows(sqlmock.NewRows([]string{"list_id"}))
+				m.ExpectCommit()
+			},
+			expRes: &models.SyncChanges{
+				Lists:          []*models.List{},
+				Items:          []*models.Item{},
+				Members:        []*models.UsersList{},
+				DeletedLists:   []int64{},
+				DeletedItems:   []int64{},
+				RemovedMembers: []*models.UsersList{},
+			},
+			expToken: 25,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, token, err := sr.GetChanges(context.Background(), 1, tc.since)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+			require.Equal(t, tc.expToken, token)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetMutation(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	sr := NewPostgresSyncRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  *models.SyncResult
+	}{
+		{
+			name: "Mutation not found",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM sync_mutations").
+					WithArgs(1, "c1").
+					WillReturnError(e)
+			},
+			retErr: sql.ErrNoRows,
+			expErr: models.ErrNoSyncMutation,
+		},
+		{
+			name: "Success get",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM sync_mutations").
+					WithArgs(1, "c1").
+					WillReturnRows(sqlmock.NewRows([]string{"list_id", "item_id"}).AddRow(5, 7))
+			},
+			expRes: &models.SyncResult{ClientID: "c1", Status: models.SyncApplied, ListID: 5, ItemID: 7},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := sr.GetMutation(context.Background(), 1, "c1")
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestSaveMutation(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	sr := NewPostgresSyncRepository(db)
+
+	tests := []struct {
+		name    string
+		result  *models.SyncResult
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name:   "Exec return error",
+			result: &models.SyncResult{ClientID: "c1", ListID: 5},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("INSERT INTO sync_mutations").
+					WithArgs(1, "c1", 5, nil).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name:   "Mutation is already saved",
+			result: &models.SyncResult{ClientID: "c1", ListID: 5},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("INSERT INTO sync_mutations").
+					WithArgs(1, "c1", 5, nil).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expErr: models.ErrSyncMutationApplied,
+		},
+		{
+			name:   "Success save",
+			result: &models.SyncResult{ClientID: "c1", ListID: 5, ItemID: 7},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("INSERT INTO sync_mutations").
+					WithArgs(1, "c1", 5, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			err := sr.SaveMutation(context.Background(), 1, tc.result)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/VladimirStepanov/todo-app/internal/models"
@@ -16,11 +17,13 @@ func NewPostgresUserRepository(DB *sqlx.DB) models.UserRepository {
 	return &PostgresUserRepository{DB: DB}
 }
 
-func (pr *PostgresUserRepository) Create(user *models.User) (*models.User, error) {
+func (pr *PostgresUserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	ctx, span := startSpan(ctx, "PostgresUserRepository.Create")
+	defer span.End()
 
 	var insertedID int64
 
-	err := pr.DB.QueryRow(
+	err := pr.DB.QueryRowContext(ctx,
 		`INSERT INTO users(email, password_hash, activated_link) 
 		 VALUES($1, $2, $3) RETURNING id`,
 		user.Email, user.Password, user.ActivatedLink,
@@ -38,8 +41,11 @@ func (pr *PostgresUserRepository) Create(user *models.User) (*models.User, error
 	return user, nil
 }
 
-func (pr *PostgresUserRepository) ConfirmEmail(Link string) error {
-	res, err := pr.DB.Exec(
+func (pr *PostgresUserRepository) ConfirmEmail(ctx context.Context, Link string) error {
+	ctx, span := startSpan(ctx, "PostgresUserRepository.ConfirmEmail")
+	defer span.End()
+
+	res, err := pr.DB.ExecContext(ctx,
 		`UPDATE users SET is_activated=TRUE 
 		 WHERE activated_link=$1 AND is_activated=FALSE`, Link)
 	if err != nil {
@@ -56,10 +62,13 @@ func (pr *PostgresUserRepository) ConfirmEmail(Link string) error {
 	return nil
 }
 
-func (pr *PostgresUserRepository) FindUserByEmail(Email string) (*models.User, error) {
+func (pr *PostgresUserRepository) FindUserByEmail(ctx context.Context, Email string) (*models.User, error) {
+	ctx, span := startSpan(ctx, "PostgresUserRepository.FindUserByEmail")
+	defer span.End()
+
 	user := &models.User{}
 
-	err := pr.DB.Get(user, "SELECT * FROM users WHERE email=$1", Email)
+	err := pr.DB.GetContext(ctx, user, "SELECT * FROM users WHERE email=$1", Email)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
@@ -80,7 +81,7 @@ func TestUserCreate(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
 			var inputUser models.User = testUser
 
-			retUser, err := pr.Create(&inputUser)
+			retUser, err := pr.Create(context.Background(), &inputUser)
 			require.Equal(t, tc.expErr, err)
 
 			if err == nil {
@@ -141,7 +142,7 @@ func TestConfirmEmail(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setMock(mock, tc.retErr)
-			err := pr.ConfirmEmail("link")
+			err := pr.ConfirmEmail(context.Background(), "link")
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -218,7 +219,7 @@ func TestFindUserByEmail(t *testing.T) {
 			var inputUser models.User = testUser
 			inputUser.ID = retID
 
-			retUser, err := pr.FindUserByEmail(testUser.Email)
+			retUser, err := pr.FindUserByEmail(context.Background(), testUser.Email)
 			require.Equal(t, tc.expErr, err)
 
 			if err == nil {
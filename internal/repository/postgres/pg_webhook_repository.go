@@ -0,0 +1,329 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	webhookColumns  = "id, list_id, url, secret, events, active, failures, created_by, created_at"
+	deliveryColumns = `d.id, d.webhook_id, d.activity_id, d.event, d.status, d.attempts,
+		d.response_code, d.error, d.next_attempt_at, d.created_at, d.updated_at`
+)
+
+var deliverySortColumns = map[string]sortColumn{
+	models.SortCreatedAt: {"d.created_at", "timestamptz"},
+}
+
+// webhookRow scans events array which models.Webhook can't hold without pq
+type webhookRow struct {
+	models.Webhook
+	Events pq.StringArray `db:"events"`
+}
+
+func (r *webhookRow) toModel() *models.Webhook {
+	webhook := r.Webhook
+	webhook.Events = []string(r.Events)
+	if webhook.Events == nil {
+		webhook.Events = []string{}
+	}
+	return &webhook
+}
+
+type deliveryRow struct {
+	models.WebhookDelivery
+	SortKey string `db:"sort_key"`
+}
+
+type webhookJobRow struct {
+	DeliveryID int64  `db:"delivery_id"`
+	WebhookID  int64  `db:"webhook_id"`
+	Attempts   int    `db:"attempts"`
+	URL        string `db:"url"`
+	Secret     string `db:"secret"`
+	models.Activity
+}
+
+type PostgresWebhookRepository struct {
+	DB *sqlx.DB
+}
+
+func NewPostgresWebhookRepository(db *sqlx.DB) models.WebhookRepository {
+	return &PostgresWebhookRepository{
+		DB: db,
+	}
+}
+
+func (wr *PostgresWebhookRepository) Create(ctx context.Context, webhook *models.Webhook) (int64, error) {
+	ctx, span := startSpan(ctx, "PostgresWebhookRepository.Create")
+	defer span.End()
+
+	var webhookID int64
+
+	err := wr.DB.QueryRowContext(ctx,
+		`INSERT INTO webhooks(list_id, url, secret, events, created_by)
+		 VALUES($1, $2, $3, $4, $5) RETURNING id`,
+		webhook.ListID, webhook.URL, webhook.Secret, pq.Array(webhook.Events), webhook.CreatedBy,
+	).Scan(&webhookID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return webhookID, nil
+}
+
+func (wr *PostgresWebhookRepository) GetWebhooks(ctx context.Context, listID int64) ([]*models.Webhook, error) {
+	ctx, span := startSpan(ctx, "PostgresWebhookRepository.GetWebhooks")
+	defer span.End()
+
+	rows := []*webhookRow{}
+
+	err := wr.DB.SelectContext(ctx,
+		&rows,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE list_id=$1 ORDER BY id",
+		listID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*models.Webhook{}
+	for _, row := range rows {
+		res = append(res, row.toModel())
+	}
+	return res, nil
+}
+
+func (wr *PostgresWebhookRepository) GetWebhookByID(ctx context.Context, listID, webhookID int64) (*models.Webhook, error) {
+	ctx, span := startSpan(ctx, "PostgresWebhookRepository.GetWebhookByID")
+	defer span.End()
+
+	row := &webhookRow{}
+
+	err := wr.DB.GetContext(ctx,
+		row,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE list_id=$1 AND id=$2",
+		listID, webhookID,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = models.ErrNoWebhook
+		}
+		return nil, err
+	}
+
+	return row.toModel(), nil
+}
+
+func (wr *PostgresWebhookRepository) Update(ctx context.Context, listID, webhookID int64, req *models.UpdateWebhookReq) error {
+	ctx, span := startSpan(ctx, "PostgresWebhookRepository.Update")
+	defer span.End()
+
+	updObj := Updater{
+		args:    []interface{}{},
+		queries: []string{},
+		index:   1,
+	}
+
+	if req.URL != nil {
+		updObj.addUpdateItem("url", *req.URL)
+	}
+
+	if req.Secret != nil {
+		updObj.addUpdateItem("secret", *req.Secret)
+	}
+
+	if req.Events != nil {
+		updObj.addUpdateItem("events", pq.Array(*req.Events))
+	}
+
+	if req.Active != nil {
+		updObj.addUpdateItem("active", *req.Active)
+		if *req.Active {
+			updObj.queries = append(updObj.queries, "failures=0")
+		}
+	}
+
+	if len(updObj.queries) == 0 {
+		_, err := wr.GetWebhookByID(ctx, listID, webhookID)
+		return err
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE webhooks SET %s WHERE list_id=$%d AND id=$%d",
+		strings.Join(updObj.queries, ","),
+		updObj.index, updObj.index+1,
+	)
+	updObj.args = append(updObj.args, listID, webhookID)
+
+	res, err := wr.DB.ExecContext(ctx, query, updObj.args...)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return models.ErrNoWebhook
+	}
+
+	return nil
+}
+
+func (wr *PostgresWebhookRepository) Delete(ctx context.Context, listID, webhookID int64) error {
+	ctx, span := startSpan(ctx, "PostgresWebhookRepository.Delete")
+	defer span.End()
+
+	res, err := wr.DB.ExecContext(ctx,
+		"DELETE FROM webhooks WHERE list_id=$1 AND id=$2",
+		listID, webhookID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		return models.ErrNoWebhook
+	}
+
+	return nil
+}
+
+// GetDeliveries returns page of webhook delivery log and cursor to the next page
+func (wr *PostgresWebhookRepository) GetDeliveries(ctx context.Context, webhookID int64, page *models.PageReq) ([]*models.WebhookDelivery, string, error) {
+	ctx, span := startSpan(ctx, "PostgresWebhookRepository.GetDeliveries")
+	defer span.End()
+
+	ks, err := newKeyset(page, deliverySortColumns, "d.id")
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := "SELECT " + deliveryColumns + ", " + ks.SortKey() + " FROM webhook_deliveries d WHERE d.webhook_id=$1"
+	args := []interface{}{webhookID}
+
+	cond, args := ks.Where(args)
+	orderLimit, args := ks.OrderLimit(args)
+
+	rows := []*deliveryRow{}
+	err = wr.DB.SelectContext(ctx, &rows, query+cond+orderLimit, args...)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	res := []*models.WebhookDelivery{}
+	for i := 0; i < len(rows) && i < page.Limit; i++ {
+		res = append(res, &rows[i].WebhookDelivery)
+	}
+
+	next := ""
+	if len(res) > 0 {
+		last := rows[len(res)-1]
+		next = ks.NextCursor(len(rows), last.SortKey, last.ID)
+	}
+
+	return res, next, nil
+}
+
+func (wr *PostgresWebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookJob, error) {
+	ctx, span := startSpan(ctx, "PostgresWebhookRepository.ClaimDeliveries")
+	defer span.End()
+
+	rows := []*webhookJobRow{}
+
+	err := wr.DB.SelectContext(ctx,
+		&rows,
+		`UPDATE webhook_deliveries d SET next_attempt_at = now() + $2 * interval '1 second'
+		 FROM webhooks w, activity a
+		 WHERE d.id IN (
+			SELECT pd.id FROM webhook_deliveries pd INNER JOIN webhooks pw ON pw.id = pd.webhook_id
+			WHERE pd.status = 'pending' AND pd.next_attempt_at <= now() AND pw.active
+			ORDER BY pd.next_attempt_at LIMIT $1
+			FOR UPDATE OF pd SKIP LOCKED
+		 ) AND w.id = d.webhook_id AND a.id = d.activity_id
+		 RETURNING d.id AS delivery_id, d.webhook_id, d.attempts, w.url, w.secret, `+activityColumns,
+		limit, lease.Seconds(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*models.WebhookJob{}
+	for _, row := range rows {
+		activity := row.Activity
+		res = append(res, &models.WebhookJob{
+			DeliveryID: row.DeliveryID,
+			WebhookID:  row.WebhookID,
+			Attempts:   row.Attempts,
+			URL:        row.URL,
+			Secret:     row.Secret,
+			Activity:   &activity,
+		})
+	}
+	return res, nil
+}
+
+// SaveResult saves delivery attempt and counts consecutive failures of webhook,
+// webhook is disabled when failures reach maxFailures
+func (wr *PostgresWebhookRepository) SaveResult(ctx context.Context, result *models.DeliveryResult, maxFailures int) error {
+	ctx, span := startSpan(ctx, "PostgresWebhookRepository.SaveResult")
+	defer span.End()
+
+	status := models.DeliverySuccess
+	var deliveryErr *string
+	if result.Error != "" {
+		status = models.DeliveryPending
+		if result.NextAttemptAt == nil {
+			status = models.DeliveryFailed
+		}
+		deliveryErr = &result.Error
+	}
+
+	return withTx(ctx, wr.DB, func(ctx context.Context, tx querier) error {
+		_, err := tx.ExecContext(ctx,
+			`UPDATE webhook_deliveries
+			 SET status=$2, attempts=attempts+1, response_code=$3, error=$4, next_attempt_at=$5, updated_at=now()
+			 WHERE id=$1`,
+			result.DeliveryID, status, result.ResponseCode, deliveryErr, result.NextAttemptAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		if deliveryErr == nil {
+			_, err = tx.ExecContext(ctx, "UPDATE webhooks SET failures=0 WHERE id=$1", result.WebhookID)
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE webhooks SET failures=failures+1, active=active AND failures+1 < $2
+			 WHERE id=$1`,
+			result.WebhookID, maxFailures,
+		)
+		return err
+	})
+}
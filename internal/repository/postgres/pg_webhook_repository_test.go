@@ -0,0 +1,481 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testWebhook = &models.Webhook{
+		ID:     1,
+		ListID: testList.ID,
+		URL:    "https://example.com/hook",
+		Secret: "secret",
+		Events: []string{models.ActionItemCreate},
+		Active: true,
+	}
+	webhookColumnNames = []string{
+		"id", "list_id", "url", "secret", "events", "active", "failures", "created_by", "created_at",
+	}
+)
+
+func testWebhookRows() *sqlmock.Rows {
+	return sqlmock.NewRows(webhookColumnNames).AddRow(
+		testWebhook.ID, testWebhook.ListID, testWebhook.URL, testWebhook.Secret,
+		"{item.create}", testWebhook.Active, 0, nil, time.Time{},
+	)
+}
+
+func TestWebhookCreate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	wr := NewPostgresWebhookRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expID   int64
+	}{
+		{
+			name: "QueryRow return error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("INSERT INTO webhooks").
+					WithArgs(
+						testWebhook.ListID, testWebhook.URL, testWebhook.Secret,
+						pq.Array(testWebhook.Events), nil,
+					).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success create",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("INSERT INTO webhooks").
+					WithArgs(
+						testWebhook.ListID, testWebhook.URL, testWebhook.Secret,
+						pq.Array(testWebhook.Events), nil,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			},
+			expID: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			id, err := wr.Create(context.Background(), testWebhook)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expID, id)
+		})
+	}
+}
+
+func TestGetWebhookByID(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	wr := NewPostgresWebhookRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  *models.Webhook
+	}{
+		{
+			name: "Webhook not found",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM webhooks").
+					WithArgs(testWebhook.ListID, testWebhook.ID).
+					WillReturnError(e)
+			},
+			retErr: sql.ErrNoRows,
+			expErr: models.ErrNoWebhook,
+		},
+		{
+			name: "Success get",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM webhooks").
+					WithArgs(testWebhook.ListID, testWebhook.ID).
+					WillReturnRows(testWebhookRows())
+			},
+			expRes: testWebhook,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := wr.GetWebhookByID(context.Background(), testWebhook.ListID, testWebhook.ID)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestGetWebhooks(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	wr := NewPostgresWebhookRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  []*models.Webhook
+	}{
+		{
+			name: "Return unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM webhooks").
+					WithArgs(testWebhook.ListID).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success get",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM webhooks").
+					WithArgs(testWebhook.ListID).
+					WillReturnRows(testWebhookRows())
+			},
+			expRes: []*models.Webhook{testWebhook},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := wr.GetWebhooks(context.Background(), testWebhook.ListID)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	wr := NewPostgresWebhookRepository(db)
+
+	url := "https://example.com/new"
+	active := true
+
+	tests := []struct {
+		name    string
+		req     *models.UpdateWebhookReq
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name: "Empty update of unknown webhook",
+			req:  &models.UpdateWebhookReq{},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("SELECT (.+) FROM webhooks").
+					WithArgs(testWebhook.ListID, testWebhook.ID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expErr: models.ErrNoWebhook,
+		},
+		{
+			name: "Webhook not found",
+			req:  &models.UpdateWebhookReq{URL: &url},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec(regexp.QuoteMeta("UPDATE webhooks SET url=$1 WHERE list_id=$2 AND id=$3")).
+					WithArgs(url, testWebhook.ListID, testWebhook.ID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expErr: models.ErrNoWebhook,
+		},
+		{
+			name: "Enable resets failures",
+			req:  &models.UpdateWebhookReq{Active: &active},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec(regexp.QuoteMeta("UPDATE webhooks SET active=$1,failures=0 WHERE list_id=$2 AND id=$3")).
+					WithArgs(active, testWebhook.ListID, testWebhook.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			err := wr.Update(context.Background(), testWebhook.ListID, testWebhook.ID, tc.req)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	wr := NewPostgresWebhookRepository(db)
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name: "Exec return error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM webhooks").
+					WithArgs(testWebhook.ListID, testWebhook.ID).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Webhook not found",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM webhooks").
+					WithArgs(testWebhook.ListID, testWebhook.ID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expErr: models.ErrNoWebhook,
+		},
+		{
+			name: "Success delete",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectExec("DELETE FROM webhooks").
+					WithArgs(testWebhook.ListID, testWebhook.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			err := wr.Delete(context.Background(), testWebhook.ListID, testWebhook.ID)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestClaimDeliveries(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	wr := NewPostgresWebhookRepository(db)
+
+	columns := []string{
+		"delivery_id", "webhook_id", "attempts", "url", "secret",
+		"id", "list_id", "item_id", "user_id", "member_id", "action", "changes", "created_at",
+	}
+
+	tests := []struct {
+		name    string
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+		expRes  []*models.WebhookJob
+	}{
+		{
+			name: "Return unknown error",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectQuery("UPDATE webhook_deliveries").
+					WithArgs(10, float64(60)).
+					WillReturnError(e)
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success claim",
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				rows := sqlmock.NewRows(columns).AddRow(
+					7, testWebhook.ID, 1, testWebhook.URL, testWebhook.Secret,
+					5, testList.ID, nil, nil, nil, models.ActionItemCreate, nil, time.Time{},
+				)
+				m.ExpectQuery(regexp.QuoteMeta("FOR UPDATE OF pd SKIP LOCKED")).
+					WithArgs(10, float64(60)).
+					WillReturnRows(rows)
+			},
+			expRes: []*models.WebhookJob{
+				{
+					DeliveryID: 7,
+					WebhookID:  testWebhook.ID,
+					Attempts:   1,
+					URL:        testWebhook.URL,
+					Secret:     testWebhook.Secret,
+					Activity: &models.Activity{
+						ID: 5, ListID: testList.ID, Action: models.ActionItemCreate,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			res, err := wr.ClaimDeliveries(context.Background(), 10, time.Minute)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestSaveResult(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatal("Error while sqlmock.New()", err)
+	}
+
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	wr := NewPostgresWebhookRepository(db)
+
+	code := 500
+	next := time.Now()
+
+	tests := []struct {
+		name    string
+		result  *models.DeliveryResult
+		setMock func(m sqlmock.Sqlmock, e error)
+		retErr  error
+		expErr  error
+	}{
+		{
+			name:   "Delivery update return error",
+			result: &models.DeliveryResult{DeliveryID: 7, WebhookID: 1},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE webhook_deliveries").
+					WillReturnError(e)
+				m.ExpectRollback()
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name:   "Success resets failures",
+			result: &models.DeliveryResult{DeliveryID: 7, WebhookID: 1},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE webhook_deliveries").
+					WithArgs(7, models.DeliverySuccess, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta("UPDATE webhooks SET failures=0 WHERE id=$1")).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "Failure is retried and counted",
+			result: &models.DeliveryResult{
+				DeliveryID: 7, WebhookID: 1, ResponseCode: &code, Error: "bad", NextAttemptAt: &next,
+			},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE webhook_deliveries").
+					WithArgs(7, models.DeliveryPending, &code, sqlmock.AnyArg(), &next).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta("active=active AND failures+1 < $2")).
+					WithArgs(1, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "Last failed attempt",
+			result: &models.DeliveryResult{
+				DeliveryID: 7, WebhookID: 1, Error: "timeout",
+			},
+			setMock: func(m sqlmock.Sqlmock, e error) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE webhook_deliveries").
+					WithArgs(7, models.DeliveryFailed, nil, sqlmock.AnyArg(), nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("UPDATE webhooks SET failures=failures\\+1").
+					WithArgs(1, 5).
+					WillReturnError(e)
+				m.ExpectRollback()
+			},
+			retErr: ErrUnknown,
+			expErr: ErrUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setMock(mock, tc.retErr)
+			err := wr.SaveResult(context.Background(), tc.result, 5)
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
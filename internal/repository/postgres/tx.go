@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// querier is implemented by both pool and transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+type txKey struct{}
+
+// txState is transaction of ctx with functions to run after its commit
+type txState struct {
+	tx          *sqlx.Tx
+	afterCommit []func()
+}
+
+// conn returns transaction of ctx, or pool if ctx has no transaction
+func conn(ctx context.Context, db *sqlx.DB) querier {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return st.tx
+	}
+	return db
+}
+
+// withTx runs fn in transaction of ctx, or in new transaction which is
+// committed if fn succeeds and rolled back otherwise. Error of rollback is
+// attached to error of fn
+func withTx(ctx context.Context, db *sqlx.DB, fn func(ctx context.Context, tx querier) error) error {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx, st.tx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	st := &txState{tx: tx}
+	if err := fn(context.WithValue(ctx, txKey{}, st), tx); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return fmt.Errorf("%w (rollback: %v)", err, e)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, f := range st.afterCommit {
+		f()
+	}
+	return nil
+}
+
+type PostgresTransactor struct {
+	DB *sqlx.DB
+}
+
+func NewPostgresTransactor(db *sqlx.DB) models.Transactor {
+	return &PostgresTransactor{
+		DB: db,
+	}
+}
+
+func (pt *PostgresTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, span := startSpan(ctx, "PostgresTransactor.InTx")
+	defer span.End()
+
+	return withTx(ctx, pt.DB, func(ctx context.Context, _ querier) error {
+		return fn(ctx)
+	})
+}
+
+func (pt *PostgresTransactor) AfterCommit(ctx context.Context, fn func()) {
+	if st, ok := ctx.Value(txKey{}).(*txState); ok {
+		st.afterCommit = append(st.afterCommit, fn)
+		return
+	}
+	fn()
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInTx(t *testing.T) {
+	tests := []struct {
+		name           string
+		setMock        func(m sqlmock.Sqlmock)
+		fnErr          error
+		expErr         error
+		expErrMsg      string
+		expAfterCommit bool
+	}{
+		{
+			name: "Begin return error",
+			setMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin().WillReturnError(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Function error rolls back",
+			setMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE lists").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectRollback()
+			},
+			fnErr:  ErrUnknown,
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Rollback error is attached to function error",
+			setMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE lists").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectRollback().WillReturnError(errors.New("connection lost"))
+			},
+			fnErr:     ErrUnknown,
+			expErr:    ErrUnknown,
+			expErrMsg: ErrUnknown.Error() + " (rollback: connection lost)",
+		},
+		{
+			name: "Commit return error",
+			setMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE lists").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit().WillReturnError(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success commit",
+			setMock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE lists").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			expAfterCommit: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal("Error while sqlmock.New()", err)
+			}
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+			tc.setMock(mock)
+
+			lr := NewPostgresListRepository(db)
+			pt := NewPostgresTransactor(db)
+			afterCommit := false
+
+			err = pt.InTx(context.Background(), func(ctx context.Context) error {
+				// nested transaction joins outer one
+				return pt.InTx(ctx, func(ctx context.Context) error {
+					if err := lr.Delete(ctx, testList.ID, nil); err != nil {
+						return err
+					}
+					pt.AfterCommit(ctx, func() { afterCommit = true })
+					return tc.fnErr
+				})
+			})
+			if tc.expErrMsg != "" {
+				require.True(t, errors.Is(err, tc.expErr))
+				require.EqualError(t, err, tc.expErrMsg)
+			} else {
+				require.Equal(t, tc.expErr, err)
+			}
+			require.Equal(t, tc.expAfterCommit, afterCommit)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAfterCommitWithoutTx(t *testing.T) {
+	pt := NewPostgresTransactor(nil)
+
+	called := false
+	pt.AfterCommit(context.Background(), func() { called = true })
+	require.True(t, called)
+}
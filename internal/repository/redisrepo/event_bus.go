@@ -0,0 +1,152 @@
+package redisrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/go-redis/redis/v8"
+)
+
+// listChannelPattern matches channels of all lists, one pattern subscription
+// of process is shared by all streams
+const listChannelPattern = "events:list:*"
+
+// subscriberBuffer is number of events buffered for stream, slow stream is closed
+// when its buffer is full, so it doesn't delay others
+const subscriberBuffer = 16
+
+type RedisEventBus struct {
+	client  *redis.Client
+	timeout time.Duration
+
+	mu          sync.Mutex
+	pubsub      *redis.PubSub
+	subscribers map[int64]map[chan *models.Activity]struct{}
+}
+
+func NewRedisEventBus(client *redis.Client, timeout time.Duration) models.EventBus {
+	return &RedisEventBus{
+		client:      client,
+		timeout:     timeout,
+		subscribers: map[int64]map[chan *models.Activity]struct{}{},
+	}
+}
+
+func listChannel(listID int64) string {
+	return fmt.Sprintf("events:list:%d", listID)
+}
+
+func (eb *RedisEventBus) Publish(ctx context.Context, activity *models.Activity) error {
+	ctx, span := startSpan(ctx, "RedisEventBus.Publish")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, eb.timeout)
+	defer cancel()
+
+	data, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+
+	return eb.client.Publish(ctx, listChannel(activity.ListID), data).Err()
+}
+
+// Subscribe adds subscriber of list to shared subscription, it is started by first subscriber
+func (eb *RedisEventBus) Subscribe(ctx context.Context, listID int64) (<-chan *models.Activity, error) {
+	ctx, span := startSpan(ctx, "RedisEventBus.Subscribe")
+	defer span.End()
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if eb.pubsub == nil {
+		if err := eb.start(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	events := eb.add(listID)
+	go func() {
+		<-ctx.Done()
+		eb.mu.Lock()
+		eb.remove(listID, events)
+		eb.mu.Unlock()
+	}()
+
+	return events, nil
+}
+
+// start subscribes to channels of all lists, subscription outlives ctx of first subscriber
+func (eb *RedisEventBus) start(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, eb.timeout)
+	defer cancel()
+
+	pubsub := eb.client.PSubscribe(context.Background(), listChannelPattern)
+
+	// wait for confirmation, so events published after return are not lost
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+
+	eb.pubsub = pubsub
+	go eb.dispatch(pubsub.Channel())
+	return nil
+}
+
+// dispatch sends messages to subscribers of their lists until subscription is closed,
+// then subscribers are closed and next Subscribe starts new subscription
+func (eb *RedisEventBus) dispatch(messages <-chan *redis.Message) {
+	for msg := range messages {
+		activity := &models.Activity{}
+		if err := json.Unmarshal([]byte(msg.Payload), activity); err != nil {
+			continue
+		}
+
+		eb.mu.Lock()
+		for events := range eb.subscribers[activity.ListID] {
+			select {
+			case events <- activity:
+			default:
+				// client reconnects and gets missed activity from feed
+				eb.remove(activity.ListID, events)
+			}
+		}
+		eb.mu.Unlock()
+	}
+
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	for listID, subscribers := range eb.subscribers {
+		for events := range subscribers {
+			eb.remove(listID, events)
+		}
+	}
+	eb.pubsub = nil
+}
+
+// add and remove are called with locked mu
+func (eb *RedisEventBus) add(listID int64) chan *models.Activity {
+	events := make(chan *models.Activity, subscriberBuffer)
+	if eb.subscribers[listID] == nil {
+		eb.subscribers[listID] = map[chan *models.Activity]struct{}{}
+	}
+	eb.subscribers[listID][events] = struct{}{}
+	return events
+}
+
+func (eb *RedisEventBus) remove(listID int64, events chan *models.Activity) {
+	if _, ok := eb.subscribers[listID][events]; !ok {
+		return
+	}
+
+	close(events)
+	delete(eb.subscribers[listID], events)
+	if len(eb.subscribers[listID]) == 0 {
+		delete(eb.subscribers, listID)
+	}
+}
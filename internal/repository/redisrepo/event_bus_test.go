@@ -0,0 +1,90 @@
+package redisrepo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/go-redis/redis/v8"
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublish(t *testing.T) {
+	activity := &models.Activity{ID: 1, ListID: 2, Action: models.ActionListUpdate}
+	payload, err := json.Marshal(activity)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		setMock func(m redismock.ClientMock)
+		expErr  error
+	}{
+		{
+			name: "Publish return error",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectPublish("events:list:2", payload).SetErr(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success publish",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectPublish("events:list:2", payload).SetVal(1)
+			},
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := redismock.NewClientMock()
+			tc.setMock(mock)
+			eb := NewRedisEventBus(db, testTimeout)
+			err := eb.Publish(context.Background(), activity)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	eb := NewRedisEventBus(nil, testTimeout).(*RedisEventBus)
+
+	first := eb.add(1)
+	second := eb.add(1)
+	other := eb.add(2)
+	slow := eb.add(1)
+	for i := 0; i < subscriberBuffer; i++ {
+		slow <- &models.Activity{}
+	}
+
+	payload, err := json.Marshal(&models.Activity{ID: 5, ListID: 1})
+	require.NoError(t, err)
+
+	messages := make(chan *redis.Message, 2)
+	messages <- &redis.Message{Channel: "events:list:1", Payload: "bad"}
+	messages <- &redis.Message{Channel: "events:list:1", Payload: string(payload)}
+	close(messages)
+
+	eb.dispatch(messages)
+
+	for _, events := range []chan *models.Activity{first, second} {
+		activity, ok := <-events
+		require.True(t, ok)
+		require.Equal(t, int64(5), activity.ID)
+		_, ok = <-events
+		require.False(t, ok)
+	}
+
+	// full subscriber is closed after its buffered events
+	for i := 0; i < subscriberBuffer; i++ {
+		<-slow
+	}
+	_, ok := <-slow
+	require.False(t, ok)
+
+	_, ok = <-other
+	require.False(t, ok)
+	require.Empty(t, eb.subscribers)
+}
@@ -0,0 +1,31 @@
+package redisrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/go-redis/redis/v8"
+)
+
+type RedisHealthChecker struct {
+	client  *redis.Client
+	timeout time.Duration
+}
+
+func NewRedisHealthChecker(client *redis.Client, timeout time.Duration) models.HealthChecker {
+	return &RedisHealthChecker{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+func (hc *RedisHealthChecker) Ping(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "RedisHealthChecker.Ping")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout)
+	defer cancel()
+
+	return hc.client.Ping(ctx).Err()
+}
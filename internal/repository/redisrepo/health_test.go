@@ -0,0 +1,41 @@
+package redisrepo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisPing(t *testing.T) {
+	tests := []struct {
+		name    string
+		setMock func(m redismock.ClientMock)
+		expErr  error
+	}{
+		{
+			name: "Ping return error",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectPing().SetErr(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success ping",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectPing().SetVal("PONG")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := redismock.NewClientMock()
+			tc.setMock(mock)
+			hc := NewRedisHealthChecker(db, testTimeout)
+			err := hc.Ping(context.Background())
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package redisrepo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/go-redis/redis/v8"
+)
+
+type RedisIdempotencyRepository struct {
+	client  *redis.Client
+	timeout time.Duration
+}
+
+func NewRedisIdempotencyRepository(client *redis.Client, timeout time.Duration) models.IdempotencyRepository {
+	return &RedisIdempotencyRepository{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+func (r *RedisIdempotencyRepository) Reserve(ctx context.Context, key string, resp *models.IdempotentResponse, ttl time.Duration) (*models.IdempotentResponse, error) {
+	ctx, span := startSpan(ctx, "RedisIdempotencyRepository.Reserve")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := r.client.SetNX(ctx, key, data, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return nil, nil
+	}
+
+	data, err = r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		// key expired right after SetNX, the first request is not finished yet
+		if err == redis.Nil {
+			return nil, models.ErrIdempotencyInFlight
+		}
+		return nil, err
+	}
+
+	stored := &models.IdempotentResponse{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (r *RedisIdempotencyRepository) Save(ctx context.Context, key string, resp *models.IdempotentResponse, ttl time.Duration) error {
+	ctx, span := startSpan(ctx, "RedisIdempotencyRepository.Save")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, key, data, ttl).Err()
+}
+
+func (r *RedisIdempotencyRepository) Delete(ctx context.Context, key string) error {
+	ctx, span := startSpan(ctx, "RedisIdempotencyRepository.Delete")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	return r.client.Del(ctx, key).Err()
+}
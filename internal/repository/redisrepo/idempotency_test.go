@@ -0,0 +1,156 @@
+package redisrepo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserve(t *testing.T) {
+	key := "idempotency:1:key"
+	lock := &models.IdempotentResponse{Fingerprint: "fp"}
+	lockData, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	done := &models.IdempotentResponse{
+		Fingerprint: "fp",
+		Done:        true,
+		Code:        200,
+		Header:      map[string]string{"Content-Type": "application/json"},
+		Body:        []byte(`{"status":"success"}`),
+	}
+	doneData, err := json.Marshal(done)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		setMock func(m redismock.ClientMock)
+		expRes  *models.IdempotentResponse
+		expErr  error
+	}{
+		{
+			name: "SetNX return error",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectSetNX(key, lockData, time.Minute).SetErr(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Key reserved",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectSetNX(key, lockData, time.Minute).SetVal(true)
+			},
+		},
+		{
+			name: "Key expired after SetNX",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectSetNX(key, lockData, time.Minute).SetVal(false)
+				m.ExpectGet(key).RedisNil()
+			},
+			expErr: models.ErrIdempotencyInFlight,
+		},
+		{
+			name: "Get return error",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectSetNX(key, lockData, time.Minute).SetVal(false)
+				m.ExpectGet(key).SetErr(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Return stored response",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectSetNX(key, lockData, time.Minute).SetVal(false)
+				m.ExpectGet(key).SetVal(string(doneData))
+			},
+			expRes: done,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := redismock.NewClientMock()
+			tc.setMock(mock)
+			ir := NewRedisIdempotencyRepository(db, testTimeout)
+			res, err := ir.Reserve(context.Background(), key, lock, time.Minute)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestSaveIdempotentResponse(t *testing.T) {
+	key := "idempotency:1:key"
+	resp := &models.IdempotentResponse{Fingerprint: "fp", Done: true, Code: 201}
+	data, err := json.Marshal(resp)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		setMock func(m redismock.ClientMock)
+		expErr  error
+	}{
+		{
+			name: "Set return error",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectSet(key, data, time.Hour).SetErr(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success save",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectSet(key, data, time.Hour).SetVal("OK")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := redismock.NewClientMock()
+			tc.setMock(mock)
+			ir := NewRedisIdempotencyRepository(db, testTimeout)
+			err := ir.Save(context.Background(), key, resp, time.Hour)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDeleteIdempotentResponse(t *testing.T) {
+	key := "idempotency:1:key"
+
+	tests := []struct {
+		name    string
+		setMock func(m redismock.ClientMock)
+		expErr  error
+	}{
+		{
+			name: "Del return error",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectDel(key).SetErr(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Success delete",
+			setMock: func(m redismock.ClientMock) {
+				m.ExpectDel(key).SetVal(1)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := redismock.NewClientMock()
+			tc.setMock(mock)
+			ir := NewRedisIdempotencyRepository(db, testTimeout)
+			err := ir.Delete(context.Background(), key)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
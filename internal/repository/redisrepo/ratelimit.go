@@ -0,0 +1,86 @@
+package redisrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/go-redis/redis/v8"
+)
+
+// rateLimitScript keeps timestamps of requests in sorted set and
+// returns allowed flag, count of requests in window and ms until reset
+var rateLimitScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+
+local reset = window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, count, reset}
+`)
+
+type RedisRateLimitRepository struct {
+	client  *redis.Client
+	timeout time.Duration
+}
+
+func NewRedisRateLimitRepository(client *redis.Client, timeout time.Duration) models.RateLimitRepository {
+	return &RedisRateLimitRepository{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+func (r *RedisRateLimitRepository) Allow(ctx context.Context, key string, limit models.RateLimit, now time.Time, member string) (*models.RateLimitResult, error) {
+	ctx, span := startSpan(ctx, "RedisRateLimitRepository.Allow")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	val, err := rateLimitScript.Run(
+		ctx, r.client, []string{key},
+		now.UnixNano()/int64(time.Millisecond), limit.Window.Milliseconds(), limit.Requests, member,
+	).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res, ok := val.([]interface{})
+	if !ok || len(res) != 3 {
+		return nil, fmt.Errorf("unexpected rate limit script result %v", val)
+	}
+
+	vals := make([]int64, len(res))
+	for i, v := range res {
+		n, ok := v.(int64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected rate limit script result %v", val)
+		}
+		vals[i] = n
+	}
+
+	return &models.RateLimitResult{
+		Allowed:   vals[0] == 1,
+		Limit:     limit.Requests,
+		Remaining: limit.Requests - int(vals[1]),
+		Window:    limit.Window,
+		Reset:     time.Duration(vals[2]) * time.Millisecond,
+	}, nil
+}
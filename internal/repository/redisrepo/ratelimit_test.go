@@ -0,0 +1,74 @@
+package redisrepo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/go-redis/redismock/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitAllow(t *testing.T) {
+	key := "ratelimit:api:user:1"
+	limit := models.RateLimit{Requests: 10, Window: time.Minute}
+	now := time.Unix(1000, 0)
+
+	expect := func(m redismock.ClientMock) *redismock.ExpectedCmd {
+		return m.ExpectEvalSha(
+			rateLimitScript.Hash(), []string{key},
+			int64(1000000), int64(60000), 10, "member",
+		)
+	}
+
+	tests := []struct {
+		name    string
+		setMock func(m redismock.ClientMock)
+		expRes  *models.RateLimitResult
+		expErr  error
+	}{
+		{
+			name: "Script return error",
+			setMock: func(m redismock.ClientMock) {
+				expect(m).SetErr(ErrUnknown)
+			},
+			expErr: ErrUnknown,
+		},
+		{
+			name: "Request allowed",
+			setMock: func(m redismock.ClientMock) {
+				expect(m).SetVal([]interface{}{int64(1), int64(3), int64(59000)})
+			},
+			expRes: &models.RateLimitResult{
+				Allowed:   true,
+				Limit:     10,
+				Remaining: 7,
+				Window:    time.Minute,
+				Reset:     59 * time.Second,
+			},
+		},
+		{
+			name: "Limit exceeded",
+			setMock: func(m redismock.ClientMock) {
+				expect(m).SetVal([]interface{}{int64(0), int64(10), int64(1500)})
+			},
+			expRes: &models.RateLimitResult{
+				Limit:  10,
+				Window: time.Minute,
+				Reset:  1500 * time.Millisecond,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := redismock.NewClientMock()
+			tc.setMock(mock)
+			rr := NewRedisRateLimitRepository(db, testTimeout)
+			res, err := rr.Allow(context.Background(), key, limit, now, "member")
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
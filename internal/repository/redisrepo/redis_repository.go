@@ -9,17 +9,22 @@ import (
 )
 
 type RedisRepository struct {
-	client *redis.Client
+	client  *redis.Client
+	timeout time.Duration
 }
 
-func NewRedisRepository(client *redis.Client) models.TokenRepository {
+func NewRedisRepository(client *redis.Client, timeout time.Duration) models.TokenRepository {
 	return &RedisRepository{
-		client: client,
+		client:  client,
+		timeout: timeout,
 	}
 }
 
-func (r *RedisRepository) SetTokens(accessKey string, accessExp time.Duration, refreshKey string, refreshExp time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+func (r *RedisRepository) SetTokens(ctx context.Context, accessKey string, accessExp time.Duration, refreshKey string, refreshExp time.Duration) error {
+	ctx, span := startSpan(ctx, "RedisRepository.SetTokens")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
@@ -44,8 +49,11 @@ func (r *RedisRepository) SetTokens(accessKey string, accessExp time.Duration, r
 	return err
 }
 
-func (r *RedisRepository) Get(key string) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+func (r *RedisRepository) Get(ctx context.Context, key string) (bool, error) {
+	ctx, span := startSpan(ctx, "RedisRepository.Get")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	res, err := r.client.Get(ctx, key).Bool()
@@ -61,20 +69,42 @@ func (r *RedisRepository) Get(key string) (bool, error) {
 	return res, nil
 }
 
-func (r *RedisRepository) Count(pattern string) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+// scanCount is number of keys checked by one SCAN call
+const scanCount = 1000
+
+// Count returns number of keys matched by pattern. Keys are iterated with SCAN,
+// KEYS blocks redis until whole keyspace is checked
+func (r *RedisRepository) Count(ctx context.Context, pattern string) (int, error) {
+	ctx, span := startSpan(ctx, "RedisRepository.Count")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	res, err := r.client.Keys(ctx, pattern).Result()
+	// SCAN may return key more than once
+	seen := map[string]struct{}{}
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, scanCount).Result()
+		if err != nil {
+			return 0, err
+		}
+		for _, key := range keys {
+			seen[key] = struct{}{}
+		}
 
-	if err != nil {
-		return 0, err
+		cursor = next
+		if cursor == 0 {
+			return len(seen), nil
+		}
 	}
-	return len(res), nil
 }
 
-func (r *RedisRepository) Delete(keys ...string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+func (r *RedisRepository) Delete(ctx context.Context, keys ...string) error {
+	ctx, span := startSpan(ctx, "RedisRepository.Delete")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	return r.client.Del(ctx, keys...).Err()
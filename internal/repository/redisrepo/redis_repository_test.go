@@ -1,6 +1,7 @@
 package redisrepo
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -11,6 +12,8 @@ import (
 
 var ErrUnknown = errors.New("unknown error")
 
+const testTimeout = 3 * time.Second
+
 func TestSetTokens(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -30,8 +33,8 @@ func TestSetTokens(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			db, mock := redismock.NewClientMock()
 			tc.setMock(mock)
-			rr := NewRedisRepository(db)
-			err := rr.SetTokens("test1", time.Hour, "test2", time.Hour)
+			rr := NewRedisRepository(db, testTimeout)
+			err := rr.SetTokens(context.Background(), "test1", time.Hour, "test2", time.Hour)
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -75,8 +78,8 @@ func TestGet(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			db, mock := redismock.NewClientMock()
 			tc.setMock(mock)
-			rr := NewRedisRepository(db)
-			val, err := rr.Get("hello")
+			rr := NewRedisRepository(db, testTimeout)
+			val, err := rr.Get(context.Background(), "hello")
 			require.Equal(t, tc.expErr, err)
 			require.Equal(t, tc.expVal, val)
 		})
@@ -94,7 +97,7 @@ func TestCount(t *testing.T) {
 		{
 			name: "Some error",
 			setMock: func(m redismock.ClientMock) {
-				m.ExpectKeys("pattern").SetErr(ErrUnknown)
+				m.ExpectScan(0, "pattern", scanCount).SetErr(ErrUnknown)
 			},
 			expErr: ErrUnknown,
 			expVal: 0,
@@ -102,7 +105,7 @@ func TestCount(t *testing.T) {
 		{
 			name: "No result",
 			setMock: func(m redismock.ClientMock) {
-				m.ExpectKeys("pattern").SetVal([]string{})
+				m.ExpectScan(0, "pattern", scanCount).SetVal([]string{}, 0)
 			},
 			expErr: nil,
 			expVal: 0,
@@ -110,7 +113,8 @@ func TestCount(t *testing.T) {
 		{
 			name: "Some result",
 			setMock: func(m redismock.ClientMock) {
-				m.ExpectKeys("pattern").SetVal([]string{"1", "2"})
+				m.ExpectScan(0, "pattern", scanCount).SetVal([]string{"1", "2"}, 7)
+				m.ExpectScan(7, "pattern", scanCount).SetVal([]string{"2"}, 0)
 			},
 			expErr: nil,
 			expVal: 2,
@@ -121,8 +125,8 @@ func TestCount(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			db, mock := redismock.NewClientMock()
 			tc.setMock(mock)
-			rr := NewRedisRepository(db)
-			val, err := rr.Count("pattern")
+			rr := NewRedisRepository(db, testTimeout)
+			val, err := rr.Count(context.Background(), "pattern")
 			require.Equal(t, tc.expErr, err)
 			require.Equal(t, tc.expVal, val)
 		})
@@ -155,8 +159,8 @@ func TestDelete(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			db, mock := redismock.NewClientMock()
 			tc.setMock(mock)
-			rr := NewRedisRepository(db)
-			err := rr.Delete("hello")
+			rr := NewRedisRepository(db, testTimeout)
+			err := rr.Delete(context.Background(), "hello")
 			require.Equal(t, tc.expErr, err)
 		})
 	}
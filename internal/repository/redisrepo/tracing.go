@@ -0,0 +1,14 @@
+package redisrepo
+
+import (
+	"context"
+
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts span of repository call, span name is type and method name
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, name, semconv.DBSystemRedis)
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader serves certificate which is reloaded when files are changed,
+// last good certificate is kept if new files are broken
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	cr := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := cr.Reload(); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// Reload loads certificate from files
+func (cr *certReloader) Reload() error {
+	modTime, err := cr.lastModified()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return err
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.cert = &cert
+	cr.modTime = modTime
+
+	return nil
+}
+
+// ReloadIfChanged reloads certificate if any of files was modified after last load
+func (cr *certReloader) ReloadIfChanged() (bool, error) {
+	modTime, err := cr.lastModified()
+	if err != nil {
+		return false, err
+	}
+
+	cr.mu.RLock()
+	changed := !modTime.Equal(cr.modTime)
+	cr.mu.RUnlock()
+
+	if !changed {
+		return false, nil
+	}
+	return true, cr.Reload()
+}
+
+// lastModified returns latest modification time of files, links are followed,
+// so replaced secrets of kubernetes are noticed
+func (cr *certReloader) lastModified() (time.Time, error) {
+	var last time.Time
+	for _, path := range []string{cr.certFile, cr.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last, nil
+}
+
+// GetCertificate is used as tls.Config.GetCertificate
+func (cr *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	return cr.cert, nil
+}
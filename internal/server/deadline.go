@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+type connKey struct{}
+
+// withConn is ConnContext of server, it keeps connection for write deadline of its requests
+func withConn(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connKey{}, c)
+}
+
+// writeDeadlineHandler sets write deadline of HTTP/1 connection for each request
+// like http.Server.WriteTimeout does, long-lived requests are served without it.
+// Deadline is cleared for them because previous request on the connection set it
+func writeDeadlineHandler(next http.Handler, timeout time.Duration, longLived func(r *http.Request) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, ok := r.Context().Value(connKey{}).(net.Conn)
+		if ok && r.ProtoMajor == 1 {
+			var deadline time.Time
+			if longLived == nil || !longLived(r) {
+				deadline = time.Now().Add(timeout)
+			}
+			if err := conn.SetWriteDeadline(deadline); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
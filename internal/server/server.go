@@ -2,32 +2,171 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
 	"net/http"
+	"sync"
 	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
+// Options of TLS and HTTP/2, zero value serves plain HTTP/1.1
+type Options struct {
+	// CertFile and KeyFile enable TLS, HTTP/2 is negotiated with ALPN
+	CertFile string
+	KeyFile  string
+	// ReloadInterval is interval of checking certificate files for changes
+	ReloadInterval time.Duration
+	// OnReload is called after certificate is reloaded on files change
+	OnReload func(err error)
+	// RedirectAddr is address of plain HTTP listener which redirects to HTTPS
+	RedirectAddr string
+	// H2C enables HTTP/2 without TLS, e.g. behind proxy in internal network
+	H2C bool
+	// WriteTimeout limits writing of response on HTTP/1 connection, requests
+	// reported by LongLived are served without it. HTTP/2 streams are bounded
+	// by flow control instead. Zero disables it
+	WriteTimeout time.Duration
+	// LongLived reports requests which stay open, e.g. event streams
+	LongLived func(r *http.Request) bool
+}
+
 type Server struct {
-	srv *http.Server
+	srv      *http.Server
+	redirect *http.Server
+	certs    *certReloader
+	opts     Options
+
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-func New(addr string, router http.Handler) *Server {
-	srv := &http.Server{
+func New(addr string, router http.Handler, opts Options) (*Server, error) {
+	s := &Server{opts: opts, done: make(chan struct{})}
+
+	// http.Server.WriteTimeout can't be lifted for single request, so deadline
+	// is set by handler
+	if opts.WriteTimeout > 0 {
+		router = writeDeadlineHandler(router, opts.WriteTimeout, opts.LongLived)
+	}
+
+	if opts.H2C && opts.CertFile == "" {
+		router = h2c.NewHandler(router, &http2.Server{})
+	}
+
+	s.srv = &http.Server{
 		Addr:           addr,
 		Handler:        router,
 		ReadTimeout:    10 * time.Second,
-		WriteTimeout:   10 * time.Second,
+		IdleTimeout:    60 * time.Second,
 		MaxHeaderBytes: 1 << 20,
+		ConnContext:    withConn,
 	}
 
-	return &Server{
-		srv: srv,
+	if opts.CertFile == "" {
+		return s, nil
 	}
+
+	var err error
+	s.certs, err = newCertReloader(opts.CertFile, opts.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	s.srv.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: s.certs.GetCertificate,
+	}
+
+	if opts.RedirectAddr != "" {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		s.redirect = &http.Server{
+			Addr:         opts.RedirectAddr,
+			Handler:      redirectHandler(port),
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+		}
+	}
+
+	return s, nil
+}
+
+// redirectHandler redirects requests to same host and path on HTTPS port
+func redirectHandler(port string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
 }
 
+// Run serves requests until Shutdown, error of any listener is returned
 func (s *Server) Run() error {
-	return s.srv.ListenAndServe()
+	if s.certs == nil {
+		return s.srv.ListenAndServe()
+	}
+
+	errs := make(chan error, 2)
+
+	if s.redirect != nil {
+		go func() { errs <- s.redirect.ListenAndServe() }()
+	}
+	go func() { errs <- s.srv.ListenAndServeTLS("", "") }()
+	go s.watchCertificates()
+
+	return <-errs
+}
+
+// watchCertificates reloads certificate when its files are changed
+func (s *Server) watchCertificates() {
+	ticker := time.NewTicker(s.opts.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			reloaded, err := s.certs.ReloadIfChanged()
+			if (reloaded || err != nil) && s.opts.OnReload != nil {
+				s.opts.OnReload(err)
+			}
+		}
+	}
+}
+
+// ReloadCertificates loads certificate files again, it does nothing without TLS
+func (s *Server) ReloadCertificates() error {
+	if s.certs == nil {
+		return nil
+	}
+	return s.certs.Reload()
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.closeOnce.Do(func() { close(s.done) })
+
+	if s.redirect != nil {
+		if err := s.redirect.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
 	return s.srv.Shutdown(ctx)
 }
+
+// RegisterOnShutdown registers f to be called when Shutdown starts,
+// it should end long-lived connections which Shutdown would wait for
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.srv.RegisterOnShutdown(f)
+}
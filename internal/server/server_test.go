@@ -0,0 +1,164 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeCert writes self-signed certificate for commonName and returns paths of cert and key
+func writeCert(t *testing.T, dir, commonName string, modTime time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, cr *certReloader) string {
+	cert, err := cr.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	loaded := time.Now().Add(-time.Minute)
+	certFile, keyFile := writeCert(t, dir, "first", loaded)
+
+	cr, err := newCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+	require.Equal(t, "first", commonName(t, cr))
+
+	reloaded, err := cr.ReloadIfChanged()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	writeCert(t, dir, "second", loaded.Add(time.Second))
+	reloaded, err = cr.ReloadIfChanged()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	require.Equal(t, "second", commonName(t, cr))
+
+	// broken files keep last good certificate
+	require.NoError(t, ioutil.WriteFile(certFile, []byte("broken"), 0600))
+	reloaded, err = cr.ReloadIfChanged()
+	require.Error(t, err)
+	require.True(t, reloaded)
+	require.Equal(t, "second", commonName(t, cr))
+
+	_, err = newCertReloader(certFile, keyFile)
+	require.Error(t, err)
+}
+
+func TestRedirectHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		port   string
+		host   string
+		target string
+		expLoc string
+	}{
+		{
+			name:   "Default https port",
+			port:   "443",
+			host:   "example.com",
+			target: "/api/lists?page=2",
+			expLoc: "https://example.com/api/lists?page=2",
+		},
+		{
+			name:   "Custom https port",
+			port:   "8443",
+			host:   "example.com:8080",
+			target: "/auth/sign-in",
+			expLoc: "https://example.com:8443/auth/sign-in",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.target, nil)
+			req.Host = tc.host
+			w := httptest.NewRecorder()
+
+			redirectHandler(tc.port).ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusPermanentRedirect, w.Code)
+			require.Equal(t, tc.expLoc, w.Header().Get("Location"))
+		})
+	}
+}
+
+func TestWriteDeadlineHandler(t *testing.T) {
+	// response is written after write timeout has passed, except of fast path
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fast" {
+			time.Sleep(150 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte("data"))
+	})
+	longLived := func(r *http.Request) bool {
+		return r.URL.Path == "/stream"
+	}
+
+	srv := httptest.NewUnstartedServer(writeDeadlineHandler(h, 50*time.Millisecond, longLived))
+	srv.Config.ConnContext = withConn
+	srv.Start()
+	defer srv.Close()
+
+	tests := []struct {
+		path   string
+		expErr bool
+	}{
+		{path: "/fast"},
+		// keep-alive connection of fast request is reused, its deadline is cleared
+		{path: "/stream"},
+		{path: "/slow", expErr: true},
+	}
+
+	client := srv.Client()
+	for _, tc := range tests {
+		resp, err := client.Get(srv.URL + tc.path)
+		if tc.expErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, "data", string(data))
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/metrics"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+)
+
+// saveActivity saves activity in transaction of the change and publishes it to subscribers
+// of the list after commit. Change is already saved then, so publish is best-effort:
+// failure is logged and counted, subscribers get the change from activity feed or sync
+func saveActivity(ctx context.Context, tx models.Transactor, activityRepo models.ActivityRepository, events models.EventBus, activity *models.Activity) error {
+	if err := activityRepo.Create(ctx, activity); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(ctx, func() {
+		metrics.Activities.WithLabelValues(activity.Action).Inc()
+
+		if err := events.Publish(ctx, activity); err != nil {
+			metrics.EventPublishFailures.Inc()
+			logging.FromContext(ctx).WithField("list_id", activity.ListID).Error("event publish: ", err)
+		}
+	})
+	return nil
+}
+
+// int64Value returns value of optional id, zero id means no value
+func int64Value(v *int64) interface{} {
+	if v == nil || *v == 0 {
+		return nil
+	}
+	return *v
+}
+
+// timeValue returns comparable value of optional time, zero time means no value
+func timeValue(t *time.Time) interface{} {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func listChanges(list *models.List, req *models.UpdateListReq) models.Changes {
+	changes := models.Changes{}
+
+	if req.Title != nil {
+		changes.Add("title", list.Title, *req.Title)
+	}
+	if req.Description != nil {
+		changes.Add("description", list.Description, *req.Description)
+	}
+
+	return changes
+}
+
+func itemChanges(item *models.Item, req *models.UpdateItemReq) models.Changes {
+	changes := models.Changes{}
+
+	if req.Title != nil {
+		changes.Add("title", item.Title, *req.Title)
+	}
+	if req.Description != nil {
+		changes.Add("description", item.Description, *req.Description)
+	}
+	if req.Done != nil {
+		changes.Add("done", item.Done, *req.Done)
+	}
+	if req.AssigneeID != nil {
+		changes.Add("assignee_id", int64Value(item.AssigneeID), int64Value(req.AssigneeID))
+	}
+	if req.DueAt != nil {
+		changes.Add("due_at", timeValue(item.DueAt), timeValue(req.DueAt))
+	}
+	if req.Priority != nil {
+		changes.Add("priority", item.Priority, *req.Priority)
+	}
+
+	return changes
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/google/uuid"
+)
+
+// number of deleted blobs removed by one Cleanup call
+const cleanupBatchSize = 100
+
+type AttachmentService struct {
+	repo         models.AttachmentRepository
+	itemRepo     models.ItemRepository
+	blobs        models.BlobStore
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+func NewAttachmentService(
+	repo models.AttachmentRepository,
+	itemRepo models.ItemRepository,
+	blobs models.BlobStore,
+	maxSize int64,
+	allowedTypes []string) models.AttachmentService {
+
+	return &AttachmentService{
+		repo:         repo,
+		itemRepo:     itemRepo,
+		blobs:        blobs,
+		MaxSize:      maxSize,
+		AllowedTypes: allowedTypes,
+	}
+}
+
+func (as *AttachmentService) isAllowedType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, t := range as.AllowedTypes {
+		if t == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// Create checks size and sniffed content type of r, stores it and saves its metadata
+func (as *AttachmentService) Create(ctx context.Context, listID, itemID, userID int64, fileName string, size int64, r io.Reader) (int64, error) {
+	if size > as.MaxSize {
+		return 0, models.ErrFileTooLarge
+	}
+
+	if _, err := as.itemRepo.GetItemByID(ctx, listID, itemID); err != nil {
+		return 0, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return 0, err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !as.isAllowedType(contentType) {
+		return 0, models.ErrFileTypeNotAllowed
+	}
+
+	attachment := &models.Attachment{
+		ItemID:      itemID,
+		UserID:      userID,
+		FileName:    filepath.Base(fileName),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  fmt.Sprintf("%d/%s", itemID, uuid.NewString()),
+	}
+
+	body := io.MultiReader(bytes.NewReader(head), io.LimitReader(r, size-int64(n)))
+	if err := as.blobs.Put(ctx, attachment.StorageKey, contentType, body, size); err != nil {
+		return 0, err
+	}
+
+	attachmentID, err := as.repo.Create(ctx, attachment)
+	if err != nil {
+		if e := as.blobs.Delete(ctx, attachment.StorageKey); e != nil {
+			err = e
+		}
+		return 0, err
+	}
+
+	return attachmentID, nil
+}
+
+func (as *AttachmentService) GetAttachments(ctx context.Context, listID, itemID int64) ([]*models.Attachment, error) {
+	if _, err := as.itemRepo.GetItemByID(ctx, listID, itemID); err != nil {
+		return nil, err
+	}
+
+	return as.repo.GetAttachments(ctx, itemID)
+}
+
+func (as *AttachmentService) getAttachment(ctx context.Context, listID, itemID, attachmentID int64) (*models.Attachment, error) {
+	if _, err := as.itemRepo.GetItemByID(ctx, listID, itemID); err != nil {
+		return nil, err
+	}
+
+	return as.repo.GetAttachmentByID(ctx, itemID, attachmentID)
+}
+
+func (as *AttachmentService) Download(ctx context.Context, listID, itemID, attachmentID int64) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := as.getAttachment(ctx, listID, itemID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := as.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if err == models.ErrNoBlob {
+			err = models.ErrNoAttachment
+		}
+		return nil, nil, err
+	}
+
+	return attachment, r, nil
+}
+
+// Delete removes attachment metadata, blob is removed later by Cleanup
+func (as *AttachmentService) Delete(ctx context.Context, listID, itemID, attachmentID, userID int64, isAdmin bool) error {
+	attachment, err := as.getAttachment(ctx, listID, itemID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if attachment.UserID != userID && !isAdmin {
+		return models.ErrNoAttachmentAccess
+	}
+
+	return as.repo.Delete(ctx, attachmentID)
+}
+
+// Cleanup removes blobs of attachments deleted directly or by items and lists cascades
+func (as *AttachmentService) Cleanup(ctx context.Context) error {
+	for {
+		keys, err := as.repo.GetDeletedKeys(ctx, cleanupBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(keys) == 0 {
+			return nil
+		}
+
+		for _, key := range keys {
+			if err := as.blobs.Delete(ctx, key); err != nil {
+				return err
+			}
+		}
+
+		if err := as.repo.RemoveDeletedKeys(ctx, keys); err != nil {
+			return err
+		}
+
+		if len(keys) < cleanupBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	pngData        = append([]byte("\x89PNG\x0D\x0A\x1A\x0A"), bytes.Repeat([]byte{0}, 600)...)
+	testAttachment = &models.Attachment{
+		ID:          1,
+		ItemID:      testItem.ID,
+		UserID:      1,
+		FileName:    "image.png",
+		ContentType: "image/png",
+		Size:        int64(len(pngData)),
+		StorageKey:  "1/key",
+	}
+	allowedTypes = []string{"image/png", "application/pdf"}
+)
+
+func TestAttachmentCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		size       int64
+		getItemErr error
+		putErr     error
+		putCalls   int
+		createID   int64
+		createErr  error
+		delErr     error
+		delCalls   int
+		expID      int64
+		expErr     error
+	}{
+		{
+			name:   "File too large",
+			data:   pngData,
+			size:   1001,
+			expErr: models.ErrFileTooLarge,
+		},
+		{
+			name:       "Item not found",
+			data:       pngData,
+			size:       int64(len(pngData)),
+			getItemErr: models.ErrNoItem,
+			expErr:     models.ErrNoItem,
+		},
+		{
+			name:   "Not allowed type",
+			data:   []byte("plain text"),
+			size:   10,
+			expErr: models.ErrFileTypeNotAllowed,
+		},
+		{
+			name:     "Put return error",
+			data:     pngData,
+			size:     int64(len(pngData)),
+			putErr:   ErrSome,
+			putCalls: 1,
+			expErr:   ErrSome,
+		},
+		{
+			name:      "Create return error, blob is removed",
+			data:      pngData,
+			size:      int64(len(pngData)),
+			putCalls:  1,
+			createErr: ErrSome,
+			delCalls:  1,
+			expErr:    ErrSome,
+		},
+		{
+			name:     "Success create",
+			data:     pngData,
+			size:     int64(len(pngData)),
+			putCalls: 1,
+			createID: 10,
+			expID:    10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(testItem, tc.getItemErr)
+
+			bs := new(mocks.BlobStore)
+			putCall := bs.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, tc.size)
+			putCall.Run(func(args mock.Arguments) {
+				data, err := ioutil.ReadAll(args.Get(3).(io.Reader))
+				require.NoError(t, err)
+				require.Equal(t, tc.data, data)
+				require.Equal(t, "image/png", args.String(2))
+				putCall.Return(tc.putErr)
+			})
+			bs.On("Delete", mock.Anything, mock.Anything).Return(tc.delErr)
+
+			ar := new(mocks.AttachmentRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(tc.createID, tc.createErr)
+
+			as := NewAttachmentService(ar, ir, bs, 1000, allowedTypes)
+
+			id, err := as.Create(context.Background(), 1, testItem.ID, 1, "../image.png", tc.size, bytes.NewReader(tc.data))
+			require.Equal(t, tc.expID, id)
+			require.Equal(t, tc.expErr, err)
+			bs.AssertNumberOfCalls(t, "Put", tc.putCalls)
+			bs.AssertNumberOfCalls(t, "Delete", tc.delCalls)
+			if tc.expErr == nil {
+				saved := ar.Calls[0].Arguments.Get(1).(*models.Attachment)
+				require.Equal(t, "image.png", saved.FileName)
+				require.Equal(t, tc.size, saved.Size)
+			}
+		})
+	}
+}
+
+func TestAttachmentDownload(t *testing.T) {
+	tests := []struct {
+		name   string
+		getErr error
+		blob   io.ReadCloser
+		blobEr error
+		expErr error
+	}{
+		{
+			name:   "Attachment not found",
+			getErr: models.ErrNoAttachment,
+			expErr: models.ErrNoAttachment,
+		},
+		{
+			name:   "Blob not found",
+			blobEr: models.ErrNoBlob,
+			expErr: models.ErrNoAttachment,
+		},
+		{
+			name:   "Blob store return error",
+			blobEr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name: "Success download",
+			blob: ioutil.NopCloser(bytes.NewReader(pngData)),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(testItem, nil)
+
+			ar := new(mocks.AttachmentRepository)
+			ar.On("GetAttachmentByID", mock.Anything, mock.Anything, mock.Anything).Return(testAttachment, tc.getErr)
+
+			bs := new(mocks.BlobStore)
+			bs.On("Get", mock.Anything, testAttachment.StorageKey).Return(tc.blob, tc.blobEr)
+
+			as := NewAttachmentService(ar, ir, bs, 1000, allowedTypes)
+
+			attachment, r, err := as.Download(context.Background(), 1, testItem.ID, testAttachment.ID)
+			require.Equal(t, tc.expErr, err)
+			if err == nil {
+				require.Equal(t, testAttachment, attachment)
+				require.Equal(t, tc.blob, r)
+			}
+		})
+	}
+}
+
+func TestAttachmentDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  int64
+		isAdmin bool
+		getErr  error
+		delErr  error
+		expErr  error
+	}{
+		{
+			name:   "Attachment not found",
+			userID: testAttachment.UserID,
+			getErr: models.ErrNoAttachment,
+			expErr: models.ErrNoAttachment,
+		},
+		{
+			name:   "Not uploader and not admin",
+			userID: testAttachment.UserID + 1,
+			expErr: models.ErrNoAttachmentAccess,
+		},
+		{
+			name:    "Admin deletes attachment",
+			userID:  testAttachment.UserID + 1,
+			isAdmin: true,
+		},
+		{
+			name:   "Delete return error",
+			userID: testAttachment.UserID,
+			delErr: ErrSome,
+			expErr: ErrSome,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(testItem, nil)
+
+			ar := new(mocks.AttachmentRepository)
+			ar.On("GetAttachmentByID", mock.Anything, mock.Anything, mock.Anything).Return(testAttachment, tc.getErr)
+			ar.On("Delete", mock.Anything, mock.Anything).Return(tc.delErr)
+
+			as := NewAttachmentService(ar, ir, nil, 1000, allowedTypes)
+
+			err := as.Delete(context.Background(), 1, testItem.ID, testAttachment.ID, tc.userID, tc.isAdmin)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestAttachmentCleanup(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		getErr    error
+		delErr    error
+		removeErr error
+		expErr    error
+	}{
+		{
+			name:   "GetDeletedKeys return error",
+			getErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name: "Nothing to clean",
+			keys: []string{},
+		},
+		{
+			name:   "Blob delete return error",
+			keys:   []string{"1/a"},
+			delErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:      "RemoveDeletedKeys return error",
+			keys:      []string{"1/a"},
+			removeErr: ErrSome,
+			expErr:    ErrSome,
+		},
+		{
+			name: "Success cleanup",
+			keys: []string{"1/a", "2/b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ar := new(mocks.AttachmentRepository)
+			ar.On("GetDeletedKeys", mock.Anything, cleanupBatchSize).Return(tc.keys, tc.getErr)
+			ar.On("RemoveDeletedKeys", mock.Anything, tc.keys).Return(tc.removeErr)
+
+			bs := new(mocks.BlobStore)
+			bs.On("Delete", mock.Anything, mock.Anything).Return(tc.delErr)
+
+			as := NewAttachmentService(ar, nil, bs, 1000, allowedTypes)
+
+			err := as.Cleanup(context.Background())
+			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				bs.AssertNumberOfCalls(t, "Delete", len(tc.keys))
+			}
+		})
+	}
+}
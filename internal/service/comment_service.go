@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+)
+
+var mentionRegexp = regexp.MustCompile(`(?:^|\s)@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+type CommentService struct {
+	repo        models.CommentRepository
+	itemRepo    models.ItemRepository
+	listRepo    models.ListRepository
+	mailService models.MailService
+}
+
+func NewCommentService(
+	repo models.CommentRepository,
+	itemRepo models.ItemRepository,
+	listRepo models.ListRepository,
+	mailService models.MailService) models.CommentService {
+
+	return &CommentService{
+		repo:        repo,
+		itemRepo:    itemRepo,
+		listRepo:    listRepo,
+		mailService: mailService,
+	}
+}
+
+// ParseMentions returns unique emails mentioned in body as @email
+func ParseMentions(body string) []string {
+	res := []string{}
+	seen := map[string]bool{}
+
+	for _, m := range mentionRegexp.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(strings.TrimRight(m[1], "."))
+		if !seen[email] {
+			seen[email] = true
+			res = append(res, email)
+		}
+	}
+
+	return res
+}
+
+func (cs *CommentService) notifyMentioned(ctx context.Context, listID, itemID, userID int64, body string) error {
+	emails := ParseMentions(body)
+	if len(emails) == 0 {
+		return nil
+	}
+
+	users, err := cs.listRepo.GetMembersByEmails(ctx, listID, emails)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		if u.ID == userID {
+			continue
+		}
+		if err := cs.mailService.SendMentionEmail(ctx, u, listID, itemID, body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cs *CommentService) Create(ctx context.Context, listID, itemID, userID int64, body string) (int64, error) {
+	if _, err := cs.itemRepo.GetItemByID(ctx, listID, itemID); err != nil {
+		return 0, err
+	}
+
+	commentID, err := cs.repo.Create(ctx, itemID, userID, body)
+	if err != nil {
+		return 0, err
+	}
+
+	// comment is already saved, so mention emails are best-effort
+	if err := cs.notifyMentioned(ctx, listID, itemID, userID, body); err != nil {
+		logging.FromContext(ctx).WithField("comment_id", commentID).Error("mention email: ", err)
+	}
+
+	return commentID, nil
+}
+
+func (cs *CommentService) GetComments(ctx context.Context, listID, itemID int64) ([]*models.Comment, error) {
+	if _, err := cs.itemRepo.GetItemByID(ctx, listID, itemID); err != nil {
+		return nil, err
+	}
+
+	return cs.repo.GetComments(ctx, itemID)
+}
+
+func (cs *CommentService) getComment(ctx context.Context, listID, itemID, commentID int64) (*models.Comment, error) {
+	if _, err := cs.itemRepo.GetItemByID(ctx, listID, itemID); err != nil {
+		return nil, err
+	}
+
+	return cs.repo.GetCommentByID(ctx, itemID, commentID)
+}
+
+func (cs *CommentService) Update(ctx context.Context, listID, itemID, commentID, userID int64, body string) error {
+	comment, err := cs.getComment(ctx, listID, itemID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != userID {
+		return models.ErrNoCommentAccess
+	}
+
+	return cs.repo.Update(ctx, commentID, body)
+}
+
+func (cs *CommentService) Delete(ctx context.Context, listID, itemID, commentID, userID int64, isAdmin bool) error {
+	comment, err := cs.getComment(ctx, listID, itemID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != userID && !isAdmin {
+		return models.ErrNoCommentAccess
+	}
+
+	return cs.repo.Delete(ctx, commentID)
+}
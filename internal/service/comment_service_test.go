@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testComment = &models.Comment{
+		ID:     1,
+		ItemID: testItem.ID,
+		UserID: 1,
+		Author: testEmail,
+		Body:   "comment",
+	}
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		exp  []string
+	}{
+		{"No mentions", "hello world", []string{}},
+		{"Email without @ prefix", "write to test@test.ru", []string{}},
+		{"One mention", "@test@test.ru look at this.", []string{"test@test.ru"}},
+		{"Mention at end of sentence", "ask @test@test.ru.", []string{"test@test.ru"}},
+		{
+			"Duplicate mentions",
+			"@Test@test.ru and @test@test.ru and @other@test.ru",
+			[]string{"test@test.ru", "other@test.ru"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exp, ParseMentions(tc.body))
+		})
+	}
+}
+
+func TestCommentCreate(t *testing.T) {
+	mentioned := &models.User{ID: 2, Email: "mentioned@test.ru"}
+	author := &models.User{ID: 1, Email: testEmail}
+
+	tests := []struct {
+		name       string
+		body       string
+		getItemErr error
+		createID   int64
+		createErr  error
+		members    []*models.User
+		membersErr error
+		mailErr    error
+		mailCalls  int
+		expID      int64
+		expErr     error
+	}{
+		{
+			name:       "Item not found",
+			body:       "comment",
+			getItemErr: models.ErrNoItem,
+			expID:      0,
+			expErr:     models.ErrNoItem,
+		},
+		{
+			name:      "Create return error",
+			body:      "comment",
+			createErr: ErrSome,
+			expID:     0,
+			expErr:    ErrSome,
+		},
+		{
+			name:     "Success without mentions",
+			body:     "comment",
+			createID: 10,
+			expID:    10,
+			expErr:   nil,
+		},
+		{
+			name:       "GetMembersByEmails error is not returned",
+			body:       "@mentioned@test.ru comment",
+			createID:   10,
+			membersErr: ErrSome,
+			expID:      10,
+			expErr:     nil,
+		},
+		{
+			name:      "Send mail error is not returned",
+			body:      "@mentioned@test.ru comment",
+			createID:  10,
+			members:   []*models.User{mentioned},
+			mailErr:   ErrSome,
+			mailCalls: 1,
+			expID:     10,
+			expErr:    nil,
+		},
+		{
+			name:      "Success with mentions, author is skipped",
+			body:      "@mentioned@test.ru @test@test.ru comment",
+			createID:  10,
+			members:   []*models.User{mentioned, author},
+			mailCalls: 1,
+			expID:     10,
+			expErr:    nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(testItem, tc.getItemErr)
+
+			cr := new(mocks.CommentRepository)
+			cr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(tc.createID, tc.createErr)
+
+			lr := new(mocks.ListRepository)
+			lr.On("GetMembersByEmails", mock.Anything, mock.Anything, mock.Anything).
+				Return(tc.members, tc.membersErr)
+
+			ms := new(mocks.MailService)
+			ms.On("SendMentionEmail", mock.Anything, mentioned, mock.Anything, mock.Anything, mock.Anything).
+				Return(tc.mailErr)
+
+			cs := NewCommentService(cr, ir, lr, ms)
+
+			id, err := cs.Create(context.Background(), 1, testItem.ID, author.ID, tc.body)
+			require.Equal(t, tc.expID, id)
+			require.Equal(t, tc.expErr, err)
+			ms.AssertNumberOfCalls(t, "SendMentionEmail", tc.mailCalls)
+		})
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	tests := []struct {
+		name       string
+		getItemErr error
+		retRes     []*models.Comment
+		retErr     error
+		expRes     []*models.Comment
+		expErr     error
+	}{
+		{
+			name:       "Item not found",
+			getItemErr: models.ErrNoItem,
+			expErr:     models.ErrNoItem,
+		},
+		{
+			name:   "Return error",
+			retErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:   "Success get",
+			retRes: []*models.Comment{testComment},
+			expRes: []*models.Comment{testComment},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(testItem, tc.getItemErr)
+
+			cr := new(mocks.CommentRepository)
+			cr.On("GetComments", mock.Anything, mock.Anything).Return(tc.retRes, tc.retErr)
+
+			cs := NewCommentService(cr, ir, nil, nil)
+
+			res, err := cs.GetComments(context.Background(), 1, testItem.ID)
+			require.Equal(t, tc.expRes, res)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestCommentUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int64
+		getItemErr error
+		getErr     error
+		updErr     error
+		expErr     error
+	}{
+		{
+			name:       "Item not found",
+			userID:     testComment.UserID,
+			getItemErr: models.ErrNoItem,
+			expErr:     models.ErrNoItem,
+		},
+		{
+			name:   "Comment not found",
+			userID: testComment.UserID,
+			getErr: models.ErrNoComment,
+			expErr: models.ErrNoComment,
+		},
+		{
+			name:   "Not author",
+			userID: testComment.UserID + 1,
+			expErr: models.ErrNoCommentAccess,
+		},
+		{
+			name:   "Update return error",
+			userID: testComment.UserID,
+			updErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:   "Success update",
+			userID: testComment.UserID,
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(testItem, tc.getItemErr)
+
+			cr := new(mocks.CommentRepository)
+			cr.On("GetCommentByID", mock.Anything, mock.Anything, mock.Anything).Return(testComment, tc.getErr)
+			cr.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(tc.updErr)
+
+			cs := NewCommentService(cr, ir, nil, nil)
+
+			err := cs.Update(context.Background(), 1, testItem.ID, testComment.ID, tc.userID, "new body")
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestCommentDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  int64
+		isAdmin bool
+		getErr  error
+		delErr  error
+		expErr  error
+	}{
+		{
+			name:   "Comment not found",
+			userID: testComment.UserID,
+			getErr: models.ErrNoComment,
+			expErr: models.ErrNoComment,
+		},
+		{
+			name:    "Not author and not admin",
+			userID:  testComment.UserID + 1,
+			isAdmin: false,
+			expErr:  models.ErrNoCommentAccess,
+		},
+		{
+			name:    "Admin deletes any comment",
+			userID:  testComment.UserID + 1,
+			isAdmin: true,
+			expErr:  nil,
+		},
+		{
+			name:   "Delete return error",
+			userID: testComment.UserID,
+			delErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:   "Author deletes comment",
+			userID: testComment.UserID,
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(testItem, nil)
+
+			cr := new(mocks.CommentRepository)
+			cr.On("GetCommentByID", mock.Anything, mock.Anything, mock.Anything).Return(testComment, tc.getErr)
+			cr.On("Delete", mock.Anything, mock.Anything).Return(tc.delErr)
+
+			cs := NewCommentService(cr, ir, nil, nil)
+
+			err := cs.Delete(context.Background(), 1, testItem.ID, testComment.ID, tc.userID, tc.isAdmin)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+type EventService struct {
+	events models.EventBus
+}
+
+func NewEventService(events models.EventBus) models.EventService {
+	return &EventService{
+		events: events,
+	}
+}
+
+// Subscribe returns activity of the list made since subscription, channel is closed when ctx is done
+func (es *EventService) Subscribe(ctx context.Context, listID int64) (<-chan *models.Activity, error) {
+	return es.events.Subscribe(ctx, listID)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newEventBusMock() *mocks.EventBus {
+	eb := new(mocks.EventBus)
+	eb.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	return eb
+}
+
+type afterCommitKey struct{}
+
+// testTransactor runs functions without database, AfterCommit functions are run
+// when outer InTx function succeeds
+type testTransactor struct{}
+
+func (testTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		return fn(ctx)
+	}
+
+	afterCommit := &[]func(){}
+	if err := fn(context.WithValue(ctx, afterCommitKey{}, afterCommit)); err != nil {
+		return err
+	}
+
+	for _, f := range *afterCommit {
+		f()
+	}
+	return nil
+}
+
+func (testTransactor) AfterCommit(ctx context.Context, fn func()) {
+	if afterCommit, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		*afterCommit = append(*afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+func TestSaveActivity(t *testing.T) {
+	activity := &models.Activity{ListID: 1, Action: models.ActionListUpdate}
+
+	tests := []struct {
+		name         string
+		createErr    error
+		txErr        error
+		publishErr   error
+		expErr       error
+		publishCalls int
+	}{
+		{
+			name:      "Create return error",
+			createErr: ErrSome,
+			expErr:    ErrSome,
+		},
+		{
+			name:   "Rolled back activity is not published",
+			txErr:  ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:         "Publish error is not returned",
+			publishErr:   ErrSome,
+			publishCalls: 1,
+		},
+		{
+			name:         "Success save",
+			publishCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, activity).Return(tc.createErr)
+
+			eb := new(mocks.EventBus)
+			eb.On("Publish", mock.Anything, activity).Return(tc.publishErr)
+
+			tx := testTransactor{}
+			err := tx.InTx(context.Background(), func(ctx context.Context) error {
+				if err := saveActivity(ctx, tx, ar, eb, activity); err != nil {
+					return err
+				}
+				return tc.txErr
+			})
+			require.Equal(t, tc.expErr, err)
+			eb.AssertNumberOfCalls(t, "Publish", tc.publishCalls)
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	events := make(chan *models.Activity)
+
+	eb := new(mocks.EventBus)
+	eb.On("Subscribe", mock.Anything, int64(1)).Return((<-chan *models.Activity)(events), nil)
+
+	es := NewEventService(eb)
+
+	res, err := es.Subscribe(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, (<-chan *models.Activity)(events), res)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+type HealthService struct {
+	checkers map[string]models.HealthChecker
+	timeout  time.Duration
+}
+
+// NewHealthService returns service of readiness checks, checkers are pinged
+// concurrently and each of them has timeout
+func NewHealthService(checkers map[string]models.HealthChecker, timeout time.Duration) models.HealthService {
+	return &HealthService{
+		checkers: checkers,
+		timeout:  timeout,
+	}
+}
+
+func (hs *HealthService) Check(ctx context.Context) *models.HealthReport {
+	report := &models.HealthReport{
+		Status: models.HealthUp,
+		Checks: make(map[string]*models.HealthCheck, len(hs.checkers)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, checker := range hs.checkers {
+		wg.Add(1)
+		go func(name string, checker models.HealthChecker) {
+			defer wg.Done()
+			check := hs.ping(ctx, checker)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = check
+			if check.Status != models.HealthUp {
+				report.Status = models.HealthDown
+			}
+		}(name, checker)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (hs *HealthService) ping(ctx context.Context, checker models.HealthChecker) *models.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, hs.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Ping(ctx)
+	check := &models.HealthCheck{
+		Status:    models.HealthUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		Err:       err,
+	}
+	if err != nil {
+		check.Status = models.HealthDown
+	}
+
+	return check
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		pgErr     error
+		redisErr  error
+		expStatus string
+		expChecks map[string]string
+	}{
+		{
+			name:      "All up",
+			expStatus: models.HealthUp,
+			expChecks: map[string]string{"postgres": models.HealthUp, "redis": models.HealthUp},
+		},
+		{
+			name:      "Redis down",
+			redisErr:  ErrSome,
+			expStatus: models.HealthDown,
+			expChecks: map[string]string{"postgres": models.HealthUp, "redis": models.HealthDown},
+		},
+		{
+			name:      "All down",
+			pgErr:     ErrSome,
+			redisErr:  ErrSome,
+			expStatus: models.HealthDown,
+			expChecks: map[string]string{"postgres": models.HealthDown, "redis": models.HealthDown},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pg := new(mocks.HealthChecker)
+			pg.On("Ping", mock.MatchedBy(func(ctx context.Context) bool {
+				_, ok := ctx.Deadline()
+				return ok
+			})).Return(tc.pgErr)
+
+			redis := new(mocks.HealthChecker)
+			redis.On("Ping", mock.Anything).Return(tc.redisErr)
+
+			hs := NewHealthService(map[string]models.HealthChecker{
+				"postgres": pg,
+				"redis":    redis,
+			}, time.Second)
+
+			report := hs.Check(context.Background())
+			require.Equal(t, tc.expStatus, report.Status)
+			require.Len(t, report.Checks, len(tc.expChecks))
+			for name, status := range tc.expChecks {
+				require.Equal(t, status, report.Checks[name].Status)
+			}
+			require.Equal(t, tc.redisErr, report.Checks["redis"].Err)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+// idempotencyLockTTL limits time of in-flight request, key is released if server dies before completion
+const idempotencyLockTTL = time.Minute
+
+type IdempotencyService struct {
+	repo models.IdempotencyRepository
+	ttl  time.Duration
+}
+
+// NewIdempotencyService returns service which stores responses of requests with
+// Idempotency-Key for ttl
+func NewIdempotencyService(repo models.IdempotencyRepository, ttl time.Duration) models.IdempotencyService {
+	return &IdempotencyService{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+func idempotencyKey(userID int64, key string) string {
+	return fmt.Sprintf("idempotency:%d:%s", userID, key)
+}
+
+func (is *IdempotencyService) Begin(ctx context.Context, userID int64, key, fingerprint string) (*models.IdempotentResponse, error) {
+	if len(key) == 0 || len(key) > 255 {
+		return nil, models.ErrBadIdempotencyKey
+	}
+
+	stored, err := is.repo.Reserve(ctx,
+		idempotencyKey(userID, key),
+		&models.IdempotentResponse{Fingerprint: fingerprint},
+		idempotencyLockTTL,
+	)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, models.ErrIdempotencyKeyReused
+	}
+
+	if !stored.Done {
+		return nil, models.ErrIdempotencyInFlight
+	}
+
+	return stored, nil
+}
+
+func (is *IdempotencyService) Complete(ctx context.Context, userID int64, key string, resp *models.IdempotentResponse) error {
+	resp.Done = true
+	return is.repo.Save(ctx, idempotencyKey(userID, key), resp, is.ttl)
+}
+
+func (is *IdempotencyService) Abort(ctx context.Context, userID int64, key string) error {
+	return is.repo.Delete(ctx, idempotencyKey(userID, key))
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyBegin(t *testing.T) {
+	done := &models.IdempotentResponse{Fingerprint: "fp", Done: true, Code: 200}
+
+	tests := []struct {
+		name      string
+		key       string
+		retStored *models.IdempotentResponse
+		retErr    error
+		expRes    *models.IdempotentResponse
+		expErr    error
+	}{
+		{
+			name:   "Key too long",
+			key:    strings.Repeat("k", 256),
+			expErr: models.ErrBadIdempotencyKey,
+		},
+		{
+			name:   "Reserve return error",
+			key:    "key",
+			retErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name: "First request",
+			key:  "key",
+		},
+		{
+			name:      "Key reused with another request",
+			key:       "key",
+			retStored: &models.IdempotentResponse{Fingerprint: "other", Done: true},
+			expErr:    models.ErrIdempotencyKeyReused,
+		},
+		{
+			name:      "Request in flight",
+			key:       "key",
+			retStored: &models.IdempotentResponse{Fingerprint: "fp"},
+			expErr:    models.ErrIdempotencyInFlight,
+		},
+		{
+			name:      "Return stored response",
+			key:       "key",
+			retStored: done,
+			expRes:    done,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mocks.IdempotencyRepository)
+			repo.On(
+				"Reserve", mock.Anything,
+				"idempotency:1:key",
+				&models.IdempotentResponse{Fingerprint: "fp"},
+				idempotencyLockTTL,
+			).Return(tc.retStored, tc.retErr)
+
+			is := NewIdempotencyService(repo, time.Hour)
+			res, err := is.Begin(context.Background(), 1, tc.key, "fp")
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestIdempotencyComplete(t *testing.T) {
+	repo := new(mocks.IdempotencyRepository)
+	repo.On("Save", mock.Anything, "idempotency:1:key", mock.MatchedBy(func(r *models.IdempotentResponse) bool {
+		return r.Done && r.Code == 201
+	}), time.Hour).Return(ErrSome)
+
+	is := NewIdempotencyService(repo, time.Hour)
+	err := is.Complete(context.Background(), 1, "key", &models.IdempotentResponse{Fingerprint: "fp", Code: 201})
+	require.Equal(t, ErrSome, err)
+}
+
+func TestIdempotencyAbort(t *testing.T) {
+	repo := new(mocks.IdempotencyRepository)
+	repo.On("Delete", mock.Anything, "idempotency:1:key").Return(nil)
+
+	is := NewIdempotencyService(repo, time.Hour)
+	err := is.Abort(context.Background(), 1, "key")
+	require.NoError(t, err)
+}
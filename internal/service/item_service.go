@@ -1,44 +1,198 @@
 package service
 
-import "github.com/VladimirStepanov/todo-app/internal/models"
+import (
+	"context"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/logging"
+)
 
 type ItemService struct {
-	repo models.ItemRepository
+	repo         models.ItemRepository
+	listRepo     models.ListRepository
+	activityRepo models.ActivityRepository
+	tx           models.Transactor
+	events       models.EventBus
+	mailService  models.MailService
 }
 
-func NewItemService(repo models.ItemRepository) models.ItemService {
+func NewItemService(
+	repo models.ItemRepository,
+	listRepo models.ListRepository,
+	activityRepo models.ActivityRepository,
+	tx models.Transactor,
+	events models.EventBus,
+	mailService models.MailService) models.ItemService {
+
 	return &ItemService{
-		repo: repo,
+		repo:         repo,
+		listRepo:     listRepo,
+		activityRepo: activityRepo,
+		tx:           tx,
+		events:       events,
+		mailService:  mailService,
 	}
 }
 
-func (is *ItemService) Create(title, description string, listID int64) (int64, error) {
-	return is.repo.Create(title, description, listID)
+// addActivity saves item mutation, mutations without changes are skipped
+func (is *ItemService) addActivity(ctx context.Context, listID, itemID, userID int64, action string, changes models.Changes) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return saveActivity(ctx, is.tx, is.activityRepo, is.events, &models.Activity{
+		ListID:  listID,
+		ItemID:  &itemID,
+		UserID:  &userID,
+		Action:  action,
+		Changes: changes,
+	})
 }
 
-func (is *ItemService) GetItems(listID int64) ([]*models.Item, error) {
-	return is.repo.GetItems(listID)
+// notifyAssignee sends email to new item assignee after commit of the change.
+// Assignee and item are read in the transaction, email is best-effort
+// and failure is only logged
+func (is *ItemService) notifyAssignee(ctx context.Context, listID, itemID, assigneeID int64) {
+	logger := logging.FromContext(ctx).WithField("item_id", itemID)
+
+	user, err := is.listRepo.GetMember(ctx, listID, assigneeID)
+	if err != nil {
+		logger.Error("assign email: ", err)
+		return
+	}
+
+	item, err := is.repo.GetItemByID(ctx, listID, itemID)
+	if err != nil {
+		logger.Error("assign email: ", err)
+		return
+	}
+
+	is.tx.AfterCommit(ctx, func() {
+		if err := is.mailService.SendAssignEmail(ctx, user, item); err != nil {
+			logger.Error("assign email: ", err)
+		}
+	})
 }
 
-func (is *ItemService) GetItemByID(listID, itemID int64) (*models.Item, error) {
-	return is.repo.GetItemByID(listID, itemID)
+func isValidPriority(priority int) bool {
+	return priority >= models.PriorityNone && priority <= models.PriorityHigh
 }
 
-func (is *ItemService) Update(listID, itemID int64, item *models.UpdateItemReq) error {
-	if item.Title == nil && item.Description == nil {
+func (is *ItemService) Create(ctx context.Context, listID, userID int64, item *models.CreateItemReq) (int64, error) {
+	if !isValidPriority(item.Priority) {
+		return 0, models.ErrBadPriority
+	}
+
+	if item.AssigneeID != nil && *item.AssigneeID == 0 {
+		item.AssigneeID = nil
+	}
+
+	var itemID int64
+
+	err := is.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		itemID, err = is.repo.Create(ctx, listID, userID, item)
+		if err != nil {
+			return err
+		}
+
+		changes := models.Changes{}
+		changes.Add("title", nil, item.Title)
+		changes.Add("description", nil, item.Description)
+		changes.Add("assignee_id", nil, int64Value(item.AssigneeID))
+		changes.Add("due_at", nil, timeValue(item.DueAt))
+		changes.Add("priority", nil, item.Priority)
+
+		if err := is.addActivity(ctx, listID, itemID, userID, models.ActionItemCreate, changes); err != nil {
+			return err
+		}
+
+		if item.AssigneeID != nil {
+			is.notifyAssignee(ctx, listID, itemID, *item.AssigneeID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return itemID, nil
+}
+
+func (is *ItemService) GetItems(ctx context.Context, listID int64, filter *models.ItemFilter, page *models.PageReq) ([]*models.Item, string, error) {
+	if err := normalizePage(page); err != nil {
+		return nil, "", err
+	}
+	return is.repo.GetItems(ctx, listID, filter, page)
+}
+
+func (is *ItemService) GetUserItems(ctx context.Context, userID int64, onlyAssigned bool) ([]*models.Item, error) {
+	return is.repo.GetUserItems(ctx, userID, onlyAssigned)
+}
+
+func (is *ItemService) GetItemByID(ctx context.Context, listID, itemID int64) (*models.Item, error) {
+	return is.repo.GetItemByID(ctx, listID, itemID)
+}
+
+func (is *ItemService) Update(ctx context.Context, listID, itemID, userID int64, item *models.UpdateItemReq) error {
+	if item.Title == nil && item.Description == nil && item.AssigneeID == nil &&
+		item.DueAt == nil && item.Priority == nil {
 		return models.ErrUpdateEmptyArgs
-	} else if len(*item.Title) < 5 {
+	} else if item.Title != nil && len(*item.Title) < 5 {
 		return models.ErrTitleTooShort
+	} else if item.Priority != nil && !isValidPriority(*item.Priority) {
+		return models.ErrBadPriority
 	}
-	return is.repo.Update(listID, itemID, item)
+
+	return is.update(ctx, listID, itemID, userID, models.ActionItemUpdate, item)
 }
 
-func (is *ItemService) Done(listID, itemID int64) error {
-	item := &models.UpdateItemReq{Done: new(bool)}
+func (is *ItemService) Done(ctx context.Context, listID, itemID, userID int64, version *int64) error {
+	item := &models.UpdateItemReq{Done: new(bool), Version: version}
 	*item.Done = true
-	return is.repo.Update(listID, itemID, item)
+
+	return is.update(ctx, listID, itemID, userID, models.ActionItemDone, item)
 }
 
-func (is *ItemService) Delete(listID, itemID int64) error {
-	return is.repo.Delete(listID, itemID)
+// update saves item changes with activity. Item is locked, so changes are computed
+// from the row which is updated. New assignee is notified
+func (is *ItemService) update(ctx context.Context, listID, itemID, userID int64, action string, item *models.UpdateItemReq) error {
+	return is.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := is.repo.GetItemForUpdate(ctx, listID, itemID)
+		if err != nil {
+			return err
+		}
+
+		if err := is.repo.Update(ctx, listID, itemID, userID, item); err != nil {
+			return err
+		}
+
+		changes := itemChanges(before, item)
+		if err := is.addActivity(ctx, listID, itemID, userID, action, changes); err != nil {
+			return err
+		}
+
+		if assignee, ok := changes["assignee_id"]; ok && assignee.After != nil {
+			is.notifyAssignee(ctx, listID, itemID, assignee.After.(int64))
+		}
+		return nil
+	})
+}
+
+// Delete moves item to trash
+func (is *ItemService) Delete(ctx context.Context, listID, itemID, userID int64, version *int64) error {
+	return is.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := is.repo.GetItemForUpdate(ctx, listID, itemID)
+		if err != nil {
+			return err
+		}
+
+		if err := is.repo.Delete(ctx, listID, itemID, version); err != nil {
+			return err
+		}
+
+		changes := models.Changes{}
+		changes.Add("title", before.Title, nil)
+		return is.addActivity(ctx, listID, itemID, userID, models.ActionItemDelete, changes)
+	})
 }
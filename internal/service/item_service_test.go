@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 
 	"github.com/VladimirStepanov/todo-app/internal/models"
@@ -46,18 +47,87 @@ func TestItemCreate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ir := new(mocks.ItemRepository)
-			ir.On("Create", mock.Anything, mock.Anything, mock.Anything).
+			ir.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tc.idRet, tc.expErr)
 
-			is := NewItemService(ir)
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
 
-			id, err := is.Create("title", "description", 1)
+			is := NewItemService(ir, nil, ar, testTransactor{}, newEventBusMock(), nil)
+
+			id, err := is.Create(context.Background(), 1, 2, &models.CreateItemReq{Title: "title", Description: "description"})
 			require.Equal(t, tc.expID, id)
 			require.Equal(t, tc.expErr, err)
 		})
 	}
 }
 
+func TestItemCreateWithAssignee(t *testing.T) {
+	assignee := &models.User{ID: 2, Email: "assignee@mail.ru"}
+
+	tests := []struct {
+		name       string
+		assigneeID int64
+		createErr  error
+		memberErr  error
+		mailCalls  int
+		expErr     error
+	}{
+		{
+			name:       "Assignee is not a list member",
+			assigneeID: assignee.ID,
+			createErr:  models.ErrNotListMember,
+			expErr:     models.ErrNotListMember,
+		},
+		{
+			name:       "GetMember error is not returned",
+			assigneeID: assignee.ID,
+			memberErr:  ErrSome,
+		},
+		{
+			name:       "Zero assignee is ignored",
+			assigneeID: 0,
+		},
+		{
+			name:       "Success create and notify",
+			assigneeID: assignee.ID,
+			mailCalls:  1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(testItem.ID, tc.createErr)
+			ir.On("GetItemByID", mock.Anything, testItem.ListID, testItem.ID).Return(testItem, nil)
+
+			lr := new(mocks.ListRepository)
+			lr.On("GetMember", mock.Anything, testItem.ListID, assignee.ID).Return(assignee, tc.memberErr)
+
+			ms := new(mocks.MailService)
+			ms.On("SendAssignEmail", mock.Anything, assignee, testItem).Return(nil)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			is := NewItemService(ir, lr, ar, testTransactor{}, newEventBusMock(), ms)
+
+			req := &models.CreateItemReq{
+				Title:       "title",
+				Description: "description",
+				AssigneeID:  &tc.assigneeID,
+			}
+			_, err := is.Create(context.Background(), testItem.ListID, 2, req)
+			require.Equal(t, tc.expErr, err)
+			ms.AssertNumberOfCalls(t, "SendAssignEmail", tc.mailCalls)
+			if tc.assigneeID == 0 {
+				require.Nil(t, req.AssigneeID)
+			}
+		})
+	}
+}
+
 func TestGetItemByID(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -92,11 +162,11 @@ func TestGetItemByID(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ir := new(mocks.ItemRepository)
-			ir.On("GetItemByID", mock.Anything, mock.Anything).Return(tc.retItem, tc.retErr)
+			ir.On("GetItemByID", mock.Anything, mock.Anything, mock.Anything).Return(tc.retItem, tc.retErr)
 
-			is := NewItemService(ir)
+			is := NewItemService(ir, nil, nil, nil, nil, nil)
 
-			retItem, err := is.GetItemByID(testItem.ListID, testItem.ID)
+			retItem, err := is.GetItemByID(context.Background(), testItem.ListID, testItem.ID)
 			require.Equal(t, tc.expItem, retItem)
 			require.Equal(t, tc.expErr, err)
 		})
@@ -129,12 +199,23 @@ func TestDeleteItem(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ir := new(mocks.ItemRepository)
-			ir.On("Delete", mock.Anything, mock.Anything).Return(tc.retErr)
+			ir.On("GetItemForUpdate", mock.Anything, testItem.ListID, testItem.ID).Return(testItem, nil)
+			ir.On("Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.retErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
 
-			is := NewItemService(ir)
+			is := NewItemService(ir, nil, ar, testTransactor{}, newEventBusMock(), nil)
 
-			err := is.Delete(testItem.ListID, testItem.ID)
+			err := is.Delete(context.Background(), testItem.ListID, testItem.ID, 2, nil)
 			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				activity := ar.Calls[0].Arguments.Get(1).(*models.Activity)
+				require.Equal(t, models.ActionItemDelete, activity.Action)
+				require.Equal(t, &models.FieldChange{Before: testItem.Title, After: nil}, activity.Changes["title"])
+			} else {
+				ar.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
@@ -165,6 +246,23 @@ func TestItemUpdate(t *testing.T) {
 			retErr: nil,
 			expErr: models.ErrUpdateEmptyArgs,
 		},
+		{
+			name: "Only assignee",
+			req: func() *models.UpdateItemReq {
+				return &models.UpdateItemReq{AssigneeID: new(int64)}
+			},
+			retErr: nil,
+			expErr: nil,
+		},
+		{
+			name: "Bad priority",
+			req: func() *models.UpdateItemReq {
+				priority := models.PriorityHigh + 1
+				return &models.UpdateItemReq{Priority: &priority}
+			},
+			retErr: nil,
+			expErr: models.ErrBadPriority,
+		},
 		{
 			name: "Title too short error",
 			req: func() *models.UpdateItemReq {
@@ -206,11 +304,15 @@ func TestItemUpdate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ir := new(mocks.ItemRepository)
-			ir.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(tc.retErr)
+			ir.On("GetItemForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(testItem, nil)
+			ir.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.retErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
 
-			is := NewItemService(ir)
+			is := NewItemService(ir, nil, ar, testTransactor{}, newEventBusMock(), nil)
 
-			err := is.Update(1, 1, tc.req())
+			err := is.Update(context.Background(), 1, 1, 2, tc.req())
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -230,6 +332,140 @@ func TestGetItems(t *testing.T) {
 		},
 	}
 
+	tests := []struct {
+		name     string
+		page     *models.PageReq
+		expPage  *models.PageReq
+		retErr   error
+		expErr   error
+		expRes   []*models.Item
+		expCalls int
+	}{
+		{
+			name:   "Bad limit",
+			page:   &models.PageReq{Limit: models.MaxPageLimit + 1},
+			expErr: models.ErrBadPageLimit,
+		},
+		{
+			name:     "Return unknown error",
+			page:     &models.PageReq{},
+			expPage:  &models.PageReq{Limit: models.DefaultPageLimit, Sort: models.SortCreatedAt},
+			retErr:   ErrSome,
+			expErr:   ErrSome,
+			expRes:   nil,
+			expCalls: 1,
+		},
+		{
+			name:     "Success get",
+			page:     &models.PageReq{Limit: 5, Sort: models.SortPriority, Desc: true},
+			expPage:  &models.PageReq{Limit: 5, Sort: models.SortPriority, Desc: true},
+			retErr:   nil,
+			expErr:   nil,
+			expRes:   result,
+			expCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := &models.ItemFilter{}
+
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItems", mock.Anything, int64(1), filter, tc.expPage).Return(tc.expRes, "next", tc.retErr)
+
+			is := NewItemService(ir, nil, nil, nil, nil, nil)
+
+			res, _, err := is.GetItems(context.Background(), 1, filter, tc.page)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+			ir.AssertNumberOfCalls(t, "GetItems", tc.expCalls)
+		})
+	}
+}
+
+func TestItemUpdateAssignee(t *testing.T) {
+	assignee := &models.User{ID: 2, Email: "assignee@mail.ru"}
+	assigned := *testItem
+	assigned.AssigneeID = &assignee.ID
+
+	tests := []struct {
+		name      string
+		before    *models.Item
+		updateErr error
+		memberErr error
+		mailErr   error
+		outerErr  error
+		mailCalls int
+		expErr    error
+	}{
+		{
+			name:      "Assignee is not a list member",
+			before:    testItem,
+			updateErr: models.ErrNotListMember,
+			expErr:    models.ErrNotListMember,
+		},
+		{
+			name:      "GetMember error is not returned",
+			before:    testItem,
+			memberErr: ErrSome,
+		},
+		{
+			name:      "SendAssignEmail error is not returned",
+			before:    testItem,
+			mailErr:   ErrSome,
+			mailCalls: 1,
+		},
+		{
+			name:   "Same assignee is not notified",
+			before: &assigned,
+		},
+		{
+			name:     "Email is not sent if outer transaction fails",
+			before:   testItem,
+			outerErr: ErrSome,
+			expErr:   ErrSome,
+		},
+		{
+			name:      "Success update and notify",
+			before:    testItem,
+			mailCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.updateErr)
+			ir.On("GetItemByID", mock.Anything, testItem.ListID, testItem.ID).Return(testItem, nil)
+			ir.On("GetItemForUpdate", mock.Anything, testItem.ListID, testItem.ID).Return(tc.before, nil)
+
+			lr := new(mocks.ListRepository)
+			lr.On("GetMember", mock.Anything, testItem.ListID, assignee.ID).Return(assignee, tc.memberErr)
+
+			ms := new(mocks.MailService)
+			ms.On("SendAssignEmail", mock.Anything, assignee, testItem).Return(tc.mailErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			tx := testTransactor{}
+			is := NewItemService(ir, lr, ar, tx, newEventBusMock(), ms)
+
+			// update is a part of outer transaction, e.g. of sync mutation
+			err := tx.InTx(context.Background(), func(ctx context.Context) error {
+				if err := is.Update(ctx, testItem.ListID, testItem.ID, 2, &models.UpdateItemReq{AssigneeID: &assignee.ID}); err != nil {
+					return err
+				}
+				ms.AssertNotCalled(t, "SendAssignEmail", mock.Anything, mock.Anything, mock.Anything)
+				return tc.outerErr
+			})
+			require.Equal(t, tc.expErr, err)
+			ms.AssertNumberOfCalls(t, "SendAssignEmail", tc.mailCalls)
+		})
+	}
+}
+
+func TestGetUserItems(t *testing.T) {
 	tests := []struct {
 		name   string
 		retErr error
@@ -246,20 +482,72 @@ func TestGetItems(t *testing.T) {
 			name:   "Success get",
 			retErr: nil,
 			expErr: nil,
-			expRes: result,
+			expRes: []*models.Item{testItem},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ir := new(mocks.ItemRepository)
-			ir.On("GetItems", mock.Anything).Return(tc.expRes, tc.retErr)
+			ir.On("GetUserItems", mock.Anything, int64(1), true).Return(tc.expRes, tc.retErr)
 
-			is := NewItemService(ir)
+			is := NewItemService(ir, nil, nil, nil, nil, nil)
 
-			res, err := is.GetItems(1)
+			res, err := is.GetUserItems(context.Background(), 1, true)
 			require.Equal(t, tc.expErr, err)
 			require.Equal(t, tc.expRes, res)
 		})
 	}
 }
+
+func TestItemUpdateActivity(t *testing.T) {
+	title := "new title"
+	description := testItem.Description
+	priority := models.PriorityHigh
+
+	tests := []struct {
+		name       string
+		req        *models.UpdateItemReq
+		expChanges models.Changes
+	}{
+		{
+			name: "Unchanged fields are skipped",
+			req:  &models.UpdateItemReq{Title: &title, Description: &description, Priority: &priority},
+			expChanges: models.Changes{
+				"title":    {Before: testItem.Title, After: title},
+				"priority": {Before: testItem.Priority, After: priority},
+			},
+		},
+		{
+			name:       "Nothing changed",
+			req:        &models.UpdateItemReq{Description: &description},
+			expChanges: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("GetItemForUpdate", mock.Anything, testItem.ListID, testItem.ID).Return(testItem, nil)
+			ir.On("Update", mock.Anything, testItem.ListID, testItem.ID, int64(2), tc.req).Return(nil)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			is := NewItemService(ir, nil, ar, testTransactor{}, newEventBusMock(), nil)
+
+			err := is.Update(context.Background(), testItem.ListID, testItem.ID, 2, tc.req)
+			require.NoError(t, err)
+			if tc.expChanges == nil {
+				ar.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			activity := ar.Calls[0].Arguments.Get(1).(*models.Activity)
+			require.Equal(t, models.ActionItemUpdate, activity.Action)
+			require.Equal(t, testItem.ID, *activity.ItemID)
+			require.Equal(t, int64(2), *activity.UserID)
+			require.Equal(t, tc.expChanges, activity.Changes)
+		})
+	}
+}
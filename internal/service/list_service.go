@@ -1,46 +1,260 @@
 package service
 
-import "github.com/VladimirStepanov/todo-app/internal/models"
+import (
+	"context"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
 
 type ListService struct {
-	repo models.ListRepository
+	repo         models.ListRepository
+	activityRepo models.ActivityRepository
+	tx           models.Transactor
+	events       models.EventBus
 }
 
-func NewListService(repo models.ListRepository) models.ListService {
+func NewListService(
+	repo models.ListRepository,
+	activityRepo models.ActivityRepository,
+	tx models.Transactor,
+	events models.EventBus) models.ListService {
+
 	return &ListService{
-		repo: repo,
+		repo:         repo,
+		activityRepo: activityRepo,
+		tx:           tx,
+		events:       events,
 	}
 }
 
-func (ls *ListService) Create(title, description string, userID int64) (int64, error) {
-	return ls.repo.Create(title, description, userID)
+func (ls *ListService) saveActivity(ctx context.Context, activity *models.Activity) error {
+	return saveActivity(ctx, ls.tx, ls.activityRepo, ls.events, activity)
 }
 
-func (ls *ListService) IsListAdmin(ListID, userID int64) error {
-	return ls.repo.IsListAdmin(ListID, userID)
+func (ls *ListService) Create(ctx context.Context, title, description string, userID int64) (int64, error) {
+	var listID int64
+
+	err := ls.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		listID, err = ls.repo.Create(ctx, title, description, userID)
+		if err != nil {
+			return err
+		}
+
+		changes := models.Changes{}
+		changes.Add("title", nil, title)
+		changes.Add("description", nil, description)
+
+		return ls.saveActivity(ctx, &models.Activity{
+			ListID:  listID,
+			UserID:  &userID,
+			Action:  models.ActionListCreate,
+			Changes: changes,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return listID, nil
 }
 
-func (ls *ListService) EditRole(listID, userID int64, role bool) error {
-	return ls.repo.EditRole(listID, userID, role)
+func (ls *ListService) IsListAdmin(ctx context.Context, ListID, userID int64) error {
+	return ls.repo.IsListAdmin(ctx, ListID, userID)
 }
 
-func (ls *ListService) GetListByID(listID, userID int64) (*models.List, error) {
-	return ls.repo.GetListByID(listID, userID)
+// EditRole sets role of user in list, user who is not a member is added to the list.
+// List is locked, so concurrent member changes see role saved by each other
+func (ls *ListService) EditRole(ctx context.Context, listID, adminID, userID int64, role bool) error {
+	return ls.tx.InTx(ctx, func(ctx context.Context) error {
+		if _, err := ls.repo.GetListForUpdate(ctx, listID, adminID); err != nil {
+			return err
+		}
+
+		var wasAdmin interface{}
+
+		switch err := ls.repo.IsListAdmin(ctx, listID, userID); err {
+		case nil:
+			wasAdmin = true
+		case models.ErrNoListAccess:
+			wasAdmin = false
+		case models.ErrNoList:
+			wasAdmin = nil
+		default:
+			return err
+		}
+
+		if err := ls.repo.EditRole(ctx, listID, userID, role); err != nil {
+			return err
+		}
+
+		changes := models.Changes{}
+		changes.Add("is_admin", wasAdmin, role)
+		if len(changes) == 0 {
+			return nil
+		}
+
+		action := models.ActionRoleChange
+		if wasAdmin == nil {
+			action = models.ActionMemberAdd
+		}
+
+		return ls.saveActivity(ctx, &models.Activity{
+			ListID:   listID,
+			UserID:   &adminID,
+			MemberID: &userID,
+			Action:   action,
+			Changes:  changes,
+		})
+	})
 }
 
-func (ls *ListService) GetUserLists(userID int64) ([]*models.List, error) {
-	return ls.repo.GetUserLists(userID)
+func (ls *ListService) GetListByID(ctx context.Context, listID, userID int64) (*models.List, error) {
+	return ls.repo.GetListByID(ctx, listID, userID)
+}
+
+func (ls *ListService) GetUserLists(ctx context.Context, userID int64, filter *models.ListFilter, page *models.PageReq) ([]*models.List, string, error) {
+	if err := normalizePage(page); err != nil {
+		return nil, "", err
+	}
+	return ls.repo.GetUserLists(ctx, userID, filter, page)
 }
 
-func (ls *ListService) Delete(listID int64) error {
-	return ls.repo.Delete(listID)
+// Delete moves list to trash, archived list is read-only and can't be deleted
+func (ls *ListService) Delete(ctx context.Context, listID, userID int64, version *int64) error {
+	return ls.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := ls.repo.GetListForUpdate(ctx, listID, userID)
+		if err != nil {
+			return err
+		}
+
+		if before.ArchivedAt != nil {
+			return models.ErrListArchived
+		}
+
+		if err := ls.repo.Delete(ctx, listID, version); err != nil {
+			return err
+		}
+
+		return ls.saveActivity(ctx, &models.Activity{
+			ListID: listID,
+			UserID: &userID,
+			Action: models.ActionListDelete,
+		})
+	})
 }
 
-func (ls *ListService) Update(listID int64, list *models.UpdateListReq) error {
+func (ls *ListService) Update(ctx context.Context, listID, userID int64, list *models.UpdateListReq) error {
 	if list.Title == nil && list.Description == nil {
 		return models.ErrUpdateEmptyArgs
-	} else if len(*list.Title) < 5 {
+	} else if list.Title != nil && len(*list.Title) < 5 {
 		return models.ErrTitleTooShort
 	}
-	return ls.repo.Update(listID, list)
+
+	// list is locked, so changes are computed from the row which is updated
+	return ls.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := ls.repo.GetListForUpdate(ctx, listID, userID)
+		if err != nil {
+			return err
+		}
+
+		if before.ArchivedAt != nil {
+			return models.ErrListArchived
+		}
+
+		if err := ls.repo.Update(ctx, listID, userID, list); err != nil {
+			return err
+		}
+
+		changes := listChanges(before, list)
+		if len(changes) == 0 {
+			return nil
+		}
+
+		return ls.saveActivity(ctx, &models.Activity{
+			ListID:  listID,
+			UserID:  &userID,
+			Action:  models.ActionListUpdate,
+			Changes: changes,
+		})
+	})
+}
+
+// RemoveMember removes user from list, items assigned to the user are unassigned
+// and each of them gets its own update activity
+func (ls *ListService) RemoveMember(ctx context.Context, listID, adminID, userID int64) error {
+	return ls.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := ls.repo.RemoveMember(ctx, listID, userID); err != nil {
+			return err
+		}
+
+		itemIDs, err := ls.repo.UnassignMember(ctx, listID, userID, adminID)
+		if err != nil {
+			return err
+		}
+
+		for _, itemID := range itemIDs {
+			itemID := itemID
+			changes := models.Changes{}
+			changes.Add("assignee_id", userID, nil)
+
+			err := ls.saveActivity(ctx, &models.Activity{
+				ListID:  listID,
+				ItemID:  &itemID,
+				UserID:  &adminID,
+				Action:  models.ActionItemUpdate,
+				Changes: changes,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return ls.saveActivity(ctx, &models.Activity{
+			ListID:   listID,
+			UserID:   &adminID,
+			MemberID: &userID,
+			Action:   models.ActionMemberRemove,
+		})
+	})
+}
+
+func (ls *ListService) GetActivity(ctx context.Context, listID int64, page *models.PageReq) ([]*models.Activity, string, error) {
+	if err := normalizePage(page); err != nil {
+		return nil, "", err
+	}
+	return ls.activityRepo.GetActivity(ctx, listID, page)
+}
+
+// SetArchived archives or unarchives list, archived list is read-only for all members
+func (ls *ListService) SetArchived(ctx context.Context, listID, userID int64, archived bool) error {
+	return ls.tx.InTx(ctx, func(ctx context.Context) error {
+		list, err := ls.repo.GetListForUpdate(ctx, listID, userID)
+		if err != nil {
+			return err
+		}
+
+		if (list.ArchivedAt != nil) == archived {
+			return nil
+		}
+
+		if err := ls.repo.SetArchived(ctx, listID, userID, archived); err != nil {
+			return err
+		}
+
+		action := models.ActionListUnarchive
+		if archived {
+			action = models.ActionListArchive
+		}
+
+		return ls.saveActivity(ctx, &models.Activity{
+			ListID: listID,
+			UserID: &userID,
+			Action: action,
+		})
+	})
+}
+
+func (ls *ListService) IsListArchived(ctx context.Context, listID int64) (bool, error) {
+	return ls.repo.IsListArchived(ctx, listID)
 }
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/VladimirStepanov/todo-app/internal/models"
 	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
@@ -44,12 +46,15 @@ func TestListCreate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			lr := new(mocks.ListRepository)
-			lr.On("Create", mock.Anything, mock.Anything, mock.Anything).
+			lr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tc.idRet, tc.expErr)
 
-			ls := NewListService(lr)
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
 
-			id, err := ls.Create("title", "description", 1)
+			ls := NewListService(lr, ar, testTransactor{}, newEventBusMock())
+
+			id, err := ls.Create(context.Background(), "title", "description", 1)
 			require.Equal(t, tc.expID, id)
 			require.Equal(t, tc.expErr, err)
 		})
@@ -90,11 +95,11 @@ func TestListGetByID(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			lr := new(mocks.ListRepository)
-			lr.On("GetListByID", mock.Anything, mock.Anything).Return(tc.retList, tc.retErr)
+			lr.On("GetListByID", mock.Anything, mock.Anything, mock.Anything).Return(tc.retList, tc.retErr)
 
-			ls := NewListService(lr)
+			ls := NewListService(lr, nil, nil, nil)
 
-			retList, err := ls.GetListByID(1, 1)
+			retList, err := ls.GetListByID(context.Background(), 1, 1)
 			require.Equal(t, tc.expList, retList)
 			require.Equal(t, tc.expErr, err)
 		})
@@ -132,11 +137,11 @@ func TestIsListAdmin(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			lr := new(mocks.ListRepository)
-			lr.On("IsListAdmin", mock.Anything, mock.Anything).Return(tc.retErr)
+			lr.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(tc.retErr)
 
-			ls := NewListService(lr)
+			ls := NewListService(lr, nil, nil, nil)
 
-			err := ls.IsListAdmin(1, 1)
+			err := ls.IsListAdmin(context.Background(), 1, 1)
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -163,11 +168,16 @@ func TestEditRole(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			lr := new(mocks.ListRepository)
-			lr.On("EditRole", mock.Anything, mock.Anything, mock.Anything).Return(tc.retErr)
+			lr.On("GetListForUpdate", mock.Anything, int64(1), int64(1)).Return(testList, nil)
+			lr.On("IsListAdmin", mock.Anything, mock.Anything, mock.Anything).Return(models.ErrNoListAccess)
+			lr.On("EditRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.retErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
 
-			ls := NewListService(lr)
+			ls := NewListService(lr, ar, testTransactor{}, newEventBusMock())
 
-			err := ls.EditRole(1, 1, true)
+			err := ls.EditRole(context.Background(), 1, 1, 2, true)
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -199,12 +209,20 @@ func TestDeleteList(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			lr := new(mocks.ListRepository)
-			lr.On("Delete", mock.Anything).Return(tc.retErr)
+			lr.On("GetListForUpdate", mock.Anything, int64(1), int64(2)).Return(&models.List{ID: 1}, nil)
+			lr.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(tc.retErr)
 
-			ls := NewListService(lr)
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
 
-			err := ls.Delete(1)
+			ls := NewListService(lr, ar, testTransactor{}, newEventBusMock())
+
+			err := ls.Delete(context.Background(), 1, 2, nil)
 			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				activity := ar.Calls[0].Arguments.Get(1).(*models.Activity)
+				require.Equal(t, models.ActionListDelete, activity.Action)
+			}
 		})
 	}
 }
@@ -235,6 +253,15 @@ func TestUpdate(t *testing.T) {
 			retErr: nil,
 			expErr: models.ErrUpdateEmptyArgs,
 		},
+		{
+			name: "Only description",
+			req: func() *models.UpdateListReq {
+				description := "world"
+				return &models.UpdateListReq{Description: &description}
+			},
+			retErr: nil,
+			expErr: nil,
+		},
 		{
 			name: "Title too short error",
 			req: func() *models.UpdateListReq {
@@ -276,11 +303,15 @@ func TestUpdate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			lr := new(mocks.ListRepository)
-			lr.On("Update", mock.Anything, mock.Anything).Return(tc.retErr)
+			lr.On("GetListForUpdate", mock.Anything, int64(1), int64(2)).Return(testList, nil)
+			lr.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.retErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
 
-			ls := NewListService(lr)
+			ls := NewListService(lr, ar, testTransactor{}, newEventBusMock())
 
-			err := ls.Update(1, tc.req())
+			err := ls.Update(context.Background(), 1, 2, tc.req())
 			require.Equal(t, tc.expErr, err)
 		})
 	}
@@ -323,11 +354,287 @@ func TestGetUserLists(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			lr := new(mocks.ListRepository)
-			lr.On("GetUserLists", mock.Anything).Return(tc.expRes, tc.retErr)
+			lr.On("GetUserLists", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.expRes, "", tc.retErr)
+
+			ls := NewListService(lr, nil, nil, nil)
+
+			res, _, err := ls.GetUserLists(context.Background(), 1, &models.ListFilter{}, &models.PageReq{})
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name        string
+		retErr      error
+		itemIDs     []int64
+		unassignErr error
+		expErr      error
+		expActions  []string
+	}{
+		{
+			name:   "User is not a member",
+			retErr: models.ErrNotListMember,
+			expErr: models.ErrNotListMember,
+		},
+		{
+			name:        "UnassignMember return error",
+			unassignErr: ErrSome,
+			expErr:      ErrSome,
+		},
+		{
+			name:       "Success remove without assigned items",
+			itemIDs:    []int64{},
+			expActions: []string{models.ActionMemberRemove},
+		},
+		{
+			name:       "Success remove with assigned items",
+			itemIDs:    []int64{7, 8},
+			expActions: []string{models.ActionItemUpdate, models.ActionItemUpdate, models.ActionMemberRemove},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lr := new(mocks.ListRepository)
+			lr.On("RemoveMember", mock.Anything, int64(1), int64(2)).Return(tc.retErr)
+			lr.On("UnassignMember", mock.Anything, int64(1), int64(2), int64(3)).Return(tc.itemIDs, tc.unassignErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			ls := NewListService(lr, ar, testTransactor{}, newEventBusMock())
+
+			err := ls.RemoveMember(context.Background(), 1, 3, 2)
+			require.Equal(t, tc.expErr, err)
+
+			actions := []string{}
+			for i, call := range ar.Calls {
+				activity := call.Arguments.Get(1).(*models.Activity)
+				actions = append(actions, activity.Action)
+				require.Equal(t, int64(3), *activity.UserID)
+
+				if activity.Action == models.ActionItemUpdate {
+					require.Equal(t, tc.itemIDs[i], *activity.ItemID)
+					require.Equal(t, models.Changes{"assignee_id": {Before: int64(2), After: nil}}, activity.Changes)
+				}
+			}
+			if tc.expErr == nil {
+				require.Equal(t, tc.expActions, actions)
+			}
+		})
+	}
+}
+
+func TestEditRoleActivity(t *testing.T) {
+	tests := []struct {
+		name       string
+		isAdminErr error
+		role       bool
+		expAction  string
+		expChanges models.Changes
+	}{
+		{
+			name:       "New member",
+			isAdminErr: models.ErrNoList,
+			role:       false,
+			expAction:  models.ActionMemberAdd,
+			expChanges: models.Changes{"is_admin": {Before: nil, After: false}},
+		},
+		{
+			name:       "Member becomes admin",
+			isAdminErr: models.ErrNoListAccess,
+			role:       true,
+			expAction:  models.ActionRoleChange,
+			expChanges: models.Changes{"is_admin": {Before: false, After: true}},
+		},
+		{
+			name:       "Role is not changed",
+			isAdminErr: nil,
+			role:       true,
+			expChanges: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lr := new(mocks.ListRepository)
+			lr.On("GetListForUpdate", mock.Anything, int64(1), int64(3)).Return(testList, nil)
+			lr.On("IsListAdmin", mock.Anything, int64(1), int64(2)).Return(tc.isAdminErr)
+			lr.On("EditRole", mock.Anything, int64(1), int64(2), tc.role).Return(nil)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			ls := NewListService(lr, ar, testTransactor{}, newEventBusMock())
+
+			err := ls.EditRole(context.Background(), 1, 3, 2, tc.role)
+			require.NoError(t, err)
+			if tc.expChanges == nil {
+				ar.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			activity := ar.Calls[0].Arguments.Get(1).(*models.Activity)
+			require.Equal(t, tc.expAction, activity.Action)
+			require.Equal(t, int64(3), *activity.UserID)
+			require.Equal(t, int64(2), *activity.MemberID)
+			require.Equal(t, tc.expChanges, activity.Changes)
+		})
+	}
+}
+
+func TestUpdateActivity(t *testing.T) {
+	title := "new title"
+	req := &models.UpdateListReq{Title: &title, Description: &testList.Description}
+
+	lr := new(mocks.ListRepository)
+	lr.On("GetListForUpdate", mock.Anything, int64(1), int64(2)).Return(testList, nil)
+	lr.On("Update", mock.Anything, int64(1), int64(2), req).Return(nil)
+
+	ar := new(mocks.ActivityRepository)
+	ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	ls := NewListService(lr, ar, testTransactor{}, newEventBusMock())
+
+	err := ls.Update(context.Background(), 1, 2, req)
+	require.NoError(t, err)
+
+	activity := ar.Calls[0].Arguments.Get(1).(*models.Activity)
+	require.Equal(t, models.ActionListUpdate, activity.Action)
+	require.Equal(t, models.Changes{"title": {Before: testList.Title, After: title}}, activity.Changes)
+}
+
+func TestUpdateArchivedList(t *testing.T) {
+	archivedAt := time.Now()
+	archived := &models.List{ID: 1, Title: "hello", ArchivedAt: &archivedAt}
+
+	lr := new(mocks.ListRepository)
+	lr.On("GetListForUpdate", mock.Anything, int64(1), int64(2)).Return(archived, nil)
+
+	ls := NewListService(lr, nil, testTransactor{}, nil)
+
+	title := "new title"
+	err := ls.Update(context.Background(), 1, 2, &models.UpdateListReq{Title: &title})
+	require.Equal(t, models.ErrListArchived, err)
+	lr.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	err = ls.Delete(context.Background(), 1, 2, nil)
+	require.Equal(t, models.ErrListArchived, err)
+	lr.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetArchived(t *testing.T) {
+	archivedAt := time.Now()
+
+	tests := []struct {
+		name      string
+		list      *models.List
+		getErr    error
+		archived  bool
+		setErr    error
+		expErr    error
+		expAction string
+	}{
+		{
+			name:     "List not found",
+			getErr:   models.ErrNoList,
+			archived: true,
+			expErr:   models.ErrNoList,
+		},
+		{
+			name:     "Already archived",
+			list:     &models.List{ID: 1, ArchivedAt: &archivedAt},
+			archived: true,
+		},
+		{
+			name:     "SetArchived return error",
+			list:     &models.List{ID: 1},
+			archived: true,
+			setErr:   ErrSome,
+			expErr:   ErrSome,
+		},
+		{
+			name:      "Success archive",
+			list:      &models.List{ID: 1},
+			archived:  true,
+			expAction: models.ActionListArchive,
+		},
+		{
+			name:      "Success unarchive",
+			list:      &models.List{ID: 1, ArchivedAt: &archivedAt},
+			archived:  false,
+			expAction: models.ActionListUnarchive,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lr := new(mocks.ListRepository)
+			lr.On("GetListForUpdate", mock.Anything, int64(1), int64(2)).Return(tc.list, tc.getErr)
+			lr.On("SetArchived", mock.Anything, int64(1), int64(2), tc.archived).Return(tc.setErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			ls := NewListService(lr, ar, testTransactor{}, newEventBusMock())
+
+			err := ls.SetArchived(context.Background(), 1, 2, tc.archived)
+			require.Equal(t, tc.expErr, err)
+
+			if tc.expAction == "" {
+				ar.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			activity := ar.Calls[0].Arguments.Get(1).(*models.Activity)
+			require.Equal(t, tc.expAction, activity.Action)
+		})
+	}
+}
+
+func TestGetActivity(t *testing.T) {
+	result := []*models.Activity{
+		{ID: 1, ListID: 1, Action: models.ActionListCreate},
+	}
+
+	tests := []struct {
+		name   string
+		page   *models.PageReq
+		retErr error
+		expErr error
+		expRes []*models.Activity
+	}{
+		{
+			name:   "Bad limit",
+			page:   &models.PageReq{Limit: -1},
+			expErr: models.ErrBadPageLimit,
+		},
+		{
+			name:   "Return unknown error",
+			page:   &models.PageReq{},
+			retErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:   "Success get",
+			page:   &models.PageReq{},
+			expRes: result,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expPage := &models.PageReq{Limit: models.DefaultPageLimit, Sort: models.SortCreatedAt}
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("GetActivity", mock.Anything, int64(1), expPage).Return(tc.expRes, "", tc.retErr)
 
-			ls := NewListService(lr)
+			ls := NewListService(nil, ar, testTransactor{}, newEventBusMock())
 
-			res, err := ls.GetUserLists(1)
+			res, _, err := ls.GetActivity(context.Background(), 1, tc.page)
 			require.Equal(t, tc.expErr, err)
 			require.Equal(t, tc.expRes, res)
 		})
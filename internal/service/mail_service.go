@@ -3,43 +3,81 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"net/smtp"
 	"strings"
 
 	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
 )
 
 type MailService struct {
 	Email    string
 	Password string
-	Domain   string
+	BaseURL  string
 }
 
-func (ms *MailService) SendConfirmationsEmail(user *models.User) error {
+func (ms *MailService) send(ctx context.Context, to, subject, body string) (err error) {
+	_, span := tracing.Start(ctx, "MailService.send")
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	from := ms.Email
 	pass := ms.Password
-	to := user.Email
 	server := "smtp.gmail.com"
 	port := "587"
 
 	msg := "From: " + from + "\n" +
 		"To: " + to + "\n" +
-		"Subject: Email conficmation\n\n" +
-		fmt.Sprintf(
-			"Confirm your email: http://%s/auth/confirm/%s",
-			ms.Domain, user.ActivatedLink,
-		)
+		"Subject: " + subject + "\n\n" +
+		body
 
 	return smtp.SendMail(strings.Join([]string{server, port}, ":"),
 		smtp.PlainAuth("", from, pass, server),
 		from, []string{to}, []byte(msg))
 }
 
-func NewMailService(Email, Password, Domain string) models.MailService {
+func (ms *MailService) SendConfirmationsEmail(ctx context.Context, user *models.User) error {
+	return ms.send(ctx,
+		user.Email,
+		"Email conficmation",
+		fmt.Sprintf(
+			"Confirm your email: %s/auth/confirm/%s",
+			ms.BaseURL, user.ActivatedLink,
+		),
+	)
+}
+
+func (ms *MailService) SendMentionEmail(ctx context.Context, user *models.User, listID, itemID int64, body string) error {
+	return ms.send(ctx,
+		user.Email,
+		"You were mentioned in a comment",
+		fmt.Sprintf(
+			"You were mentioned in a comment: %s/api/lists/%d/items/%d/comments\n\n%s",
+			ms.BaseURL, listID, itemID, body,
+		),
+	)
+}
+
+func (ms *MailService) SendAssignEmail(ctx context.Context, user *models.User, item *models.Item) error {
+	return ms.send(ctx,
+		user.Email,
+		"You were assigned to an item",
+		fmt.Sprintf(
+			"You were assigned to \"%s\": %s/api/lists/%d/items/%d",
+			item.Title, ms.BaseURL, item.ListID, item.ID,
+		),
+	)
+}
+
+// NewMailService returns service which sends emails with links to baseURL, e.g. https://example.com
+func NewMailService(Email, Password, BaseURL string) models.MailService {
 	return &MailService{
 		Email:    Email,
 		Password: Password,
-		Domain:   Domain,
+		BaseURL:  BaseURL,
 	}
 }
@@ -0,0 +1,17 @@
+package service
+
+import "github.com/VladimirStepanov/todo-app/internal/models"
+
+// normalizePage sets default limit and sort of page and validates limit
+func normalizePage(page *models.PageReq) error {
+	if page.Limit == 0 {
+		page.Limit = models.DefaultPageLimit
+	} else if page.Limit < 0 || page.Limit > models.MaxPageLimit {
+		return models.ErrBadPageLimit
+	}
+
+	if page.Sort == "" {
+		page.Sort = models.SortCreatedAt
+	}
+	return nil
+}
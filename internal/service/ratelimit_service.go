@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/google/uuid"
+)
+
+type RateLimitService struct {
+	repo   models.RateLimitRepository
+	limits map[string]models.RateLimit
+}
+
+// NewRateLimitService returns service of sliding window rate limits,
+// groups without limit or with zero requests are not limited
+func NewRateLimitService(repo models.RateLimitRepository, limits map[string]models.RateLimit) models.RateLimitService {
+	return &RateLimitService{
+		repo:   repo,
+		limits: limits,
+	}
+}
+
+func (rs *RateLimitService) Allow(ctx context.Context, group, subject string) (*models.RateLimitResult, error) {
+	limit, ok := rs.limits[group]
+	if !ok || limit.Requests <= 0 || limit.Window <= 0 {
+		return nil, nil
+	}
+
+	return rs.repo.Allow(ctx,
+		fmt.Sprintf("ratelimit:%s:%s", group, subject),
+		limit,
+		time.Now(),
+		uuid.NewString(),
+	)
+}
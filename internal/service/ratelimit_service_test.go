@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitAllow(t *testing.T) {
+	limits := map[string]models.RateLimit{
+		models.RateLimitAPI:  {Requests: 10, Window: time.Minute},
+		models.RateLimitAuth: {Requests: 0, Window: time.Minute},
+	}
+	res := &models.RateLimitResult{Allowed: true, Limit: 10, Remaining: 9}
+
+	tests := []struct {
+		name   string
+		group  string
+		retErr error
+		expRes *models.RateLimitResult
+		expErr error
+	}{
+		{
+			name:  "Group without limit",
+			group: "unknown",
+		},
+		{
+			name:  "Disabled limit",
+			group: models.RateLimitAuth,
+		},
+		{
+			name:   "Allow return error",
+			group:  models.RateLimitAPI,
+			retErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:   "Success allow",
+			group:  models.RateLimitAPI,
+			expRes: res,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mocks.RateLimitRepository)
+			repo.On(
+				"Allow", mock.Anything, "ratelimit:api:user:1", limits[models.RateLimitAPI], mock.Anything, mock.Anything,
+			).Return(tc.expRes, tc.retErr)
+
+			rs := NewRateLimitService(repo, limits)
+			ret, err := rs.Allow(context.Background(), tc.group, "user:1")
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, ret)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+// max number of search results
+const searchLimit = 50
+
+// supported languages and their postgres text search configurations
+var searchLanguages = map[string]string{
+	"en":      "english",
+	"english": "english",
+	"ru":      "russian",
+	"russian": "russian",
+}
+
+type SearchService struct {
+	repo        models.SearchRepository
+	DefaultLang string
+}
+
+func NewSearchService(repo models.SearchRepository, defaultLang string) models.SearchService {
+	return &SearchService{
+		repo:        repo,
+		DefaultLang: defaultLang,
+	}
+}
+
+// Search finds lists and items accessible by user, empty lang means default language
+func (ss *SearchService) Search(ctx context.Context, userID int64, query, lang string) ([]*models.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, models.ErrEmptySearchQuery
+	}
+
+	if lang == "" {
+		lang = ss.DefaultLang
+	}
+
+	config, ok := searchLanguages[strings.ToLower(lang)]
+	if !ok {
+		return nil, models.ErrBadSearchLanguage
+	}
+
+	return ss.repo.Search(ctx, userID, query, config, searchLimit)
+}
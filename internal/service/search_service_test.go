@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	expRes := []*models.SearchResult{
+		{
+			Type:    models.SearchTypeList,
+			ListID:  1,
+			Title:   "Покупки",
+			Snippet: "<b>Покупки</b>",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		lang      string
+		expConfig string
+		retErr    error
+		expErr    error
+		expRes    []*models.SearchResult
+	}{
+		{
+			name:   "Empty query",
+			query:  "   ",
+			expErr: models.ErrEmptySearchQuery,
+		},
+		{
+			name:   "Unsupported language",
+			query:  "shop",
+			lang:   "de",
+			expErr: models.ErrBadSearchLanguage,
+		},
+		{
+			name:      "Repository return error",
+			query:     "shop",
+			expConfig: "english",
+			retErr:    ErrSome,
+			expErr:    ErrSome,
+		},
+		{
+			name:      "Default language",
+			query:     " shop ",
+			expConfig: "english",
+			expRes:    expRes,
+		},
+		{
+			name:      "Russian language",
+			query:     "shop",
+			lang:      "RU",
+			expConfig: "russian",
+			expRes:    expRes,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := new(mocks.SearchRepository)
+			sr.On("Search", mock.Anything, int64(1), "shop", tc.expConfig, searchLimit).Return(tc.expRes, tc.retErr)
+
+			ss := NewSearchService(sr, "en")
+
+			res, err := ss.Search(context.Background(), 1, tc.query, tc.lang)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+			if tc.expConfig == "" {
+				sr.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
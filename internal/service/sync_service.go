@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+// rejections are errors of mutation which are reported to client instead of failing the batch
+var rejections = []error{
+	models.ErrNoList,
+	models.ErrNoItem,
+	models.ErrNoListAccess,
+	models.ErrListArchived,
+	models.ErrUpdateEmptyArgs,
+	models.ErrTitleTooShort,
+	models.ErrBadPriority,
+	models.ErrNotListMember,
+	models.ErrBadSyncOp,
+	models.ErrNoSyncTitle,
+}
+
+// isRejection returns true if err is one of rejections
+func isRejection(err error) bool {
+	for _, rejection := range rejections {
+		if errors.Is(err, rejection) {
+			return true
+		}
+	}
+	return false
+}
+
+type SyncService struct {
+	repo        models.SyncRepository
+	tx          models.Transactor
+	listService models.ListService
+	itemService models.ItemService
+}
+
+// NewSyncService returns service of offline clients sync. Mutations are applied
+// with list and item services, so they are validated and logged as regular requests
+func NewSyncService(
+	repo models.SyncRepository,
+	tx models.Transactor,
+	listService models.ListService,
+	itemService models.ItemService) models.SyncService {
+
+	return &SyncService{
+		repo:        repo,
+		tx:          tx,
+		listService: listService,
+		itemService: itemService,
+	}
+}
+
+// GetChanges returns changes after since token, empty token returns all lists and items of user
+func (ss *SyncService) GetChanges(ctx context.Context, userID int64, since string) (*models.SyncChanges, error) {
+	var seq int64
+	if since != "" {
+		var err error
+		if seq, err = strconv.ParseInt(since, 10, 64); err != nil || seq < 0 {
+			return nil, models.ErrBadSyncToken
+		}
+	}
+
+	changes, token, err := ss.repo.GetChanges(ctx, userID, seq)
+	if err != nil {
+		return nil, err
+	}
+
+	changes.Token = strconv.FormatInt(token, 10)
+	return changes, nil
+}
+
+// Apply applies mutations in order, later mutations may refer to lists and items
+// created by earlier ones with their client ids
+func (ss *SyncService) Apply(ctx context.Context, userID int64, mutations []*models.SyncMutation) ([]*models.SyncResult, error) {
+	results := []*models.SyncResult{}
+
+	for _, m := range mutations {
+		res, err := ss.apply(ctx, userID, m)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+func (ss *SyncService) apply(ctx context.Context, userID int64, m *models.SyncMutation) (*models.SyncResult, error) {
+	applied, err := ss.repo.GetMutation(ctx, userID, m.ClientID)
+	if err == nil {
+		return applied, nil
+	} else if !errors.Is(err, models.ErrNoSyncMutation) {
+		return nil, err
+	}
+
+	res := &models.SyncResult{
+		ClientID: m.ClientID,
+		Status:   models.SyncApplied,
+		ListID:   m.ListID,
+		ItemID:   m.ItemID,
+	}
+
+	// mutation is saved with its change, so retry applies change which was rolled back
+	err = ss.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := ss.resolveClientIDs(ctx, userID, m, res); err != nil {
+			return err
+		}
+
+		if err := ss.mutate(ctx, userID, m, res); err != nil {
+			return err
+		}
+
+		return ss.repo.SaveMutation(ctx, userID, res)
+	})
+
+	switch {
+	case err == nil:
+	case errors.Is(err, models.ErrSyncMutationApplied):
+		// concurrent retry has applied the mutation, change of this one is rolled back
+		return ss.repo.GetMutation(ctx, userID, m.ClientID)
+	case errors.Is(err, models.ErrVersionMismatch):
+		return ss.conflict(ctx, userID, m, res)
+	case isRejection(err):
+		res.Status = models.SyncRejected
+		res.Error = err.Error()
+	default:
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// resolveClientIDs sets server ids of list and item created by previous mutations
+func (ss *SyncService) resolveClientIDs(ctx context.Context, userID int64, m *models.SyncMutation, res *models.SyncResult) error {
+	if m.ListClientID != "" {
+		created, err := ss.repo.GetMutation(ctx, userID, m.ListClientID)
+		if errors.Is(err, models.ErrNoSyncMutation) {
+			return models.ErrNoList
+		} else if err != nil {
+			return err
+		}
+		res.ListID = created.ListID
+	}
+
+	if m.ItemClientID != "" {
+		created, err := ss.repo.GetMutation(ctx, userID, m.ItemClientID)
+		if errors.Is(err, models.ErrNoSyncMutation) || (err == nil && created.ItemID == 0) {
+			return models.ErrNoItem
+		} else if err != nil {
+			return err
+		}
+		res.ListID, res.ItemID = created.ListID, created.ItemID
+	}
+
+	return nil
+}
+
+// checkItemAccess returns error if user can't change items of list
+func (ss *SyncService) checkItemAccess(ctx context.Context, listID, userID int64) error {
+	if err := ss.listService.IsListAdmin(ctx, listID, userID); err != nil && !errors.Is(err, models.ErrNoListAccess) {
+		return err
+	}
+
+	archived, err := ss.listService.IsListArchived(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	if archived {
+		return models.ErrListArchived
+	}
+	return nil
+}
+
+func (ss *SyncService) mutate(ctx context.Context, userID int64, m *models.SyncMutation, res *models.SyncResult) error {
+	switch m.Op {
+	case models.ActionListCreate, models.ActionListUpdate, models.ActionListDelete:
+		return ss.mutateList(ctx, userID, m, res)
+	case models.ActionItemCreate, models.ActionItemUpdate, models.ActionItemDone, models.ActionItemDelete:
+		if err := ss.checkItemAccess(ctx, res.ListID, userID); err != nil {
+			return err
+		}
+		return ss.mutateItem(ctx, userID, m, res)
+	}
+
+	return models.ErrBadSyncOp
+}
+
+func (ss *SyncService) mutateList(ctx context.Context, userID int64, m *models.SyncMutation, res *models.SyncResult) error {
+	if m.Op == models.ActionListCreate {
+		if m.Title == nil || *m.Title == "" || m.Description == nil {
+			return models.ErrNoSyncTitle
+		}
+
+		var err error
+		res.ListID, err = ss.listService.Create(ctx, *m.Title, *m.Description, userID)
+		return err
+	}
+
+	if err := ss.listService.IsListAdmin(ctx, res.ListID, userID); err != nil {
+		return err
+	}
+
+	if m.Op == models.ActionListDelete {
+		return ss.listService.Delete(ctx, res.ListID, userID, m.Version)
+	}
+
+	return ss.listService.Update(ctx, res.ListID, userID, &models.UpdateListReq{
+		Title:       m.Title,
+		Description: m.Description,
+		Version:     m.Version,
+	})
+}
+
+func (ss *SyncService) mutateItem(ctx context.Context, userID int64, m *models.SyncMutation, res *models.SyncResult) error {
+	switch m.Op {
+	case models.ActionItemCreate:
+		if m.Title == nil || *m.Title == "" || m.Description == nil {
+			return models.ErrNoSyncTitle
+		}
+
+		item := &models.CreateItemReq{
+			Title:       *m.Title,
+			Description: *m.Description,
+			AssigneeID:  m.AssigneeID,
+			DueAt:       m.DueAt,
+		}
+		if m.Priority != nil {
+			item.Priority = *m.Priority
+		}
+
+		var err error
+		res.ItemID, err = ss.itemService.Create(ctx, res.ListID, userID, item)
+		return err
+
+	case models.ActionItemUpdate:
+		return ss.itemService.Update(ctx, res.ListID, res.ItemID, userID, &models.UpdateItemReq{
+			Title:       m.Title,
+			Description: m.Description,
+			AssigneeID:  m.AssigneeID,
+			DueAt:       m.DueAt,
+			Priority:    m.Priority,
+			Version:     m.Version,
+		})
+
+	case models.ActionItemDone:
+		return ss.itemService.Done(ctx, res.ListID, res.ItemID, userID, m.Version)
+	}
+
+	return ss.itemService.Delete(ctx, res.ListID, res.ItemID, userID, m.Version)
+}
+
+// conflict returns current server state of changed list or item
+func (ss *SyncService) conflict(ctx context.Context, userID int64, m *models.SyncMutation, res *models.SyncResult) (*models.SyncResult, error) {
+	var err error
+
+	res.Status = models.SyncConflict
+	res.Error = models.ErrVersionMismatch.Error()
+
+	if m.Op == models.ActionListUpdate || m.Op == models.ActionListDelete {
+		res.List, err = ss.listService.GetListByID(ctx, res.ListID, userID)
+	} else {
+		res.Item, err = ss.itemService.GetItemByID(ctx, res.ListID, res.ItemID)
+	}
+
+	// list or item was deleted after version check
+	if isRejection(err) {
+		res.Status = models.SyncRejected
+		res.Error = err.Error()
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
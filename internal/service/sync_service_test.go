@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		since    string
+		expSince int64
+		retErr   error
+		expToken string
+		expErr   error
+	}{
+		{
+			name:   "Bad token",
+			since:  "abc",
+			expErr: models.ErrBadSyncToken,
+		},
+		{
+			name:   "Negative token",
+			since:  "-1",
+			expErr: models.ErrBadSyncToken,
+		},
+		{
+			name:   "GetChanges return error",
+			since:  "10",
+			retErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:     "Success full sync",
+			expToken: "42",
+		},
+		{
+			name:     "Success sync since token",
+			since:    "10",
+			expSince: 10,
+			expToken: "42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := new(mocks.SyncRepository)
+			sr.On("GetChanges", mock.Anything, int64(1), mock.Anything).Return(
+				&models.SyncChanges{}, int64(42), tc.retErr,
+			)
+
+			ss := NewSyncService(sr, nil, nil, nil)
+
+			res, err := ss.GetChanges(context.Background(), 1, tc.since)
+			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				require.Equal(t, tc.expToken, res.Token)
+				sr.AssertCalled(t, "GetChanges", mock.Anything, int64(1), tc.expSince)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	title := "Title of list"
+	description := "description"
+	version := int64(3)
+
+	tests := []struct {
+		name     string
+		mutation *models.SyncMutation
+		setMocks func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService)
+		expRes   *models.SyncResult
+		expErr   error
+	}{
+		{
+			name:     "Already applied",
+			mutation: &models.SyncMutation{ClientID: "c1", Op: models.ActionListCreate},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(
+					&models.SyncResult{ClientID: "c1", Status: models.SyncApplied, ListID: 5}, nil,
+				)
+			},
+			expRes: &models.SyncResult{ClientID: "c1", Status: models.SyncApplied, ListID: 5},
+		},
+		{
+			name:     "GetMutation return error",
+			mutation: &models.SyncMutation{ClientID: "c1", Op: models.ActionListCreate},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, ErrSome)
+			},
+			expErr: ErrSome,
+		},
+		{
+			name:     "Unknown op",
+			mutation: &models.SyncMutation{ClientID: "c1", Op: "list.explode"},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+			},
+			expRes: &models.SyncResult{
+				ClientID: "c1", Status: models.SyncRejected, Error: models.ErrBadSyncOp.Error(),
+			},
+		},
+		{
+			name:     "Create list without title",
+			mutation: &models.SyncMutation{ClientID: "c1", Op: models.ActionListCreate},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+			},
+			expRes: &models.SyncResult{
+				ClientID: "c1", Status: models.SyncRejected, Error: models.ErrNoSyncTitle.Error(),
+			},
+		},
+		{
+			name: "Success create list",
+			mutation: &models.SyncMutation{
+				ClientID: "c1", Op: models.ActionListCreate, Title: &title, Description: &description,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				// change and mutation are saved in one transaction
+				inTx := mock.MatchedBy(func(ctx context.Context) bool {
+					return ctx.Value(afterCommitKey{}) != nil
+				})
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+				ls.On("Create", inTx, title, description, int64(1)).Return(int64(5), nil)
+				sr.On("SaveMutation", inTx, int64(1), mock.Anything).Return(nil)
+			},
+			expRes: &models.SyncResult{ClientID: "c1", Status: models.SyncApplied, ListID: 5},
+		},
+		{
+			name: "Mutation applied by concurrent retry",
+			mutation: &models.SyncMutation{
+				ClientID: "c1", Op: models.ActionListCreate, Title: &title, Description: &description,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation).Once()
+				ls.On("Create", mock.Anything, title, description, int64(1)).Return(int64(6), nil)
+				sr.On("SaveMutation", mock.Anything, int64(1), mock.Anything).Return(models.ErrSyncMutationApplied)
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(
+					&models.SyncResult{ClientID: "c1", Status: models.SyncApplied, ListID: 5}, nil,
+				)
+			},
+			expRes: &models.SyncResult{ClientID: "c1", Status: models.SyncApplied, ListID: 5},
+		},
+		{
+			name: "SaveMutation return error",
+			mutation: &models.SyncMutation{
+				ClientID: "c1", Op: models.ActionListCreate, Title: &title, Description: &description,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+				ls.On("Create", mock.Anything, title, description, int64(1)).Return(int64(5), nil)
+				sr.On("SaveMutation", mock.Anything, int64(1), mock.Anything).Return(ErrSome)
+			},
+			expErr: ErrSome,
+		},
+		{
+			name:     "Update list by not admin",
+			mutation: &models.SyncMutation{ClientID: "c1", Op: models.ActionListUpdate, ListID: 5, Title: &title},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+				ls.On("IsListAdmin", mock.Anything, int64(5), int64(1)).Return(models.ErrNoListAccess)
+			},
+			expRes: &models.SyncResult{
+				ClientID: "c1", Status: models.SyncRejected, ListID: 5, Error: models.ErrNoListAccess.Error(),
+			},
+		},
+		{
+			name: "Update list conflict",
+			mutation: &models.SyncMutation{
+				ClientID: "c1", Op: models.ActionListUpdate, ListID: 5, Title: &title, Version: &version,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+				ls.On("IsListAdmin", mock.Anything, int64(5), int64(1)).Return(nil)
+				ls.On("Update", mock.Anything, int64(5), int64(1), mock.MatchedBy(func(req *models.UpdateListReq) bool {
+					return *req.Version == version && *req.Title == title
+				})).Return(models.ErrVersionMismatch)
+				ls.On("GetListByID", mock.Anything, int64(5), int64(1)).Return(testList, nil)
+			},
+			expRes: &models.SyncResult{
+				ClientID: "c1", Status: models.SyncConflict, ListID: 5,
+				Error: models.ErrVersionMismatch.Error(), List: testList,
+			},
+		},
+		{
+			name: "Wrapped error of list is rejection",
+			mutation: &models.SyncMutation{
+				ClientID: "c1", Op: models.ActionListUpdate, ListID: 5, Title: &title,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+				ls.On("IsListAdmin", mock.Anything, int64(5), int64(1)).Return(nil)
+				ls.On("Update", mock.Anything, int64(5), int64(1), mock.Anything).Return(
+					fmt.Errorf("%w (rollback: %v)", models.ErrNoList, ErrSome),
+				)
+			},
+			expRes: &models.SyncResult{
+				ClientID: "c1", Status: models.SyncRejected, ListID: 5,
+				Error: fmt.Sprintf("%s (rollback: %s)", models.ErrNoList, ErrSome),
+			},
+		},
+		{
+			name: "Delete item of archived list",
+			mutation: &models.SyncMutation{
+				ClientID: "c1", Op: models.ActionItemDelete, ListID: 5, ItemID: 7,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+				ls.On("IsListAdmin", mock.Anything, int64(5), int64(1)).Return(models.ErrNoListAccess)
+				ls.On("IsListArchived", mock.Anything, int64(5)).Return(true, nil)
+			},
+			expRes: &models.SyncResult{
+				ClientID: "c1", Status: models.SyncRejected, ListID: 5, ItemID: 7,
+				Error: models.ErrListArchived.Error(),
+			},
+		},
+		{
+			name: "Create item in list created offline",
+			mutation: &models.SyncMutation{
+				ClientID: "c2", Op: models.ActionItemCreate, ListClientID: "c1",
+				Title: &title, Description: &description,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c2").Return(nil, models.ErrNoSyncMutation)
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(
+					&models.SyncResult{ClientID: "c1", Status: models.SyncApplied, ListID: 5}, nil,
+				)
+				ls.On("IsListAdmin", mock.Anything, int64(5), int64(1)).Return(nil)
+				ls.On("IsListArchived", mock.Anything, int64(5)).Return(false, nil)
+				is.On("Create", mock.Anything, int64(5), int64(1), &models.CreateItemReq{
+					Title: title, Description: description,
+				}).Return(int64(7), nil)
+				sr.On("SaveMutation", mock.Anything, int64(1), mock.Anything).Return(nil)
+			},
+			expRes: &models.SyncResult{ClientID: "c2", Status: models.SyncApplied, ListID: 5, ItemID: 7},
+		},
+		{
+			name: "Done item created offline",
+			mutation: &models.SyncMutation{
+				ClientID: "c3", Op: models.ActionItemDone, ItemClientID: "c2",
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c3").Return(nil, models.ErrNoSyncMutation)
+				sr.On("GetMutation", mock.Anything, int64(1), "c2").Return(
+					&models.SyncResult{ClientID: "c2", Status: models.SyncApplied, ListID: 5, ItemID: 7}, nil,
+				)
+				ls.On("IsListAdmin", mock.Anything, int64(5), int64(1)).Return(nil)
+				ls.On("IsListArchived", mock.Anything, int64(5)).Return(false, nil)
+				is.On("Done", mock.Anything, int64(5), int64(7), int64(1), (*int64)(nil)).Return(nil)
+				sr.On("SaveMutation", mock.Anything, int64(1), mock.Anything).Return(nil)
+			},
+			expRes: &models.SyncResult{ClientID: "c3", Status: models.SyncApplied, ListID: 5, ItemID: 7},
+		},
+		{
+			name: "Unknown list client id",
+			mutation: &models.SyncMutation{
+				ClientID: "c2", Op: models.ActionItemCreate, ListClientID: "c1",
+				Title: &title, Description: &description,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c2").Return(nil, models.ErrNoSyncMutation)
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+			},
+			expRes: &models.SyncResult{
+				ClientID: "c2", Status: models.SyncRejected, Error: models.ErrNoList.Error(),
+			},
+		},
+		{
+			name: "Update item return unknown error",
+			mutation: &models.SyncMutation{
+				ClientID: "c1", Op: models.ActionItemUpdate, ListID: 5, ItemID: 7, Title: &title,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+				ls.On("IsListAdmin", mock.Anything, int64(5), int64(1)).Return(nil)
+				ls.On("IsListArchived", mock.Anything, int64(5)).Return(false, nil)
+				is.On("Update", mock.Anything, int64(5), int64(7), int64(1), mock.Anything).Return(ErrSome)
+			},
+			expErr: ErrSome,
+		},
+		{
+			name: "Update of deleted item conflicts",
+			mutation: &models.SyncMutation{
+				ClientID: "c1", Op: models.ActionItemUpdate, ListID: 5, ItemID: 7, Title: &title, Version: &version,
+			},
+			setMocks: func(sr *mocks.SyncRepository, ls *mocks.ListService, is *mocks.ItemService) {
+				sr.On("GetMutation", mock.Anything, int64(1), "c1").Return(nil, models.ErrNoSyncMutation)
+				ls.On("IsListAdmin", mock.Anything, int64(5), int64(1)).Return(nil)
+				ls.On("IsListArchived", mock.Anything, int64(5)).Return(false, nil)
+				is.On("Update", mock.Anything, int64(5), int64(7), int64(1), mock.Anything).Return(models.ErrVersionMismatch)
+				is.On("GetItemByID", mock.Anything, int64(5), int64(7)).Return(nil, models.ErrNoItem)
+			},
+			expRes: &models.SyncResult{
+				ClientID: "c1", Status: models.SyncRejected, ListID: 5, ItemID: 7,
+				Error: models.ErrNoItem.Error(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := new(mocks.SyncRepository)
+			ls := new(mocks.ListService)
+			is := new(mocks.ItemService)
+			tc.setMocks(sr, ls, is)
+
+			ss := NewSyncService(sr, testTransactor{}, ls, is)
+
+			res, err := ss.Apply(context.Background(), 1, []*models.SyncMutation{tc.mutation})
+			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				require.Equal(t, []*models.SyncResult{tc.expRes}, res)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 type TokenService struct {
 	AccessKey   string
 	RefreshKey  string
+	AccessTTL   time.Duration
+	RefreshTTL  time.Duration
 	MaxLoggedIn int
 	repo        models.TokenRepository
 }
@@ -28,10 +31,16 @@ func GenerateToken(uuid string, uID int64, iat, exp int64, key string) (string,
 	return rt.SignedString([]byte(key))
 }
 
-func NewTokenService(accessKey, refreshKey string, maxLoggedIn int, repo models.TokenRepository) models.TokenService {
+func NewTokenService(
+	accessKey, refreshKey string,
+	accessTTL, refreshTTL time.Duration,
+	maxLoggedIn int, repo models.TokenRepository) models.TokenService {
+
 	return &TokenService{
 		AccessKey:   accessKey,
 		RefreshKey:  refreshKey,
+		AccessTTL:   accessTTL,
+		RefreshTTL:  refreshTTL,
 		MaxLoggedIn: maxLoggedIn,
 		repo:        repo,
 	}
@@ -43,7 +52,7 @@ func (ts *TokenService) generatePair(userID int64) (*models.TokenDetails, error)
 	res := &models.TokenDetails{}
 
 	res.UUID = uuid.NewString()
-	res.AccessET = time.Now().Add(time.Minute * 15).Unix()
+	res.AccessET = time.Now().Add(ts.AccessTTL).Unix()
 	res.AccessIAT = time.Now().Unix()
 
 	res.AccessToken, err = GenerateToken(res.UUID, userID, res.AccessIAT, res.AccessET, ts.AccessKey)
@@ -51,7 +60,7 @@ func (ts *TokenService) generatePair(userID int64) (*models.TokenDetails, error)
 		return nil, err
 	}
 
-	res.RefreshET = time.Now().Add(time.Hour * 24 * 7).Unix()
+	res.RefreshET = time.Now().Add(ts.RefreshTTL).Unix()
 	res.RefreshIAT = time.Now().Unix()
 
 	res.RefreshToken, err = GenerateToken(res.UUID, userID, res.RefreshIAT, res.RefreshET, ts.RefreshKey)
@@ -62,10 +71,10 @@ func (ts *TokenService) generatePair(userID int64) (*models.TokenDetails, error)
 	return res, nil
 }
 
-func (ts *TokenService) saveTokenPair(userID int64, td *models.TokenDetails) error {
+func (ts *TokenService) saveTokenPair(ctx context.Context, userID int64, td *models.TokenDetails) error {
 	pattern := fmt.Sprintf("r:%d:*", userID)
 
-	count, err := ts.repo.Count(pattern)
+	count, err := ts.repo.Count(ctx, pattern)
 
 	if err != nil {
 		return err
@@ -81,18 +90,18 @@ func (ts *TokenService) saveTokenPair(userID int64, td *models.TokenDetails) err
 	accessDur := time.Duration((td.AccessET - td.AccessIAT)) * time.Second
 	refreshDur := time.Duration(td.RefreshET-td.RefreshIAT) * time.Second
 
-	return ts.repo.SetTokens(accessRedisKey, accessDur, refreshRedisKey, refreshDur)
+	return ts.repo.SetTokens(ctx, accessRedisKey, accessDur, refreshRedisKey, refreshDur)
 
 }
 
-func (ts *TokenService) NewTokenPair(userID int64) (*models.TokenDetails, error) {
+func (ts *TokenService) NewTokenPair(ctx context.Context, userID int64) (*models.TokenDetails, error) {
 	td, err := ts.generatePair(userID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	err = ts.saveTokenPair(userID, td)
+	err = ts.saveTokenPair(ctx, userID, td)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +132,7 @@ func (ts *TokenService) getClaims(tokenString, key string) (jwt.MapClaims, error
 	}
 }
 
-func (ts *TokenService) verify(token, key, prefix string) (jwt.MapClaims, error) {
+func (ts *TokenService) verify(ctx context.Context, token, key, prefix string) (jwt.MapClaims, error) {
 	claims, err := ts.getClaims(token, key)
 
 	if err != nil {
@@ -134,7 +143,7 @@ func (ts *TokenService) verify(token, key, prefix string) (jwt.MapClaims, error)
 		"%s:%d:%s", prefix, int64(claims["user_id"].(float64)), claims["uuid"].(string),
 	)
 
-	val, err := ts.repo.Get(redisKey)
+	val, err := ts.repo.Get(ctx, redisKey)
 
 	if err != nil {
 		return nil, err
@@ -145,8 +154,8 @@ func (ts *TokenService) verify(token, key, prefix string) (jwt.MapClaims, error)
 	return claims, nil
 }
 
-func (ts *TokenService) Refresh(refreshToken string) (*models.TokenDetails, error) {
-	claims, err := ts.verify(refreshToken, ts.RefreshKey, "r")
+func (ts *TokenService) Refresh(ctx context.Context, refreshToken string) (*models.TokenDetails, error) {
+	claims, err := ts.verify(ctx, refreshToken, ts.RefreshKey, "r")
 
 	if err != nil {
 		return nil, err
@@ -160,15 +169,15 @@ func (ts *TokenService) Refresh(refreshToken string) (*models.TokenDetails, erro
 		"r:%d:%s", int64(claims["user_id"].(float64)), claims["uuid"].(string),
 	)
 
-	err = ts.repo.Delete(refreshRedisKey, accessRedisKey)
+	err = ts.repo.Delete(ctx, refreshRedisKey, accessRedisKey)
 	if err != nil {
 		return nil, err
 	}
-	return ts.NewTokenPair(int64(claims["user_id"].(float64)))
+	return ts.NewTokenPair(ctx, int64(claims["user_id"].(float64)))
 }
 
-func (ts *TokenService) Verify(token string) (int64, string, error) {
-	claims, err := ts.verify(token, ts.AccessKey, "a")
+func (ts *TokenService) Verify(ctx context.Context, token string) (int64, string, error) {
+	claims, err := ts.verify(ctx, token, ts.AccessKey, "a")
 
 	if err != nil {
 		return 0, "", err
@@ -177,9 +186,28 @@ func (ts *TokenService) Verify(token string) (int64, string, error) {
 	return int64(claims["user_id"].(float64)), claims["uuid"].(string), nil
 }
 
-func (ts *TokenService) Logout(userID int64, userUUID string) error {
+func (ts *TokenService) ExpiresAt(token string) (time.Time, error) {
+	claims, err := ts.getClaims(token, ts.AccessKey)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}, models.ErrBadToken
+	}
+
+	return time.Unix(int64(exp), 0), nil
+}
+
+func (ts *TokenService) Logout(ctx context.Context, userID int64, userUUID string) error {
 	accessRedisKey := fmt.Sprintf("a:%d:%s", userID, userUUID)
 	refreshRedisKey := fmt.Sprintf("r:%d:%s", userID, userUUID)
 
-	return ts.repo.Delete(refreshRedisKey, accessRedisKey)
+	return ts.repo.Delete(ctx, refreshRedisKey, accessRedisKey)
+}
+
+func (ts *TokenService) ActiveSessions(ctx context.Context) (int, error) {
+	return ts.repo.Count(ctx, "r:*")
 }
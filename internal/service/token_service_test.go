@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -13,6 +14,8 @@ import (
 var (
 	accessKey   = "accessKey"
 	refreshKey  = "refreshKey"
+	accessTTL   = 15 * time.Minute
+	refreshTTL  = 7 * 24 * time.Hour
 	maxLoggenIn = 6
 	testUUID    = "60a1cc8e-f741-45bc-a794-1ac655790c3b"
 	userID      = int64(1)
@@ -59,12 +62,12 @@ func TestNewTokenPair(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			repoMock := new(mocks.TokenRepository)
-			repoMock.On("Count", mock.Anything).Return(tc.countRetVal, tc.countRetErr)
-			repoMock.On("SetTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.setTokRet)
+			repoMock.On("Count", mock.Anything, mock.Anything).Return(tc.countRetVal, tc.countRetErr)
+			repoMock.On("SetTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.setTokRet)
 
-			ts := NewTokenService(accessKey, refreshKey, maxLoggenIn, repoMock)
+			ts := NewTokenService(accessKey, refreshKey, accessTTL, refreshTTL, maxLoggenIn, repoMock)
 
-			td, err := ts.NewTokenPair(userID)
+			td, err := ts.NewTokenPair(context.Background(), userID)
 
 			require.Equal(t, tc.expErr, err)
 
@@ -72,6 +75,8 @@ func TestNewTokenPair(t *testing.T) {
 				require.NotEmpty(t, td.AccessToken)
 				require.NotEmpty(t, td.RefreshToken)
 				require.NotEmpty(t, td.UUID)
+				require.Equal(t, int64(accessTTL.Seconds()), td.AccessET-td.AccessIAT)
+				require.Equal(t, int64(refreshTTL.Seconds()), td.RefreshET-td.RefreshIAT)
 			}
 
 		})
@@ -195,16 +200,16 @@ func TestRefresh(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			repoMock := new(mocks.TokenRepository)
-			repoMock.On("Get", mock.Anything).Return(tc.getRetVal, tc.getRetErr)
-			repoMock.On("Delete", mock.Anything, mock.Anything).Return(tc.delRetErr)
-			repoMock.On("Count", mock.Anything).
+			repoMock.On("Get", mock.Anything, mock.Anything).Return(tc.getRetVal, tc.getRetErr)
+			repoMock.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(tc.delRetErr)
+			repoMock.On("Count", mock.Anything, mock.Anything).
 				Return(tc.countRetVal, tc.countRetErr)
-			repoMock.On("SetTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			repoMock.On("SetTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(tc.setTokensRetErr)
 
-			ts := NewTokenService(accessKey, refreshKey, maxLoggenIn, repoMock)
+			ts := NewTokenService(accessKey, refreshKey, accessTTL, refreshTTL, maxLoggenIn, repoMock)
 
-			td, err := ts.Refresh(tc.token)
+			td, err := ts.Refresh(context.Background(), tc.token)
 
 			require.Equal(t, tc.expErr, err)
 
@@ -275,10 +280,10 @@ func TestVerify(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			repoMock := new(mocks.TokenRepository)
-			repoMock.On("Get", mock.Anything).Return(tc.getRetVal, tc.getRetErr)
-			ts := NewTokenService(accessKey, refreshKey, maxLoggenIn, repoMock)
+			repoMock.On("Get", mock.Anything, mock.Anything).Return(tc.getRetVal, tc.getRetErr)
+			ts := NewTokenService(accessKey, refreshKey, accessTTL, refreshTTL, maxLoggenIn, repoMock)
 
-			id, uuid, err := ts.Verify(tc.token)
+			id, uuid, err := ts.Verify(context.Background(), tc.token)
 
 			require.Equal(t, tc.expErr, err)
 
@@ -291,6 +296,56 @@ func TestVerify(t *testing.T) {
 	}
 }
 
+func TestExpiresAt(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	expiredToken, err := GenerateToken(testUUID, userID, 100, 103, accessKey)
+	require.NoError(t, err)
+	refreshToken, err := GenerateToken(testUUID, userID, time.Now().Unix(), exp, refreshKey)
+	require.NoError(t, err)
+	actualToken, err := GenerateToken(testUUID, userID, time.Now().Unix(), exp, accessKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		token  string
+		expErr error
+	}{
+		{
+			name:   "Bad token",
+			token:  "bad.bad.bad",
+			expErr: models.ErrBadToken,
+		},
+		{
+			name:   "Expired token",
+			token:  expiredToken,
+			expErr: models.ErrTokenExpired,
+		},
+		{
+			name:   "Refresh token",
+			token:  refreshToken,
+			expErr: models.ErrBadToken,
+		},
+		{
+			name:   "Success",
+			token:  actualToken,
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := NewTokenService(accessKey, refreshKey, accessTTL, refreshTTL, maxLoggenIn, nil)
+
+			res, err := ts.ExpiresAt(tc.token)
+
+			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				require.Equal(t, time.Unix(exp, 0), res)
+			}
+		})
+	}
+}
+
 func TestLogout(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -312,11 +367,42 @@ func TestLogout(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			repoMock := new(mocks.TokenRepository)
-			repoMock.On("Delete", mock.Anything, mock.Anything).Return(tc.delRetErr)
+			repoMock.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(tc.delRetErr)
+
+			ts := NewTokenService(accessKey, refreshKey, accessTTL, refreshTTL, maxLoggenIn, repoMock)
+			err := ts.Logout(context.Background(), 1, "hello")
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestActiveSessions(t *testing.T) {
+	tests := []struct {
+		name   string
+		retCnt int
+		retErr error
+		expErr error
+	}{
+		{
+			name:   "Count return error",
+			retErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name:   "Success count",
+			retCnt: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repoMock := new(mocks.TokenRepository)
+			repoMock.On("Count", mock.Anything, "r:*").Return(tc.retCnt, tc.retErr)
 
-			ts := NewTokenService(accessKey, refreshKey, maxLoggenIn, repoMock)
-			err := ts.Logout(1, "hello")
+			ts := NewTokenService(accessKey, refreshKey, accessTTL, refreshTTL, maxLoggenIn, repoMock)
+			count, err := ts.ActiveSessions(context.Background())
 			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.retCnt, count)
 		})
 	}
 }
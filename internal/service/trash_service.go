@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+type TrashService struct {
+	listRepo     models.ListRepository
+	itemRepo     models.ItemRepository
+	activityRepo models.ActivityRepository
+	tx           models.Transactor
+	events       models.EventBus
+	retention    time.Duration
+}
+
+// NewTrashService returns service of deleted lists and items,
+// they are permanently removed by Purge after retention period
+func NewTrashService(
+	listRepo models.ListRepository,
+	itemRepo models.ItemRepository,
+	activityRepo models.ActivityRepository,
+	tx models.Transactor,
+	events models.EventBus,
+	retention time.Duration) models.TrashService {
+
+	return &TrashService{
+		listRepo:     listRepo,
+		itemRepo:     itemRepo,
+		activityRepo: activityRepo,
+		tx:           tx,
+		events:       events,
+		retention:    retention,
+	}
+}
+
+func (ts *TrashService) GetTrash(ctx context.Context, userID int64) (*models.Trash, error) {
+	lists, err := ts.listRepo.GetDeletedLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := ts.itemRepo.GetDeletedItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Trash{Lists: lists, Items: items}, nil
+}
+
+func (ts *TrashService) IsDeletedListAdmin(ctx context.Context, listID, userID int64) error {
+	return ts.listRepo.IsDeletedListAdmin(ctx, listID, userID)
+}
+
+func (ts *TrashService) RestoreList(ctx context.Context, listID, userID int64) error {
+	return ts.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := ts.listRepo.Restore(ctx, listID, userID); err != nil {
+			return err
+		}
+
+		return saveActivity(ctx, ts.tx, ts.activityRepo, ts.events, &models.Activity{
+			ListID: listID,
+			UserID: &userID,
+			Action: models.ActionListRestore,
+		})
+	})
+}
+
+func (ts *TrashService) RestoreItem(ctx context.Context, listID, itemID, userID int64) error {
+	return ts.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := ts.itemRepo.Restore(ctx, listID, itemID, userID); err != nil {
+			return err
+		}
+
+		return saveActivity(ctx, ts.tx, ts.activityRepo, ts.events, &models.Activity{
+			ListID: listID,
+			ItemID: &itemID,
+			UserID: &userID,
+			Action: models.ActionItemRestore,
+		})
+	})
+}
+
+// Purge permanently removes lists and items which are in trash longer than retention period
+func (ts *TrashService) Purge(ctx context.Context) error {
+	before := time.Now().Add(-ts.retention)
+
+	if _, err := ts.itemRepo.Purge(ctx, before); err != nil {
+		return err
+	}
+
+	_, err := ts.listRepo.Purge(ctx, before)
+	return err
+}
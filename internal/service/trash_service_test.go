@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrash(t *testing.T) {
+	tests := []struct {
+		name     string
+		listsErr error
+		itemsErr error
+		expRes   *models.Trash
+		expErr   error
+	}{
+		{
+			name:     "GetDeletedLists return error",
+			listsErr: ErrSome,
+			expErr:   ErrSome,
+		},
+		{
+			name:     "GetDeletedItems return error",
+			itemsErr: ErrSome,
+			expErr:   ErrSome,
+		},
+		{
+			name: "Success get trash",
+			expRes: &models.Trash{
+				Lists: []*models.List{testList},
+				Items: []*models.Item{testItem},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lr := new(mocks.ListRepository)
+			lr.On("GetDeletedLists", mock.Anything, int64(1)).Return([]*models.List{testList}, tc.listsErr)
+
+			ir := new(mocks.ItemRepository)
+			ir.On("GetDeletedItems", mock.Anything, int64(1)).Return([]*models.Item{testItem}, tc.itemsErr)
+
+			ts := NewTrashService(lr, ir, nil, nil, nil, time.Hour)
+
+			res, err := ts.GetTrash(context.Background(), 1)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestRestoreList(t *testing.T) {
+	tests := []struct {
+		name   string
+		retErr error
+		expErr error
+	}{
+		{
+			name:   "Return ErrNoList error",
+			retErr: models.ErrNoList,
+			expErr: models.ErrNoList,
+		},
+		{
+			name: "Success restore",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lr := new(mocks.ListRepository)
+			lr.On("Restore", mock.Anything, int64(1), int64(2)).Return(tc.retErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			ts := NewTrashService(lr, nil, ar, testTransactor{}, newEventBusMock(), time.Hour)
+
+			err := ts.RestoreList(context.Background(), 1, 2)
+			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				activity := ar.Calls[0].Arguments.Get(1).(*models.Activity)
+				require.Equal(t, models.ActionListRestore, activity.Action)
+			} else {
+				ar.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRestoreItem(t *testing.T) {
+	tests := []struct {
+		name   string
+		retErr error
+		expErr error
+	}{
+		{
+			name:   "Return ErrNoItem error",
+			retErr: models.ErrNoItem,
+			expErr: models.ErrNoItem,
+		},
+		{
+			name: "Success restore",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ir := new(mocks.ItemRepository)
+			ir.On("Restore", mock.Anything, int64(1), int64(3), int64(2)).Return(tc.retErr)
+
+			ar := new(mocks.ActivityRepository)
+			ar.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			ts := NewTrashService(nil, ir, ar, testTransactor{}, newEventBusMock(), time.Hour)
+
+			err := ts.RestoreItem(context.Background(), 1, 3, 2)
+			require.Equal(t, tc.expErr, err)
+			if tc.expErr == nil {
+				activity := ar.Calls[0].Arguments.Get(1).(*models.Activity)
+				require.Equal(t, models.ActionItemRestore, activity.Action)
+				require.Equal(t, int64(3), *activity.ItemID)
+			} else {
+				ar.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	tests := []struct {
+		name     string
+		itemsErr error
+		listsErr error
+		expErr   error
+	}{
+		{
+			name:     "Items purge return error",
+			itemsErr: ErrSome,
+			expErr:   ErrSome,
+		},
+		{
+			name:     "Lists purge return error",
+			listsErr: ErrSome,
+			expErr:   ErrSome,
+		},
+		{
+			name: "Success purge",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lr := new(mocks.ListRepository)
+			lr.On("Purge", mock.Anything, mock.Anything).Return(int64(1), tc.listsErr)
+
+			ir := new(mocks.ItemRepository)
+			ir.On("Purge", mock.Anything, mock.Anything).Return(int64(1), tc.itemsErr)
+
+			ts := NewTrashService(lr, ir, nil, nil, nil, time.Hour)
+
+			err := ts.Purge(context.Background())
+			require.Equal(t, tc.expErr, err)
+
+			before := ir.Calls[0].Arguments.Get(1).(time.Time)
+			require.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Minute)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
+
 	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/pkg/tracing"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -14,8 +17,8 @@ func NewUserService(repo models.UserRepository) models.UserService {
 	return &UserService{repo: repo}
 }
 
-func (us *UserService) Create(Email, Password string) (*models.User, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(Password), bcrypt.DefaultCost)
+func (us *UserService) Create(ctx context.Context, Email, Password string) (*models.User, error) {
+	hashedPassword, err := hashPassword(ctx, Password)
 	if err != nil {
 		return nil, err
 	}
@@ -27,21 +30,21 @@ func (us *UserService) Create(Email, Password string) (*models.User, error) {
 		ActivatedLink: link,
 	}
 
-	return us.repo.Create(user)
+	return us.repo.Create(ctx, user)
 }
 
-func (us *UserService) ConfirmEmail(Link string) error {
-	return us.repo.ConfirmEmail(Link)
+func (us *UserService) ConfirmEmail(ctx context.Context, Link string) error {
+	return us.repo.ConfirmEmail(ctx, Link)
 }
 
-func (us *UserService) SignIn(Email, Password string) (*models.User, error) {
-	user, err := us.repo.FindUserByEmail(Email)
+func (us *UserService) SignIn(ctx context.Context, Email, Password string) (*models.User, error) {
+	user, err := us.repo.FindUserByEmail(ctx, Email)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(Password)) != nil {
+	if comparePassword(ctx, user.Password, Password) != nil {
 		return nil, models.ErrBadUser
 	}
 
@@ -50,3 +53,18 @@ func (us *UserService) SignIn(Email, Password string) (*models.User, error) {
 	}
 	return user, nil
 }
+
+// hashPassword and comparePassword are traced, bcrypt takes most of sign up and sign in time
+func hashPassword(ctx context.Context, password string) ([]byte, error) {
+	_, span := tracing.Start(ctx, "bcrypt.GenerateFromPassword")
+	defer span.End()
+
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+}
+
+func comparePassword(ctx context.Context, hash, password string) error {
+	_, span := tracing.Start(ctx, "bcrypt.CompareHashAndPassword")
+	defer span.End()
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
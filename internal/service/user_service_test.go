@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -37,10 +38,10 @@ func TestConfirmEmail(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			repoMock := new(mocks.UserRepository)
-			repoMock.On("ConfirmEmail", mock.Anything).Return(tc.retErr)
+			repoMock.On("ConfirmEmail", mock.Anything, mock.Anything).Return(tc.retErr)
 			us := NewUserService(repoMock)
 
-			err := us.ConfirmEmail("test")
+			err := us.ConfirmEmail(context.Background(), "test")
 
 			require.Equal(t, tc.expErr, err)
 			repoMock.AssertExpectations(t)
@@ -63,13 +64,13 @@ func TestCreate(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			repoMock := new(mocks.UserRepository)
-			mockCall := repoMock.On("Create", mock.Anything)
+			mockCall := repoMock.On("Create", mock.Anything, mock.Anything)
 			mockCall.Run(func(args mock.Arguments) {
-				mockCall.Return(args.Get(0), tc.err)
+				mockCall.Return(args.Get(1), tc.err)
 			})
 			us := NewUserService(repoMock)
 
-			user, err := us.Create(tc.email, "World")
+			user, err := us.Create(context.Background(), tc.email, "World")
 
 			require.Equal(t, tc.err, err)
 			if err != nil && tc.err == err {
@@ -162,11 +163,11 @@ func TestSignIn(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			repoMock := new(mocks.UserRepository)
-			repoMock.On("FindUserByEmail", mock.AnythingOfType("string")).
+			repoMock.On("FindUserByEmail", mock.Anything, mock.AnythingOfType("string")).
 				Return(tc.repoRetUser(), tc.repoRetErr)
 			us := NewUserService(repoMock)
 
-			u, err := us.SignIn(testEmail, tc.password)
+			u, err := us.SignIn(context.Background(), testEmail, tc.password)
 			require.Equal(t, tc.expErr, err)
 			require.Equal(t, tc.expUser(), u)
 			repoMock.AssertExpectations(t)
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var errForbiddenAddress = errors.New("webhook address is not allowed")
+
+// deniedNets are address ranges which webhooks can't be delivered to
+var deniedNets = parseCIDRs(
+	"0.0.0.0/8",      // "this" network
+	"10.0.0.0/8",     // private
+	"100.64.0.0/10",  // carrier-grade NAT
+	"127.0.0.0/8",    // loopback
+	"169.254.0.0/16", // link-local
+	"172.16.0.0/12",  // private
+	"192.168.0.0/16", // private
+	"224.0.0.0/4",    // multicast
+	"240.0.0.0/4",    // reserved and broadcast
+	"::/128",         // unspecified
+	"::1/128",        // loopback
+	"fc00::/7",       // unique local
+	"fe80::/10",      // link-local
+	"ff00::/8",       // multicast
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+// isPublicIP returns false for addresses of denied ranges,
+// IPv4-mapped IPv6 addresses are checked as IPv4
+func isPublicIP(ip net.IP) bool {
+	for _, ipNet := range deniedNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// dialControl refuses connections to non-public addresses. It is called after
+// DNS resolution, so hosts which resolve to internal addresses are refused too
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return errForbiddenAddress
+	}
+	return nil
+}
+
+// NewWebhookClient returns http client of webhook deliveries. It connects only to
+// public addresses and doesn't follow redirects, redirect is failed delivery
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: dialControl,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		// proxy from environment would be dialed instead of webhook address
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
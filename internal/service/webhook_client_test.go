@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip  string
+		exp bool
+	}{
+		{"93.184.216.34", true},
+		{"100.63.255.255", true},
+		{"100.128.0.1", true},
+		{"172.32.0.1", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"10.1.2.3", false},
+		{"100.64.0.1", false},
+		{"100.127.255.255", false},
+		{"127.0.0.1", false},
+		{"127.1.2.3", false},
+		{"169.254.169.254", false},
+		{"172.16.0.1", false},
+		{"172.31.255.255", false},
+		{"192.168.1.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::", false},
+		{"::1", false},
+		{"fc00::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"ff02::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:100.64.0.1", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ip, func(t *testing.T) {
+			require.Equal(t, tc.exp, isPublicIP(net.ParseIP(tc.ip)))
+		})
+	}
+}
+
+func TestWebhookClient(t *testing.T) {
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	client := NewWebhookClient(time.Second)
+
+	_, err := client.Post(receiver.URL, "application/json", nil)
+	require.True(t, errors.Is(err, errForbiddenAddress))
+	require.False(t, called)
+
+	require.Equal(t, http.ErrUseLastResponse, client.CheckRedirect(nil, nil))
+}
+
+func TestDeliverRedirectIsFailure(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer receiver.Close()
+
+	// client of test server with redirect policy of webhook client
+	client := receiver.Client()
+	client.CheckRedirect = NewWebhookClient(time.Second).CheckRedirect
+
+	var saved *models.DeliveryResult
+	wr := new(mocks.WebhookRepository)
+	wr.On("ClaimDeliveries", mock.Anything, deliveryBatchSize, mock.Anything).Return(
+		[]*models.WebhookJob{{DeliveryID: 1, URL: receiver.URL, Activity: &models.Activity{}}}, nil,
+	)
+	wr.On("SaveResult", mock.Anything, mock.Anything, 5).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*models.DeliveryResult)
+	}).Return(nil)
+
+	ws := NewWebhookService(wr, client, 3, time.Second, 5)
+
+	require.NoError(t, ws.Deliver(context.Background()))
+	require.Equal(t, http.StatusFound, *saved.ResponseCode)
+	require.NotEmpty(t, saved.Error)
+}
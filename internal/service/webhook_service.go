@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+)
+
+// number of deliveries claimed by one Deliver call
+const deliveryBatchSize = 10
+
+// SignatureHeader contains hex HMAC-SHA256 of request body with "sha256=" prefix
+const SignatureHeader = "X-Todo-Signature"
+
+type WebhookService struct {
+	repo        models.WebhookRepository
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	maxFailures int
+}
+
+// NewWebhookService returns service of list webhooks. Failed delivery is retried
+// maxAttempts times with exponential backoff, webhook is disabled after maxFailures
+// consecutive failed attempts
+func NewWebhookService(
+	repo models.WebhookRepository,
+	client *http.Client,
+	maxAttempts int,
+	backoff time.Duration,
+	maxFailures int) models.WebhookService {
+
+	return &WebhookService{
+		repo:        repo,
+		client:      client,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		maxFailures: maxFailures,
+	}
+}
+
+// Sign returns value of signature header for body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// validateWebhookURL refuses url of internal host given by address or localhost,
+// other hosts are checked when delivery connects
+func validateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.ErrBadWebhookURL
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return models.ErrBadWebhookURL
+	}
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return models.ErrBadWebhookURL
+	}
+	return nil
+}
+
+func validateWebhookEvents(events []string) error {
+	for _, event := range events {
+		if !models.IsAction(event) {
+			return models.ErrBadWebhookEvent
+		}
+	}
+	return nil
+}
+
+func (ws *WebhookService) Create(ctx context.Context, listID, userID int64, req *models.CreateWebhookReq) (int64, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return 0, err
+	}
+
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return 0, err
+	}
+
+	events := req.Events
+	if events == nil {
+		events = []string{}
+	}
+
+	return ws.repo.Create(ctx, &models.Webhook{
+		ListID:    listID,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    events,
+		CreatedBy: &userID,
+	})
+}
+
+func (ws *WebhookService) GetWebhooks(ctx context.Context, listID int64) ([]*models.Webhook, error) {
+	return ws.repo.GetWebhooks(ctx, listID)
+}
+
+func (ws *WebhookService) Update(ctx context.Context, listID, webhookID int64, req *models.UpdateWebhookReq) error {
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return err
+		}
+	}
+
+	if req.Events != nil {
+		if err := validateWebhookEvents(*req.Events); err != nil {
+			return err
+		}
+	}
+
+	return ws.repo.Update(ctx, listID, webhookID, req)
+}
+
+func (ws *WebhookService) Delete(ctx context.Context, listID, webhookID int64) error {
+	return ws.repo.Delete(ctx, listID, webhookID)
+}
+
+func (ws *WebhookService) GetDeliveries(ctx context.Context, listID, webhookID int64, page *models.PageReq) ([]*models.WebhookDelivery, string, error) {
+	if err := normalizePage(page); err != nil {
+		return nil, "", err
+	}
+
+	if _, err := ws.repo.GetWebhookByID(ctx, listID, webhookID); err != nil {
+		return nil, "", err
+	}
+
+	return ws.repo.GetDeliveries(ctx, webhookID, page)
+}
+
+// Deliver sends due pending deliveries until there are no more of them
+func (ws *WebhookService) Deliver(ctx context.Context) error {
+	// deliveries of one webhook are sent one by one, lease covers timeouts of all of them
+	lease := ws.client.Timeout*deliveryBatchSize + time.Minute
+
+	for {
+		jobs, err := ws.repo.ClaimDeliveries(ctx, deliveryBatchSize, lease)
+		if err != nil {
+			return err
+		}
+
+		if err := ws.deliverBatch(ctx, jobs); err != nil {
+			return err
+		}
+
+		if len(jobs) < deliveryBatchSize {
+			return nil
+		}
+	}
+}
+
+// deliverBatch sends deliveries of different webhooks concurrently, so slow receiver
+// doesn't delay others. Deliveries of one webhook are sent in order of claim.
+// Number of goroutines is bounded by batch size
+func (ws *WebhookService) deliverBatch(ctx context.Context, jobs []*models.WebhookJob) error {
+	byWebhook := map[int64][]*models.WebhookJob{}
+	order := []int64{}
+	for _, job := range jobs {
+		if _, ok := byWebhook[job.WebhookID]; !ok {
+			order = append(order, job.WebhookID)
+		}
+		byWebhook[job.WebhookID] = append(byWebhook[job.WebhookID], job)
+	}
+
+	errs := make(chan error, len(order))
+	for _, webhookID := range order {
+		go func(jobs []*models.WebhookJob) {
+			for _, job := range jobs {
+				if err := ws.repo.SaveResult(ctx, ws.send(ctx, job), ws.maxFailures); err != nil {
+					errs <- err
+					return
+				}
+			}
+			errs <- nil
+		}(byWebhook[webhookID])
+	}
+
+	var firstErr error
+	for range order {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// send makes delivery attempt and schedules next one if it fails
+func (ws *WebhookService) send(ctx context.Context, job *models.WebhookJob) *models.DeliveryResult {
+	result := &models.DeliveryResult{
+		DeliveryID: job.DeliveryID,
+		WebhookID:  job.WebhookID,
+	}
+
+	code, err := ws.post(ctx, job)
+	if code != 0 {
+		result.ResponseCode = &code
+	}
+
+	if err == nil {
+		return result
+	}
+
+	result.Error = err.Error()
+
+	attempts := job.Attempts + 1
+	if attempts < ws.maxAttempts {
+		next := time.Now().Add(ws.backoff << (attempts - 1))
+		result.NextAttemptAt = &next
+	}
+
+	return result
+}
+
+func (ws *WebhookService) post(ctx context.Context, job *models.WebhookJob) (int, error) {
+	body, err := json.Marshal(job.Activity)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-app-webhook")
+	req.Header.Set("X-Todo-Event", job.Activity.Action)
+	req.Header.Set("X-Todo-Delivery", strconv.FormatInt(job.DeliveryID, 10))
+	req.Header.Set(SignatureHeader, Sign(job.Secret, body))
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// body is drained, so connection can be reused
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
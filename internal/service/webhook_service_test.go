@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *models.CreateWebhookReq
+		retErr error
+		expID  int64
+		expErr error
+	}{
+		{
+			name:   "Bad url scheme",
+			req:    &models.CreateWebhookReq{URL: "ftp://example.com", Secret: "s"},
+			expErr: models.ErrBadWebhookURL,
+		},
+		{
+			name:   "Relative url",
+			req:    &models.CreateWebhookReq{URL: "/hook", Secret: "s"},
+			expErr: models.ErrBadWebhookURL,
+		},
+		{
+			name:   "Loopback address",
+			req:    &models.CreateWebhookReq{URL: "http://127.0.0.1:8080/hook", Secret: "s"},
+			expErr: models.ErrBadWebhookURL,
+		},
+		{
+			name:   "Metadata address",
+			req:    &models.CreateWebhookReq{URL: "http://169.254.169.254/latest", Secret: "s"},
+			expErr: models.ErrBadWebhookURL,
+		},
+		{
+			name:   "Localhost",
+			req:    &models.CreateWebhookReq{URL: "http://localhost/hook", Secret: "s"},
+			expErr: models.ErrBadWebhookURL,
+		},
+		{
+			name: "Unknown event",
+			req: &models.CreateWebhookReq{
+				URL: "https://example.com/hook", Secret: "s", Events: []string{"list.explode"},
+			},
+			expErr: models.ErrBadWebhookEvent,
+		},
+		{
+			name:   "Create return error",
+			req:    &models.CreateWebhookReq{URL: "https://example.com/hook", Secret: "s"},
+			retErr: ErrSome,
+			expErr: ErrSome,
+		},
+		{
+			name: "Success create",
+			req: &models.CreateWebhookReq{
+				URL: "https://example.com/hook", Secret: "s", Events: []string{models.ActionItemCreate},
+			},
+			expID: 7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wr := new(mocks.WebhookRepository)
+			wr.On("Create", mock.Anything, mock.MatchedBy(func(w *models.Webhook) bool {
+				return w.ListID == 1 && *w.CreatedBy == 2 && w.Events != nil
+			})).Return(tc.expID, tc.retErr)
+
+			ws := NewWebhookService(wr, http.DefaultClient, 3, time.Second, 5)
+
+			id, err := ws.Create(context.Background(), 1, 2, tc.req)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expID, id)
+		})
+	}
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	badURL := "example.com"
+	badEvents := []string{"bad"}
+
+	tests := []struct {
+		name   string
+		req    *models.UpdateWebhookReq
+		retErr error
+		expErr error
+	}{
+		{
+			name:   "Bad url",
+			req:    &models.UpdateWebhookReq{URL: &badURL},
+			expErr: models.ErrBadWebhookURL,
+		},
+		{
+			name:   "Unknown event",
+			req:    &models.UpdateWebhookReq{Events: &badEvents},
+			expErr: models.ErrBadWebhookEvent,
+		},
+		{
+			name:   "Webhook not found",
+			req:    &models.UpdateWebhookReq{},
+			retErr: models.ErrNoWebhook,
+			expErr: models.ErrNoWebhook,
+		},
+		{
+			name: "Success update",
+			req:  &models.UpdateWebhookReq{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wr := new(mocks.WebhookRepository)
+			wr.On("Update", mock.Anything, int64(1), int64(2), tc.req).Return(tc.retErr)
+
+			ws := NewWebhookService(wr, http.DefaultClient, 3, time.Second, 5)
+
+			require.Equal(t, tc.expErr, ws.Update(context.Background(), 1, 2, tc.req))
+		})
+	}
+}
+
+func TestGetDeliveries(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    *models.PageReq
+		getErr  error
+		expNext string
+		expErr  error
+	}{
+		{
+			name:   "Bad page limit",
+			page:   &models.PageReq{Limit: 1000},
+			expErr: models.ErrBadPageLimit,
+		},
+		{
+			name:   "Webhook not found",
+			page:   &models.PageReq{},
+			getErr: models.ErrNoWebhook,
+			expErr: models.ErrNoWebhook,
+		},
+		{
+			name:    "Success get",
+			page:    &models.PageReq{},
+			expNext: "next",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wr := new(mocks.WebhookRepository)
+			wr.On("GetWebhookByID", mock.Anything, int64(1), int64(2)).Return(&models.Webhook{ID: 2}, tc.getErr)
+			wr.On("GetDeliveries", mock.Anything, int64(2), tc.page).Return(
+				[]*models.WebhookDelivery{}, "next", nil,
+			)
+
+			ws := NewWebhookService(wr, http.DefaultClient, 3, time.Second, 5)
+
+			_, next, err := ws.GetDeliveries(context.Background(), 1, 2, tc.page)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expNext, next)
+		})
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        int
+		attempts    int
+		expCode     int
+		expError    bool
+		expNextIn   time.Duration
+		expNoRetry  bool
+		expSignedOK bool
+	}{
+		{
+			name:        "Success delivery",
+			code:        http.StatusNoContent,
+			expCode:     http.StatusNoContent,
+			expSignedOK: true,
+		},
+		{
+			name:        "First failure is retried after backoff",
+			code:        http.StatusInternalServerError,
+			expCode:     http.StatusInternalServerError,
+			expError:    true,
+			expNextIn:   time.Minute,
+			expSignedOK: true,
+		},
+		{
+			name:        "Backoff grows exponentially",
+			code:        http.StatusBadGateway,
+			attempts:    1,
+			expCode:     http.StatusBadGateway,
+			expError:    true,
+			expNextIn:   2 * time.Minute,
+			expSignedOK: true,
+		},
+		{
+			name:        "Last attempt is not retried",
+			code:        http.StatusNotFound,
+			attempts:    2,
+			expCode:     http.StatusNotFound,
+			expError:    true,
+			expNoRetry:  true,
+			expSignedOK: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			signedOK := false
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				signedOK = r.Header.Get(SignatureHeader) == Sign("secret", body) &&
+					r.Header.Get("X-Todo-Event") == models.ActionItemCreate &&
+					r.Header.Get("X-Todo-Delivery") == "11"
+				w.WriteHeader(tc.code)
+			}))
+			defer receiver.Close()
+
+			job := &models.WebhookJob{
+				DeliveryID: 11,
+				WebhookID:  3,
+				Attempts:   tc.attempts,
+				URL:        receiver.URL,
+				Secret:     "secret",
+				Activity:   &models.Activity{ID: 5, ListID: 1, Action: models.ActionItemCreate},
+			}
+
+			var saved *models.DeliveryResult
+			wr := new(mocks.WebhookRepository)
+			wr.On("ClaimDeliveries", mock.Anything, deliveryBatchSize, mock.Anything).Return(
+				[]*models.WebhookJob{job}, nil,
+			)
+			wr.On("SaveResult", mock.Anything, mock.Anything, 5).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(*models.DeliveryResult)
+			}).Return(nil)
+
+			ws := NewWebhookService(wr, receiver.Client(), 3, time.Minute, 5)
+
+			start := time.Now()
+			require.NoError(t, ws.Deliver(context.Background()))
+			require.Equal(t, tc.expSignedOK, signedOK)
+
+			require.NotNil(t, saved)
+			require.Equal(t, int64(11), saved.DeliveryID)
+			require.Equal(t, int64(3), saved.WebhookID)
+			require.Equal(t, tc.expCode, *saved.ResponseCode)
+			require.Equal(t, tc.expError, saved.Error != "")
+
+			if !tc.expError || tc.expNoRetry {
+				require.Nil(t, saved.NextAttemptAt)
+			} else {
+				require.NotNil(t, saved.NextAttemptAt)
+				require.WithinDuration(t, start.Add(tc.expNextIn), *saved.NextAttemptAt, 5*time.Second)
+			}
+		})
+	}
+}
+
+func TestDeliverConcurrently(t *testing.T) {
+	fastDone := make(chan struct{})
+	var mu sync.Mutex
+	slowOrder := []string{}
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fast" {
+			close(fastDone)
+			return
+		}
+
+		// slow webhook waits for delivery of fast one, so they are sent concurrently
+		select {
+		case <-fastDone:
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}
+		mu.Lock()
+		slowOrder = append(slowOrder, r.Header.Get("X-Todo-Delivery"))
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	jobs := []*models.WebhookJob{
+		{DeliveryID: 1, WebhookID: 1, URL: receiver.URL + "/slow", Activity: &models.Activity{}},
+		{DeliveryID: 2, WebhookID: 1, URL: receiver.URL + "/slow", Activity: &models.Activity{}},
+		{DeliveryID: 3, WebhookID: 2, URL: receiver.URL + "/fast", Activity: &models.Activity{}},
+	}
+
+	saved := make(chan *models.DeliveryResult, len(jobs))
+	wr := new(mocks.WebhookRepository)
+	wr.On("ClaimDeliveries", mock.Anything, deliveryBatchSize, mock.Anything).Return(jobs, nil)
+	wr.On("SaveResult", mock.Anything, mock.Anything, 5).Run(func(args mock.Arguments) {
+		saved <- args.Get(1).(*models.DeliveryResult)
+	}).Return(nil)
+
+	ws := NewWebhookService(wr, receiver.Client(), 3, time.Second, 5)
+
+	require.NoError(t, ws.Deliver(context.Background()))
+	close(saved)
+	for result := range saved {
+		require.Empty(t, result.Error)
+	}
+	require.Equal(t, []string{"1", "2"}, slowOrder)
+}
+
+func TestDeliverErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		claimErr error
+		saveErr  error
+		expErr   error
+	}{
+		{
+			name:     "ClaimDeliveries return error",
+			claimErr: ErrSome,
+			expErr:   ErrSome,
+		},
+		{
+			name:    "SaveResult return error",
+			saveErr: ErrSome,
+			expErr:  ErrSome,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &models.WebhookJob{
+				DeliveryID: 1,
+				URL:        "http://127.0.0.1:1/unreachable",
+				Activity:   &models.Activity{},
+			}
+
+			wr := new(mocks.WebhookRepository)
+			wr.On("ClaimDeliveries", mock.Anything, mock.Anything, mock.Anything).Return(
+				[]*models.WebhookJob{job}, tc.claimErr,
+			)
+			wr.On("SaveResult", mock.Anything, mock.MatchedBy(func(r *models.DeliveryResult) bool {
+				return r.Error != "" && r.ResponseCode == nil
+			}), mock.Anything).Return(tc.saveErr)
+
+			ws := NewWebhookService(wr, &http.Client{Timeout: time.Second}, 3, time.Second, 5)
+
+			require.Equal(t, tc.expErr, ws.Deliver(context.Background()))
+		})
+	}
+}
@@ -88,8 +88,8 @@ func (suite *TestingSuite) TestSignUp() {
 				resp := handler.ErrorResponse{}
 				err := json.Unmarshal(data, &resp)
 				require.NoError(t, err)
-				require.Equal(t, "error", resp.Status)
-				require.Equal(t, tc.errMsg, resp.Message)
+				require.Equal(t, tc.code, resp.Status)
+				require.Equal(t, tc.errMsg, resp.Detail)
 			}
 		})
 	}
@@ -112,13 +112,13 @@ func (suite *TestingSuite) TestEmailConfirmation() {
 			"Already confirmation",
 			confirmedUser.ActivatedLink,
 			http.StatusNotFound,
-			"Page not found",
+			models.ErrConfirmLinkNotExists.Error(),
 		},
 		{
 			"Unknown confirmation link",
 			unknownConfLink,
 			http.StatusNotFound,
-			"Page not found",
+			models.ErrConfirmLinkNotExists.Error(),
 		},
 	}
 
@@ -137,8 +137,8 @@ func (suite *TestingSuite) TestEmailConfirmation() {
 				resp := handler.ErrorResponse{}
 				err := json.Unmarshal(data, &resp)
 				require.NoError(t, err)
-				require.Equal(t, "error", resp.Status)
-				require.Equal(t, tc.errMsg, resp.Message)
+				require.Equal(t, tc.code, resp.Status)
+				require.Equal(t, tc.errMsg, resp.Detail)
 
 			}
 		})
@@ -223,8 +223,8 @@ func (suite *TestingSuite) TestSignIn() {
 				resp := handler.ErrorResponse{}
 				err := json.Unmarshal(data, &resp)
 				require.NoError(t, err)
-				require.Equal(t, "error", resp.Status)
-				require.Equal(t, tc.errMsg, resp.Message)
+				require.Equal(t, tc.code, resp.Status)
+				require.Equal(t, tc.errMsg, resp.Detail)
 			} else if tc.code == http.StatusOK {
 				tokenResp := rawToTokensResponse(t, data)
 				makeLogout(t, suite.router, tokenResp)
@@ -315,8 +315,8 @@ func (suite *TestingSuite) TestRefresh() {
 				resp := handler.ErrorResponse{}
 				err := json.Unmarshal(data, &resp)
 				require.NoError(t, err)
-				require.Equal(t, "error", resp.Status)
-				require.Equal(t, tc.errMsg, resp.Message)
+				require.Equal(t, tc.code, resp.Status)
+				require.Equal(t, tc.errMsg, resp.Detail)
 			} else if tc.code == http.StatusOK {
 				authResp := rawToTokensResponse(t, data)
 				makeLogout(t, suite.router, authResp)
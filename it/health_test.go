@@ -0,0 +1,29 @@
+package it
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/VladimirStepanov/todo-app/internal/helpers"
+	"github.com/VladimirStepanov/todo-app/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *TestingSuite) TestReadyz() {
+	code, data := helpers.MakeRequest(
+		suite.router,
+		suite.T(),
+		http.MethodGet,
+		"/readyz",
+		bytes.NewBuffer([]byte{}),
+		nil,
+	)
+	require.Equal(suite.T(), http.StatusOK, code)
+
+	report := &models.HealthReport{}
+	require.NoError(suite.T(), json.Unmarshal(data, report))
+	require.Equal(suite.T(), models.HealthUp, report.Status)
+	require.Equal(suite.T(), models.HealthUp, report.Checks["postgres"].Status)
+	require.Equal(suite.T(), models.HealthUp, report.Checks["redis"].Status)
+}
@@ -118,7 +118,7 @@ func (suite *TestingSuite) TestGetItemByID() {
 				errResp := &handler.ErrorResponse{}
 				err := json.Unmarshal(responseData, errResp)
 				require.NoError(t, err)
-				require.Equal(t, tc.expErrMsg, errResp.Message)
+				require.Equal(t, tc.expErrMsg, errResp.Detail)
 			} else {
 				item := &models.Item{}
 				err := json.Unmarshal(responseData, item)
@@ -199,7 +199,7 @@ func (suite *TestingSuite) TestDeleteItem() {
 				errResp := &handler.ErrorResponse{}
 				err := json.Unmarshal(responseData, errResp)
 				require.NoError(t, err)
-				require.Equal(t, tc.expErrMsg, errResp.Message)
+				require.Equal(t, tc.expErrMsg, errResp.Detail)
 			} else {
 				t.Run("Check delete item result", func(t *testing.T) {
 					code, _ := helpers.MakeRequest(
@@ -292,7 +292,7 @@ func (suite *TestingSuite) TestUpdateItem() {
 				errResp := &handler.ErrorResponse{}
 				err := json.Unmarshal(responseData, errResp)
 				require.NoError(t, err)
-				require.Equal(t, tc.expErrMsg, errResp.Message)
+				require.Equal(t, tc.expErrMsg, errResp.Detail)
 			} else {
 				t.Run("Check update result", func(t *testing.T) {
 					code, getData := helpers.MakeRequest(
@@ -381,7 +381,7 @@ func (suite *TestingSuite) TestDoneItem() {
 				errResp := &handler.ErrorResponse{}
 				err := json.Unmarshal(responseData, errResp)
 				require.NoError(t, err)
-				require.Equal(t, tc.expErrMsg, errResp.Message)
+				require.Equal(t, tc.expErrMsg, errResp.Detail)
 			} else {
 				t.Run("Check done result", func(t *testing.T) {
 					code, getData := helpers.MakeRequest(
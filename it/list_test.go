@@ -97,7 +97,7 @@ func (suite *TestingSuite) TestGetListByID() {
 				errResp := &handler.ErrorResponse{}
 				err := json.Unmarshal(responseData, errResp)
 				require.NoError(t, err)
-				require.Equal(t, tc.expErrMsg, errResp.Message)
+				require.Equal(t, tc.expErrMsg, errResp.Detail)
 			} else {
 				userList := &models.List{}
 				err := json.Unmarshal(responseData, userList)
@@ -185,7 +185,7 @@ func (suite *TestingSuite) TestEditRole() {
 				errResp := &handler.ErrorResponse{}
 				err := json.Unmarshal(responseData, errResp)
 				require.NoError(t, err)
-				require.Equal(t, tc.expErrMsg, errResp.Message)
+				require.Equal(t, tc.expErrMsg, errResp.Detail)
 			} else {
 				actResp := map[string]interface{}{}
 				err := json.Unmarshal(responseData, &actResp)
@@ -261,7 +261,7 @@ func (suite *TestingSuite) TestDeleteList() {
 				errResp := &handler.ErrorResponse{}
 				err := json.Unmarshal(responseData, errResp)
 				require.NoError(t, err)
-				require.Equal(t, tc.expErrMsg, errResp.Message)
+				require.Equal(t, tc.expErrMsg, errResp.Detail)
 			} else {
 				actResp := map[string]interface{}{}
 				err := json.Unmarshal(responseData, &actResp)
@@ -364,7 +364,7 @@ func (suite *TestingSuite) TestUpdateList() {
 				errResp := &handler.ErrorResponse{}
 				err := json.Unmarshal(responseData, errResp)
 				require.NoError(t, err)
-				require.Equal(t, tc.expErrMsg, errResp.Message)
+				require.Equal(t, tc.expErrMsg, errResp.Detail)
 			} else {
 				actResp := map[string]interface{}{}
 				err := json.Unmarshal(responseData, &actResp)
@@ -6,10 +6,12 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/VladimirStepanov/todo-app/internal/handler"
 	"github.com/VladimirStepanov/todo-app/internal/models"
 	"github.com/VladimirStepanov/todo-app/internal/models/mocks"
+	"github.com/VladimirStepanov/todo-app/internal/repository/blobstore"
 	"github.com/VladimirStepanov/todo-app/internal/repository/postgres"
 	"github.com/VladimirStepanov/todo-app/internal/repository/redisrepo"
 	"github.com/VladimirStepanov/todo-app/internal/service"
@@ -213,7 +215,7 @@ func (suite *TestingSuite) SetupSuite() {
 	db, err := postgres.NewDB(
 		os.Getenv("POSTGRES_HOST"), os.Getenv("POSTGRES_PORT"),
 		os.Getenv("POSTGRES_USER"), os.Getenv("POSTGRES_PASSWORD"),
-		os.Getenv("POSTGRES_DB"), "disable",
+		os.Getenv("POSTGRES_DB"), "disable", 5*time.Second,
 	)
 
 	if err != nil {
@@ -233,22 +235,49 @@ func (suite *TestingSuite) SetupSuite() {
 
 	repo := postgres.NewPostgresUserRepository(db)
 	listRepo := postgres.NewPostgresListRepository(db)
-	tokenRepo := redisrepo.NewRedisRepository(redisClient)
+	tokenRepo := redisrepo.NewRedisRepository(redisClient, 3*time.Second)
 	itemRepo := postgres.NewPostgresItemRepository(db)
+	commentRepo := postgres.NewPostgresCommentRepository(db)
+	attachmentRepo := postgres.NewPostgresAttachmentRepository(db)
+	searchRepo := postgres.NewPostgresSearchRepository(db)
+	activityRepo := postgres.NewPostgresActivityRepository(db)
+	transactor := postgres.NewPostgresTransactor(db)
+	eventBus := redisrepo.NewRedisEventBus(redisClient, 3*time.Second)
+	blobStore, err := blobstore.NewLocalStore(suite.T().TempDir())
+	if err != nil {
+		suite.T().Fatal("Can't create blob store", err)
+	}
 	userService := service.NewUserService(repo)
 	tokenService := service.NewTokenService(
-		accessKey, refreshKey, maxLoggenInCount, tokenRepo,
+		accessKey, refreshKey, 15*time.Minute, 7*24*time.Hour,
+		maxLoggenInCount, tokenRepo,
 	)
-	listService := service.NewListService(listRepo)
-	itemService := service.NewItemService(itemRepo)
+	listService := service.NewListService(listRepo, activityRepo, transactor, eventBus)
 	msObj := new(mocks.MailService)
-	msObj.On("SendConfirmationsEmail", mock.Anything).Return(nil)
+	msObj.On("SendConfirmationsEmail", mock.Anything, mock.Anything).Return(nil)
+	msObj.On(
+		"SendMentionEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return(nil)
+	msObj.On("SendAssignEmail", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	itemService := service.NewItemService(itemRepo, listRepo, activityRepo, transactor, eventBus, msObj)
+	commentService := service.NewCommentService(commentRepo, itemRepo, listRepo, msObj)
+	attachmentService := service.NewAttachmentService(
+		attachmentRepo, itemRepo, blobStore, 1<<20, []string{"application/pdf"},
+	)
+	searchService := service.NewSearchService(searchRepo, "english")
+	trashService := service.NewTrashService(listRepo, itemRepo, activityRepo, transactor, eventBus, time.Hour)
+	eventService := service.NewEventService(eventBus)
+	healthService := service.NewHealthService(map[string]models.HealthChecker{
+		"postgres": postgres.NewPostgresHealthChecker(db),
+		"redis":    redisrepo.NewRedisHealthChecker(redisClient, 3*time.Second),
+	}, time.Second)
 	logger := logrus.New()
 	logger.Out = ioutil.Discard
 	suite.router = handler.New(
 		userService, msObj,
 		tokenService, listService, itemService,
-		logger).InitRoutes(gin.TestMode)
+		commentService, attachmentService, searchService, trashService,
+		eventService, nil, nil, nil, nil, healthService, logger).InitRoutes(gin.TestMode)
 }
 
 func TestSuite(t *testing.T) {
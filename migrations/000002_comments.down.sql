@@ -0,0 +1 @@
+drop table comments;
\ No newline at end of file
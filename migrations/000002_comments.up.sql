@@ -0,0 +1,10 @@
+create table comments (
+    id serial primary key,
+    item_id integer not null,
+    user_id integer not null,
+    body text not null,
+    CONSTRAINT fk_items_id FOREIGN KEY(item_id) REFERENCES items(id) ON DELETE CASCADE,
+    CONSTRAINT fk_users_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+create index idx_comments_item_id on comments(item_id);
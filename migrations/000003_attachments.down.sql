@@ -0,0 +1,4 @@
+drop trigger attachments_queue_deleted_blob on attachments;
+drop function queue_deleted_blob;
+drop table deleted_blobs;
+drop table attachments;
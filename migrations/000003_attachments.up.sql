@@ -0,0 +1,30 @@
+create table attachments (
+    id serial primary key,
+    item_id integer not null,
+    user_id integer not null,
+    file_name varchar(255) not null,
+    content_type varchar(127) not null,
+    size bigint not null,
+    storage_key varchar(255) not null unique,
+    CONSTRAINT fk_items_id FOREIGN KEY(item_id) REFERENCES items(id) ON DELETE CASCADE,
+    CONSTRAINT fk_users_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+create index idx_attachments_item_id on attachments(item_id);
+
+-- storage keys of deleted attachments (including ones removed by cascades
+-- from items and lists) waiting for blob removal
+create table deleted_blobs (
+    storage_key varchar(255) primary key
+);
+
+create function queue_deleted_blob() returns trigger as $$
+begin
+    insert into deleted_blobs(storage_key) values (OLD.storage_key) on conflict do nothing;
+    return OLD;
+end;
+$$ language plpgsql;
+
+create trigger attachments_queue_deleted_blob
+    after delete on attachments
+    for each row execute procedure queue_deleted_blob();
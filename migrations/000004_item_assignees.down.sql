@@ -0,0 +1 @@
+alter table items drop column assignee_id;
@@ -0,0 +1,10 @@
+alter table items add column assignee_id integer;
+
+-- assignee must be a member of the item's list
+alter table items add CONSTRAINT fk_items_assignee_member
+    FOREIGN KEY(assignee_id, list_id) REFERENCES users_lists(user_id, list_id);
+
+alter table items add CONSTRAINT fk_items_assignee_id
+    FOREIGN KEY(assignee_id) REFERENCES users(id) ON DELETE SET NULL;
+
+create index idx_items_assignee_id on items(assignee_id);
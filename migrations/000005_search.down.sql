@@ -0,0 +1,2 @@
+alter table items drop column search_vector;
+alter table lists drop column search_vector;
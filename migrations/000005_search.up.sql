@@ -0,0 +1,18 @@
+-- documents are indexed with both english and russian configurations,
+-- so a query in either language matches stemmed words of the other one
+alter table lists add column search_vector tsvector generated always as (
+    setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('russian', title), 'A') ||
+    setweight(to_tsvector('english', description), 'B') ||
+    setweight(to_tsvector('russian', description), 'B')
+) stored;
+
+alter table items add column search_vector tsvector generated always as (
+    setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('russian', title), 'A') ||
+    setweight(to_tsvector('english', description), 'B') ||
+    setweight(to_tsvector('russian', description), 'B')
+) stored;
+
+create index idx_lists_search_vector on lists using gin(search_vector);
+create index idx_items_search_vector on items using gin(search_vector);
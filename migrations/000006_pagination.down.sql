@@ -0,0 +1,5 @@
+alter table items drop column priority;
+alter table items drop column due_at;
+alter table items drop column created_at;
+
+alter table lists drop column created_at;
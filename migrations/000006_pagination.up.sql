@@ -0,0 +1,12 @@
+alter table lists add column created_at timestamptz not null default now();
+
+alter table items add column created_at timestamptz not null default now();
+alter table items add column due_at timestamptz;
+alter table items add column priority smallint not null default 0;
+
+-- indexes for keyset pagination, items without due date are sorted last
+create index idx_lists_created_at on lists(created_at, id);
+create index idx_items_list_created_at on items(list_id, created_at, id);
+create index idx_items_list_title on items(list_id, title, id);
+create index idx_items_list_due_at on items(list_id, (coalesce(due_at, 'infinity'::timestamptz)), id);
+create index idx_items_list_priority on items(list_id, priority, id);
@@ -0,0 +1,11 @@
+drop trigger items_set_updated_at on items;
+drop trigger lists_set_updated_at on lists;
+drop function set_updated_at;
+
+alter table items drop column updated_by;
+alter table items drop column created_by;
+alter table items drop column updated_at;
+
+alter table lists drop column updated_by;
+alter table lists drop column created_by;
+alter table lists drop column updated_at;
@@ -0,0 +1,36 @@
+alter table lists add column updated_at timestamptz not null default now();
+alter table lists add column created_by integer;
+alter table lists add column updated_by integer;
+alter table lists add CONSTRAINT fk_lists_created_by
+    FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL;
+alter table lists add CONSTRAINT fk_lists_updated_by
+    FOREIGN KEY(updated_by) REFERENCES users(id) ON DELETE SET NULL;
+
+alter table items add column updated_at timestamptz not null default now();
+alter table items add column created_by integer;
+alter table items add column updated_by integer;
+alter table items add CONSTRAINT fk_items_created_by
+    FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL;
+alter table items add CONSTRAINT fk_items_updated_by
+    FOREIGN KEY(updated_by) REFERENCES users(id) ON DELETE SET NULL;
+
+-- authors of existing lists are unknown, the first admin is the best guess
+update lists l set created_by = (
+    select min(ul.user_id) from users_lists ul where ul.list_id = l.id and ul.is_admin
+);
+update lists set updated_by = created_by;
+
+create function set_updated_at() returns trigger as $$
+begin
+    NEW.updated_at = now();
+    return NEW;
+end;
+$$ language plpgsql;
+
+create trigger lists_set_updated_at
+    before update on lists
+    for each row execute procedure set_updated_at();
+
+create trigger items_set_updated_at
+    before update on items
+    for each row execute procedure set_updated_at();
@@ -0,0 +1,2 @@
+alter table items drop column version;
+alter table lists drop column version;
@@ -0,0 +1,3 @@
+-- version is incremented on every update and used as entity tag
+alter table lists add column version integer not null default 1;
+alter table items add column version integer not null default 1;
@@ -0,0 +1,16 @@
+-- append-only history of list and item mutations, removed together with the list
+create table activity (
+    id serial primary key,
+    list_id integer not null,
+    item_id integer,
+    user_id integer,
+    member_id integer,
+    action varchar(32) not null,
+    changes jsonb,
+    created_at timestamptz not null default now(),
+    CONSTRAINT fk_activity_list_id FOREIGN KEY(list_id) REFERENCES lists(id) ON DELETE CASCADE,
+    CONSTRAINT fk_activity_user_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_activity_member_id FOREIGN KEY(member_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+create index idx_activity_list_created_at on activity(list_id, created_at, id);
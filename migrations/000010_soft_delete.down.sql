@@ -0,0 +1,6 @@
+-- rows in trash are removed, otherwise they are restored by rollback
+delete from items where deleted_at is not null;
+delete from lists where deleted_at is not null;
+
+alter table items drop column deleted_at;
+alter table lists drop column deleted_at;
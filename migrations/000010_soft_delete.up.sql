@@ -0,0 +1,6 @@
+alter table lists add column deleted_at timestamptz;
+alter table items add column deleted_at timestamptz;
+
+-- indexes for trash and purge of deleted rows
+create index idx_lists_deleted_at on lists(deleted_at) where deleted_at is not null;
+create index idx_items_deleted_at on items(deleted_at) where deleted_at is not null;
@@ -0,0 +1 @@
+alter table lists drop column archived_at;
@@ -0,0 +1 @@
+alter table lists add column archived_at timestamptz;
@@ -0,0 +1,37 @@
+-- outgoing webhooks of list, empty events array matches all activity actions
+create table webhooks (
+    id serial primary key,
+    list_id integer not null,
+    url text not null,
+    secret varchar(255) not null,
+    events text[] not null default '{}',
+    active boolean not null default true,
+    -- consecutive failed attempts, webhook is disabled after too many of them
+    failures integer not null default 0,
+    created_by integer,
+    created_at timestamptz not null default now(),
+    CONSTRAINT fk_webhooks_list_id FOREIGN KEY(list_id) REFERENCES lists(id) ON DELETE CASCADE,
+    CONSTRAINT fk_webhooks_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+create index idx_webhooks_list_id on webhooks(list_id);
+
+-- delivery of activity to webhook, pending deliveries are sent by background worker
+create table webhook_deliveries (
+    id serial primary key,
+    webhook_id integer not null,
+    activity_id integer not null,
+    event varchar(32) not null,
+    status varchar(16) not null default 'pending',
+    attempts integer not null default 0,
+    response_code integer,
+    error text,
+    next_attempt_at timestamptz default now(),
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    CONSTRAINT fk_webhook_deliveries_webhook_id FOREIGN KEY(webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    CONSTRAINT fk_webhook_deliveries_activity_id FOREIGN KEY(activity_id) REFERENCES activity(id) ON DELETE CASCADE
+);
+
+create index idx_webhook_deliveries_webhook_id on webhook_deliveries(webhook_id, created_at, id);
+create index idx_webhook_deliveries_pending on webhook_deliveries(next_attempt_at) where status = 'pending';
@@ -0,0 +1,19 @@
+drop table sync_mutations;
+
+drop trigger users_lists_add_tombstone on users_lists;
+drop trigger items_add_tombstone on items;
+drop function add_member_tombstone;
+drop function add_item_tombstone;
+drop table sync_tombstones;
+
+drop trigger lists_restore_change_xid on lists;
+drop function bump_members_change_xid;
+drop trigger users_lists_set_change_xid on users_lists;
+drop trigger items_set_change_xid on items;
+drop trigger lists_set_change_xid on lists;
+drop function set_change_xid;
+
+drop index idx_users_lists_list_id;
+alter table users_lists drop column change_xid;
+alter table items drop column change_xid;
+alter table lists drop column change_xid;
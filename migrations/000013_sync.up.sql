@@ -0,0 +1,89 @@
+-- every insert and update of synced rows records id of writing transaction. Transaction ids
+-- are not in commit order, so sync token is the oldest transaction in progress when changes
+-- are read: rows of transactions which commit later have the same or greater id
+alter table lists add column change_xid xid8 not null default pg_current_xact_id();
+alter table items add column change_xid xid8 not null default pg_current_xact_id();
+-- membership change makes the whole list visible again to the member
+alter table users_lists add column change_xid xid8 not null default pg_current_xact_id();
+
+create index idx_lists_change_xid on lists(change_xid);
+create index idx_items_list_change_xid on items(list_id, change_xid);
+create index idx_users_lists_list_id on users_lists(list_id);
+
+create function set_change_xid() returns trigger as $$
+begin
+    NEW.change_xid = pg_current_xact_id();
+    return NEW;
+end;
+$$ language plpgsql;
+
+create trigger lists_set_change_xid
+    before update on lists
+    for each row execute procedure set_change_xid();
+
+create trigger items_set_change_xid
+    before update on items
+    for each row execute procedure set_change_xid();
+
+create trigger users_lists_set_change_xid
+    before update on users_lists
+    for each row execute procedure set_change_xid();
+
+-- items of restored list are sent again to clients which dropped them with the list
+create function bump_members_change_xid() returns trigger as $$
+begin
+    update users_lists set change_xid = pg_current_xact_id() where list_id = NEW.id;
+    return NEW;
+end;
+$$ language plpgsql;
+
+create trigger lists_restore_change_xid
+    after update of deleted_at on lists
+    for each row when (OLD.deleted_at is not null and NEW.deleted_at is null)
+    execute procedure bump_members_change_xid();
+
+-- rows removed for good, item_id is set for purged item, user_id for removed member
+create table sync_tombstones (
+    id serial primary key,
+    list_id integer not null,
+    item_id integer,
+    user_id integer,
+    change_xid xid8 not null default pg_current_xact_id(),
+    created_at timestamptz not null default now()
+);
+
+create index idx_sync_tombstones_list_id on sync_tombstones(list_id, change_xid);
+create index idx_sync_tombstones_user_id on sync_tombstones(user_id, change_xid);
+
+create function add_item_tombstone() returns trigger as $$
+begin
+    insert into sync_tombstones(list_id, item_id) values(OLD.list_id, OLD.id);
+    return OLD;
+end;
+$$ language plpgsql;
+
+create function add_member_tombstone() returns trigger as $$
+begin
+    insert into sync_tombstones(list_id, user_id) values(OLD.list_id, OLD.user_id);
+    return OLD;
+end;
+$$ language plpgsql;
+
+create trigger items_add_tombstone
+    after delete on items
+    for each row execute procedure add_item_tombstone();
+
+create trigger users_lists_add_tombstone
+    after delete on users_lists
+    for each row execute procedure add_member_tombstone();
+
+-- applied client mutations, mutation with the same client id is applied once
+create table sync_mutations (
+    user_id integer not null,
+    client_id varchar(64) not null,
+    list_id integer not null,
+    item_id integer,
+    created_at timestamptz not null default now(),
+    CONSTRAINT fk_sync_mutations_user_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT pk_sync_mutations PRIMARY KEY(user_id, client_id)
+);
@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	loggerKey
+)
+
+// WithRequestID returns copy of ctx with request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns request id from ctx or empty string
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithLogger returns copy of ctx with logger, it is used by code without own logger
+func WithLogger(ctx context.Context, logger *logrus.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// Detach returns background context with request id and logger of ctx,
+// it is used by work which must outlive cancellation of ctx
+func Detach(ctx context.Context) context.Context {
+	detached := WithRequestID(context.Background(), RequestID(ctx))
+	if logger, ok := ctx.Value(loggerKey).(*logrus.Logger); ok {
+		detached = WithLogger(detached, logger)
+	}
+	return detached
+}
+
+// FromContext returns entry of logger from ctx or of standard logger
+// with request id and trace ids from ctx
+func FromContext(ctx context.Context) *logrus.Entry {
+	logger, ok := ctx.Value(loggerKey).(*logrus.Logger)
+	if !ok {
+		logger = logrus.StandardLogger()
+	}
+	return Entry(logger, ctx)
+}
+
+// Entry returns logger entry with request id and trace ids from ctx
+func Entry(logger *logrus.Logger, ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(logger)
+	if id := RequestID(ctx); id != "" {
+		entry = entry.WithField("request_id", id)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		entry = entry.WithFields(logrus.Fields{
+			"trace_id": sc.TraceID().String(),
+			"span_id":  sc.SpanID().String(),
+		})
+	}
+	return entry
+}
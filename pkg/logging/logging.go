@@ -1,33 +1,91 @@
 package logging
 
 import (
+	"fmt"
 	"io"
 	"os"
 
 	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func GetLogger(logLevel string, logFile string) (*logrus.Logger, error) {
+// log formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
 
-	level, err := logrus.ParseLevel(logLevel)
-	if err != nil {
-		return nil, err
-	}
-	logger := logrus.New()
-	logger.SetFormatter(
-		&logrus.JSONFormatter{},
-	)
-	logger.SetLevel(level)
+// log outputs
+const (
+	OutputStdout = "stdout"
+	OutputFile   = "file"
+	OutputBoth   = "both"
+)
+
+type Options struct {
+	// Level is logrus level name: trace, debug, info, warn, error, fatal or panic
+	Level string
+	// Format is json or text
+	Format string
+	// Output is stdout, file or both
+	Output string
+	// File is path of log file, it is rotated when MaxSizeMB is reached
+	File       string
+	MaxSizeMB  int
+	MaxAgeDays int
+	MaxBackups int
+	Compress   bool
+}
 
-	file, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+// GetLogger returns logger configured by opts. Values of sensitive fields
+// and bearer tokens in messages are redacted by formatter
+func GetLogger(opts Options) (*logrus.Logger, error) {
+	logger := logrus.New()
 
-	if err != nil {
+	if err := SetLevel(logger, opts.Level); err != nil {
 		return nil, err
 	}
 
-	mw := io.MultiWriter(os.Stdout, file)
+	var formatter logrus.Formatter
+	switch opts.Format {
+	case FormatJSON, "":
+		formatter = &logrus.JSONFormatter{}
+	case FormatText:
+		formatter = &logrus.TextFormatter{FullTimestamp: true}
+	default:
+		return nil, fmt.Errorf("unknown log format %q", opts.Format)
+	}
+	logger.SetFormatter(&redactingFormatter{formatter: formatter})
+
+	file := &lumberjack.Logger{
+		Filename:   opts.File,
+		MaxSize:    opts.MaxSizeMB,
+		MaxAge:     opts.MaxAgeDays,
+		MaxBackups: opts.MaxBackups,
+		Compress:   opts.Compress,
+	}
 
-	logger.SetOutput(mw)
+	switch opts.Output {
+	case OutputStdout, "":
+		logger.SetOutput(os.Stdout)
+	case OutputFile:
+		logger.SetOutput(file)
+	case OutputBoth:
+		logger.SetOutput(io.MultiWriter(os.Stdout, file))
+	default:
+		return nil, fmt.Errorf("unknown log output %q", opts.Output)
+	}
 
 	return logger, nil
 }
+
+// SetLevel changes level of logger, it is safe to call while logger is used
+func SetLevel(logger *logrus.Logger, logLevel string) error {
+	level, err := logrus.ParseLevel(logLevel)
+	if err != nil {
+		return err
+	}
+
+	logger.SetLevel(level)
+	return nil
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// redacted replaces values of sensitive fields
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are parts of field names whose values are never logged
+var sensitiveKeys = []string{"password", "token", "secret", "authorization", "cookie"}
+
+// sensitiveValues match credentials inside messages, e.g. errors with request headers
+var sensitiveValues = regexp.MustCompile(`(?i)(bearer\s+|(?:access_token|refresh_token|password)=)[^\s&"]+`)
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactString(s string) string {
+	return sensitiveValues.ReplaceAllString(s, "${1}"+redacted)
+}
+
+// redactingFormatter redacts entry before formatting, entry of caller is not changed
+type redactingFormatter struct {
+	formatter logrus.Formatter
+}
+
+func (rf *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		switch {
+		case isSensitive(k):
+			data[k] = redacted
+		case k == logrus.ErrorKey:
+			if err, ok := v.(error); ok {
+				data[k] = redactString(err.Error())
+			} else {
+				data[k] = v
+			}
+		default:
+			if s, ok := v.(string); ok {
+				v = redactString(s)
+			}
+			data[k] = v
+		}
+	}
+
+	redactedEntry := *entry
+	redactedEntry.Data = data
+	redactedEntry.Message = redactString(entry.Message)
+
+	return rf.formatter.Format(&redactedEntry)
+}
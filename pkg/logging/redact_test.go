@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactingFormatter(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    string
+		fields logrus.Fields
+		expMsg string
+		expRes map[string]interface{}
+	}{
+		{
+			name:   "Sensitive fields",
+			msg:    "sign in",
+			fields: logrus.Fields{"password": "qwerty", "Authorization": "Bearer abc", "refresh_token": "r", "email": "user@mail.ru"},
+			expMsg: "sign in",
+			expRes: map[string]interface{}{"password": redacted, "Authorization": redacted, "refresh_token": redacted, "email": "user@mail.ru"},
+		},
+		{
+			name:   "Bearer token in message",
+			msg:    "bad header Bearer eyJhbGci.payload.sign",
+			expMsg: "bad header Bearer " + redacted,
+			expRes: map[string]interface{}{},
+		},
+		{
+			name:   "Credentials in error and string fields",
+			fields: logrus.Fields{logrus.ErrorKey: errors.New("parse password=qwerty&x=1"), "query": "access_token=abc"},
+			expRes: map[string]interface{}{logrus.ErrorKey: "parse password=" + redacted + "&x=1", "query": "access_token=" + redacted},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := logrus.New()
+			logger.SetOutput(buf)
+			logger.SetFormatter(&redactingFormatter{formatter: &logrus.JSONFormatter{}})
+
+			entry := logger.WithFields(tc.fields)
+			entry.Info(tc.msg)
+
+			res := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+			require.Equal(t, tc.expMsg, res["msg"])
+			for k, v := range tc.expRes {
+				require.Equal(t, v, res[k])
+			}
+
+			// entry of caller keeps original values
+			for k, v := range tc.fields {
+				require.Equal(t, v, entry.Data[k])
+			}
+		})
+	}
+}
+
+func TestGetLogger(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   Options
+		hasErr bool
+	}{
+		{
+			name:   "Bad level",
+			opts:   Options{Level: "loud"},
+			hasErr: true,
+		},
+		{
+			name:   "Bad format",
+			opts:   Options{Level: "info", Format: "xml"},
+			hasErr: true,
+		},
+		{
+			name:   "Bad output",
+			opts:   Options{Level: "info", Output: "syslog"},
+			hasErr: true,
+		},
+		{
+			name: "Success text stdout",
+			opts: Options{Level: "debug", Format: FormatText, Output: OutputStdout},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, err := GetLogger(tc.opts)
+			if tc.hasErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, logrus.DebugLevel, logger.GetLevel())
+
+			require.Error(t, SetLevel(logger, "loud"))
+			require.NoError(t, SetLevel(logger, "warn"))
+			require.Equal(t, logrus.WarnLevel, logger.GetLevel())
+		})
+	}
+}
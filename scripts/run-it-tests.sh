@@ -3,4 +3,4 @@
 sh scripts/wait-postgres.sh
 go run cmd/migrate/migrate.go -direction down
 go run cmd/migrate/migrate.go -direction up
-go test -v ./it
\ No newline at end of file
+go test -v ./it ./internal/repository/blobstore
\ No newline at end of file